@@ -0,0 +1,19 @@
+package api
+
+import "github.com/gofrs/uuid"
+
+// AssetDownloadPathPrefix is the path where the download handler for assets is mounted
+const AssetDownloadPathPrefix = "/assets/"
+
+// AssetVariantPathPrefix is the path where the handler for resized image variants is mounted
+const AssetVariantPathPrefix = "/assets/variants/"
+
+// AssetDownloadPath is the (unsigned) path for downloading an asset
+func AssetDownloadPath(assetID uuid.UUID) string {
+	return AssetDownloadPathPrefix + assetID.String()
+}
+
+// AssetVariantPath is the (unsigned) path for a resized variant of an image asset
+func AssetVariantPath(assetID uuid.UUID, variantName string) string {
+	return AssetVariantPathPrefix + assetID.String() + "/" + variantName
+}
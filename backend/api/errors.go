@@ -12,6 +12,15 @@ var ErrAuthTokenExpired = TypedError{"authTokenExpired", "auth token expired"}
 var ErrAuthenticationRequired = TypedError{"authenticationRequired", "authentication required"}
 var ErrCsrfTokenMissing = TypedError{"csrfTokenMissing", "CSRF token missing"}
 var ErrCsrfTokenInvalid = TypedError{"csrfTokenInvalid", "CSRF token invalid"}
+var ErrDepthLimitExceeded = TypedError{"depthLimitExceeded", "depth limit exceeded"}
+var ErrComplexityLimitExceeded = TypedError{"complexityLimitExceeded", "complexity limit exceeded"}
+var ErrPersistedQueryNotFound = TypedError{"persistedQueryNotFound", "persisted query not found"}
+var ErrOperationNotAllowlisted = TypedError{"operationNotAllowlisted", "operation is not allowlisted"}
+var ErrSubscriptionsUnavailable = TypedError{"subscriptionsUnavailable", "subscriptions are unavailable"}
+var ErrAssetDownloadUnavailable = TypedError{"assetDownloadUnavailable", "asset downloads are unavailable"}
+var ErrIdempotencyKeyInvalid = TypedError{"idempotencyKeyInvalid", "idempotency key is invalid"}
+var ErrIdempotencyKeyConflict = TypedError{"idempotencyKeyConflict", "idempotency key was already used for a different request"}
+var ErrIdempotencyKeyInProgress = TypedError{"idempotencyKeyInProgress", "request with idempotency key is still in progress"}
 
 type TypedError struct {
 	errorType string
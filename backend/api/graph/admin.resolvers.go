@@ -7,18 +7,25 @@ package graph
 import (
 	"context"
 
+	logger "github.com/apex/log"
+	fog_errors "github.com/friendsofgo/errors"
 	"github.com/gofrs/uuid"
+	"myvendor.mytld/myproject/backend/api"
 	"myvendor.mytld/myproject/backend/api/graph/generated"
 	"myvendor.mytld/myproject/backend/api/graph/helper"
+	"myvendor.mytld/myproject/backend/api/graph/loader"
 	"myvendor.mytld/myproject/backend/api/graph/model"
 	"myvendor.mytld/myproject/backend/domain/command"
 	"myvendor.mytld/myproject/backend/domain/query"
 	domain_model "myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/notification"
 	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/security/authorization"
 )
 
 // CreateAccount is the resolver for the createAccount field.
-func (r *mutationResolver) CreateAccount(ctx context.Context, role domain_model.Role, emailAddress string, password string, organisationID *uuid.UUID) (*model.Account, error) {
+func (r *mutationResolver) CreateAccount(ctx context.Context, role domain_model.Role, emailAddress string, password string, organisationID *uuid.UUID, idempotencyKey *string) (*model.Account, error) {
+	// idempotencyKey is handled by the idempotency extension before the operation is executed
 	cmd, err := command.NewAccountCreateCmd(emailAddress, domain_model.Role(role), password)
 	if err != nil {
 		return nil, err
@@ -43,7 +50,7 @@ func (r *mutationResolver) CreateAccount(ctx context.Context, role domain_model.
 }
 
 // UpdateAccount is the resolver for the updateAccount field.
-func (r *mutationResolver) UpdateAccount(ctx context.Context, id uuid.UUID, role domain_model.Role, emailAddress string, password *string, organisationID *uuid.UUID) (*model.Account, error) {
+func (r *mutationResolver) UpdateAccount(ctx context.Context, id uuid.UUID, role domain_model.Role, emailAddress string, password *string, organisationID *uuid.UUID, expectedVersion *int) (*model.Account, error) {
 	// Fetch previous record to get organisation id
 	prevRecord, err := r.finder.QueryAccount(ctx, query.AccountQuery{
 		AccountID: id,
@@ -56,6 +63,7 @@ func (r *mutationResolver) UpdateAccount(ctx context.Context, id uuid.UUID, role
 	if err != nil {
 		return nil, err
 	}
+	cmd.ExpectedVersion = expectedVersion
 	// Only set NewOrganisationID if the role fits (work around an issue with selecting an organisation and then changing the role in the admin UI)
 	if role != domain_model.RoleSystemAdministrator {
 		cmd.NewOrganisationID = helper.ToNullUUID(organisationID)
@@ -91,8 +99,33 @@ func (r *mutationResolver) DeleteAccount(ctx context.Context, id uuid.UUID) (*mo
 	return helper.MapToAccount(record), nil
 }
 
+// RestoreAccount is the resolver for the restoreAccount field.
+func (r *mutationResolver) RestoreAccount(ctx context.Context, id uuid.UUID) (*model.Account, error) {
+	record, err := r.finder.QueryDeletedAccount(ctx, query.AccountQuery{
+		AccountID: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := command.NewAccountRestoreCmd(id, record.OrganisationID)
+	err = r.handler.AccountRestore(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err = r.finder.QueryAccount(ctx, query.AccountQuery{
+		AccountID: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return helper.MapToAccount(record), nil
+}
+
 // CreateOrganisation is the resolver for the createOrganisation field.
-func (r *mutationResolver) CreateOrganisation(ctx context.Context, name string) (*model.Organisation, error) {
+func (r *mutationResolver) CreateOrganisation(ctx context.Context, name string, idempotencyKey *string) (*model.Organisation, error) {
+	// idempotencyKey is handled by the idempotency extension before the operation is executed
 	cmd, err := command.NewOrganisationCreateCmd()
 	if err != nil {
 		return nil, err
@@ -112,10 +145,11 @@ func (r *mutationResolver) CreateOrganisation(ctx context.Context, name string)
 }
 
 // UpdateOrganisation is the resolver for the updateOrganisation field.
-func (r *mutationResolver) UpdateOrganisation(ctx context.Context, id uuid.UUID, name string) (*model.Organisation, error) {
+func (r *mutationResolver) UpdateOrganisation(ctx context.Context, id uuid.UUID, name string, expectedVersion *int) (*model.Organisation, error) {
 	cmd := command.OrganisationUpdateCmd{
-		OrganisationID: id,
-		Name:           name,
+		OrganisationID:  id,
+		Name:            name,
+		ExpectedVersion: expectedVersion,
 	}
 	err := r.handler.OrganisationUpdate(ctx, cmd)
 	if err != nil {
@@ -147,6 +181,36 @@ func (r *mutationResolver) DeleteOrganisation(ctx context.Context, id uuid.UUID)
 	return helper.MapToOrganisation(record), nil
 }
 
+// RestoreOrganisation is the resolver for the restoreOrganisation field.
+func (r *mutationResolver) RestoreOrganisation(ctx context.Context, id uuid.UUID) (*model.Organisation, error) {
+	cmd := command.NewOrganisationRestoreCmd(id)
+	err := r.handler.OrganisationRestore(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := r.finder.QueryOrganisation(ctx, query.OrganisationQuery{
+		OrganisationID: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return helper.MapToOrganisation(record), nil
+}
+
+// Accounts is the resolver for the accounts field.
+func (r *organisationResolver) Accounts(ctx context.Context, obj *model.Organisation, sortField *string, sortOrder *string, first *int) ([]*model.Account, error) {
+	paging, err := helper.MapToPaging(nil, first, sortField, sortOrder)
+	if err != nil {
+		return nil, err
+	}
+	records, err := loader.For(ctx).AccountsByOrganisationID(paging).Load(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	return helper.MapToAccounts(records), nil
+}
+
 // Account is the resolver for the Account field.
 func (r *queryResolver) Account(ctx context.Context, id uuid.UUID) (*model.Account, error) {
 	record, err := r.finder.QueryAccount(ctx, query.AccountQuery{
@@ -187,6 +251,31 @@ func (r *queryResolver) AllAccountsMeta(ctx context.Context, page *int, perPage
 	}, nil
 }
 
+// AccountsConnection is the resolver for the accountsConnection field.
+func (r *queryResolver) AccountsConnection(ctx context.Context, first *int, after *string, last *int, before *string, filter *model.AccountFilter, orderBy *model.AccountOrder) (*model.AccountConnection, error) {
+	query := helper.MapFromAccountFilter(filter)
+
+	sortField, sortDirection := helper.MapFromAccountOrder(orderBy)
+	paging, err := helper.MapToCursorPaging(first, after, last, before, sortField, sortDirection)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := r.finder.QueryAccountsConnection(ctx, query, paging)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only count if requested, since it needs an additional query
+	var totalCount int
+	if helper.SelectedFields(ctx).PathSelected("totalCount") {
+		totalCount, err = r.finder.CountAccounts(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return helper.MapToAccountConnection(conn, totalCount), nil
+}
+
 // Organisation is the resolver for the Organisation field.
 func (r *queryResolver) Organisation(ctx context.Context, id uuid.UUID) (*model.Organisation, error) {
 	record, err := r.finder.QueryOrganisation(ctx, query.OrganisationQuery{
@@ -228,7 +317,107 @@ func (r *queryResolver) AllOrganisationsMeta(ctx context.Context, page *int, per
 	}, nil
 }
 
+// OrganisationsConnection is the resolver for the organisationsConnection field.
+func (r *queryResolver) OrganisationsConnection(ctx context.Context, first *int, after *string, last *int, before *string, filter *model.OrganisationFilter, orderBy *model.OrganisationOrder) (*model.OrganisationConnection, error) {
+	query := helper.MapToOrganisationsQuery(filter)
+
+	sortField, sortDirection := helper.MapFromOrganisationOrder(orderBy)
+	paging, err := helper.MapToCursorPaging(first, after, last, before, sortField, sortDirection)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := r.finder.QueryOrganisationsConnection(ctx, query, paging)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only count if requested, since it needs an additional query
+	var totalCount int
+	if helper.SelectedFields(ctx).PathSelected("totalCount") {
+		totalCount, err = r.finder.CountOrganisations(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return helper.MapToOrganisationConnection(conn, totalCount), nil
+}
+
+// AccountChanged is the resolver for the accountChanged field.
+func (r *subscriptionResolver) AccountChanged(ctx context.Context, organisationID *uuid.UUID) (<-chan *model.AccountChangedEvent, error) {
+	if r.Notifications == nil {
+		return nil, api.ErrSubscriptionsUnavailable
+	}
+
+	events, err := r.finder.SubscribeAccountChanged(ctx, r.Notifications, query.AccountChangedSubscription{
+		OrganisationID: organisationID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.MapEvents(ctx, events, func(event notification.AccountChanged) *model.AccountChangedEvent {
+		result := helper.MapToAccountChangedEvent(event)
+		if event.Action == notification.ChangeActionDeleted {
+			return result
+		}
+
+		record, err := r.finder.QueryAccount(ctx, query.AccountQuery{
+			AccountID: event.AccountID,
+		})
+		if err != nil {
+			// The account could have been deleted in the meantime or moved to an organisation that is not visible
+			var authorizationErr authorization.Error
+			if !fog_errors.Is(err, repository.ErrNotFound) && !fog_errors.As(err, &authorizationErr) {
+				logger.FromContext(ctx).WithError(err).Warn("Could not load account of change event")
+			}
+			return result
+		}
+		result.Account = helper.MapToAccount(record)
+		return result
+	}), nil
+}
+
+// OrganisationChanged is the resolver for the organisationChanged field.
+func (r *subscriptionResolver) OrganisationChanged(ctx context.Context) (<-chan *model.OrganisationChangedEvent, error) {
+	if r.Notifications == nil {
+		return nil, api.ErrSubscriptionsUnavailable
+	}
+
+	events, err := r.finder.SubscribeOrganisationChanged(ctx, r.Notifications, query.OrganisationChangedSubscription{})
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.MapEvents(ctx, events, func(event notification.OrganisationChanged) *model.OrganisationChangedEvent {
+		result := helper.MapToOrganisationChangedEvent(event)
+		if event.Action == notification.ChangeActionDeleted {
+			return result
+		}
+
+		record, err := r.finder.QueryOrganisation(ctx, query.OrganisationQuery{
+			OrganisationID: event.OrganisationID,
+		})
+		if err != nil {
+			// The organisation could have been deleted in the meantime
+			if !fog_errors.Is(err, repository.ErrNotFound) {
+				logger.FromContext(ctx).WithError(err).Warn("Could not load organisation of change event")
+			}
+			return result
+		}
+		result.Organisation = helper.MapToOrganisation(record)
+		return result
+	}), nil
+}
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
+// Organisation returns generated.OrganisationResolver implementation.
+func (r *Resolver) Organisation() generated.OrganisationResolver { return &organisationResolver{r} }
+
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
 type mutationResolver struct{ *Resolver }
+type organisationResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
@@ -0,0 +1,45 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.49
+
+import (
+	"context"
+
+	"myvendor.mytld/myproject/backend/api/graph/helper"
+	"myvendor.mytld/myproject/backend/api/graph/model"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+// AllAuditEntries is the resolver for the allAuditEntries field.
+func (r *queryResolver) AllAuditEntries(ctx context.Context, page *int, perPage *int, sortField *string, sortOrder *string, filter *model.AuditEntryFilter) ([]*model.AuditEntry, error) {
+	query := helper.MapFromAuditEntryFilter(filter)
+
+	// Newest entries are the most relevant in a log
+	if sortField == nil {
+		sortField = helper.ToPtr("occurredAt")
+		sortOrder = helper.ToPtr(repository.SortOrderDesc)
+	}
+	paging, err := helper.MapToPaging(page, perPage, sortField, sortOrder)
+	if err != nil {
+		return nil, err
+	}
+	records, err := r.finder.QueryAuditEntries(ctx, query, paging)
+	if err != nil {
+		return nil, err
+	}
+	return helper.MapToAuditEntries(records), nil
+}
+
+// AllAuditEntriesMeta is the resolver for the _allAuditEntriesMeta field.
+func (r *queryResolver) AllAuditEntriesMeta(ctx context.Context, page *int, perPage *int, sortField *string, sortOrder *string, filter *model.AuditEntryFilter) (*model.ListMetadata, error) {
+	query := helper.MapFromAuditEntryFilter(filter)
+	count, err := r.finder.CountAuditEntries(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ListMetadata{
+		Count: count,
+	}, nil
+}
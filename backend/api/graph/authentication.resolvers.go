@@ -10,7 +10,9 @@ import (
 	logger "github.com/apex/log"
 	fog_errors "github.com/friendsofgo/errors"
 	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/api/graph/generated"
 	"myvendor.mytld/myproject/backend/api/graph/helper"
+	"myvendor.mytld/myproject/backend/api/graph/loader"
 	"myvendor.mytld/myproject/backend/api/graph/model"
 	"myvendor.mytld/myproject/backend/domain/command"
 	"myvendor.mytld/myproject/backend/domain/query"
@@ -20,6 +22,25 @@ import (
 	"myvendor.mytld/myproject/backend/security/authentication"
 )
 
+// Organisation is the resolver for the organisation field.
+func (r *accountResolver) Organisation(ctx context.Context, obj *model.Account) (*model.Organisation, error) {
+	// Organisation could already be side-loaded with the account
+	if obj.Organisation != nil {
+		return obj.Organisation, nil
+	}
+	if obj.OrganisationID == nil {
+		return nil, nil
+	}
+
+	record, err := loader.For(ctx).OrganisationByID.Load(ctx, *obj.OrganisationID)
+	if err == repository.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return helper.MapToOrganisation(record), nil
+}
+
 // Login is the resolver for the login field.
 func (r *mutationResolver) Login(ctx context.Context, credentials model.LoginCredentials) (*model.LoginResult, error) {
 	defer helper.ConstantTime(r.SensitiveOperationConstantTime).Wait(ctx)
@@ -102,3 +123,8 @@ func (r *queryResolver) CurrentAccount(ctx context.Context) (*model.Account, err
 
 	return helper.MapToAccount(account), nil
 }
+
+// Account returns generated.AccountResolver implementation.
+func (r *Resolver) Account() generated.AccountResolver { return &accountResolver{r} }
+
+type accountResolver struct{ *Resolver }
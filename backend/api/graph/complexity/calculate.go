@@ -0,0 +1,156 @@
+package complexity
+
+import (
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const (
+	costDirectiveName        = "cost"
+	costComplexityArgName    = "complexity"
+	costMultipliersArgName   = "multipliers"
+	defaultFieldComplexity   = 1
+	introspectionFieldPrefix = "__"
+)
+
+// Result of the complexity calculation of an operation
+type Result struct {
+	Complexity int
+	Depth      int
+}
+
+// Calculate computes complexity and depth of an operation.
+//
+// Every field costs 1 or the complexity given by the @cost directive of the field definition.
+// The complexity of the selected sub fields is multiplied by the value of the arguments listed in multipliers of @cost
+// (e.g. perPage or first), falling back to the default value of the argument or defaultListSize if no value is set.
+// Introspection fields are ignored.
+func Calculate(op *ast.OperationDefinition, variables map[string]any, defaultListSize int) Result {
+	c := calculator{
+		variables:       variables,
+		defaultListSize: defaultListSize,
+	}
+	complexity, depth := c.selectionSet(op.SelectionSet)
+	return Result{
+		Complexity: complexity,
+		Depth:      depth,
+	}
+}
+
+type calculator struct {
+	variables       map[string]any
+	defaultListSize int
+}
+
+func (c calculator) selectionSet(selectionSet ast.SelectionSet) (complexity int, depth int) {
+	for _, selection := range selectionSet {
+		var selComplexity, selDepth int
+		switch sel := selection.(type) {
+		case *ast.Field:
+			selComplexity, selDepth = c.field(sel)
+		case *ast.FragmentSpread:
+			if sel.Definition != nil {
+				selComplexity, selDepth = c.selectionSet(sel.Definition.SelectionSet)
+			}
+		case *ast.InlineFragment:
+			selComplexity, selDepth = c.selectionSet(sel.SelectionSet)
+		}
+
+		complexity = safeAdd(complexity, selComplexity)
+		depth = max(depth, selDepth)
+	}
+	return complexity, depth
+}
+
+func (c calculator) field(field *ast.Field) (complexity int, depth int) {
+	if strings.HasPrefix(field.Name, introspectionFieldPrefix) {
+		return 0, 0
+	}
+
+	childComplexity, childDepth := c.selectionSet(field.SelectionSet)
+
+	fieldComplexity := defaultFieldComplexity
+	multiplier := 1
+	if field.Definition != nil {
+		if costDirective := field.Definition.Directives.ForName(costDirectiveName); costDirective != nil {
+			if value, ok := c.intArgument(costDirective.Arguments.ForName(costComplexityArgName)); ok {
+				fieldComplexity = value
+			}
+			multiplier = c.multiplier(field, costDirective)
+		}
+	}
+
+	return safeAdd(fieldComplexity, safeMul(childComplexity, multiplier)), childDepth + 1
+}
+
+// multiplier uses the first multiplier argument that has a value
+func (c calculator) multiplier(field *ast.Field, costDirective *ast.Directive) int {
+	multipliersArg := costDirective.Arguments.ForName(costMultipliersArgName)
+	if multipliersArg == nil || multipliersArg.Value == nil {
+		return 1
+	}
+
+	for _, child := range multipliersArg.Value.Children {
+		argName := child.Value.Raw
+
+		if value, ok := c.intArgument(field.Arguments.ForName(argName)); ok {
+			return max(value, 0)
+		}
+		if argDefinition := field.Definition.Arguments.ForName(argName); argDefinition != nil && argDefinition.DefaultValue != nil {
+			if value, ok := c.intValue(argDefinition.DefaultValue); ok {
+				return max(value, 0)
+			}
+		}
+	}
+
+	return c.defaultListSize
+}
+
+func (c calculator) intArgument(arg *ast.Argument) (int, bool) {
+	if arg == nil || arg.Value == nil {
+		return 0, false
+	}
+	return c.intValue(arg.Value)
+}
+
+func (c calculator) intValue(value *ast.Value) (int, bool) {
+	resolved, err := value.Value(c.variables)
+	if err != nil {
+		return 0, false
+	}
+	switch v := resolved.(type) {
+	case int64:
+		return int(v), true
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	}
+	// Variables decoded from JSON could be json.Number or other numeric types
+	if n, ok := resolved.(interface{ Int64() (int64, error) }); ok {
+		if i, err := n.Int64(); err == nil {
+			return int(i), true
+		}
+	}
+	return 0, false
+}
+
+const maxComplexity = int(^uint(0) >> 1)
+
+func safeAdd(a, b int) int {
+	if a > maxComplexity-b {
+		return maxComplexity
+	}
+	return a + b
+}
+
+func safeMul(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	if a > maxComplexity/b {
+		return maxComplexity
+	}
+	return a * b
+}
@@ -0,0 +1,92 @@
+package complexity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+
+	"myvendor.mytld/myproject/backend/api/graph/complexity"
+	"myvendor.mytld/myproject/backend/api/graph/generated"
+)
+
+func TestCalculate(t *testing.T) {
+	schema := generated.NewExecutableSchema(generated.Config{}).Schema()
+
+	tests := []struct {
+		name               string
+		query              string
+		variables          map[string]any
+		expectedComplexity int
+		expectedDepth      int
+	}{
+		{
+			name:               "scalar field",
+			query:              `{ echo(hello: "world") }`,
+			expectedComplexity: 1,
+			expectedDepth:      1,
+		},
+		{
+			name:  "list with perPage argument",
+			query: `{ allAccounts(perPage: 10) { id emailAddress } }`,
+			// 1 + 10 * 2
+			expectedComplexity: 21,
+			expectedDepth:      2,
+		},
+		{
+			name:      "list with perPage variable",
+			query:     `query ($perPage: Int) { allAccounts(perPage: $perPage) { id } }`,
+			variables: map[string]any{"perPage": int64(100)},
+			// 1 + 100 * 1
+			expectedComplexity: 101,
+			expectedDepth:      2,
+		},
+		{
+			name:  "list without perPage uses default list size",
+			query: `{ allOrganisations { id } }`,
+			// 1 + 50 * 1
+			expectedComplexity: 51,
+			expectedDepth:      2,
+		},
+		{
+			name:  "connection with last and nested cost hints",
+			query: `{ accountsConnection(last: 5) { edges { node { id organisation { name accounts { id } } } } } }`,
+			// 1 + 5 * (edges: 1 + (node: 1 + (id: 1 + organisation: 2 + (name: 1 + accounts: 10 + 50 * 1))))
+			expectedComplexity: 331,
+			expectedDepth:      6,
+		},
+		{
+			name:  "nested list with first argument",
+			query: `{ allOrganisations(perPage: 10) { name accounts(first: 3) { id emailAddress } } }`,
+			// 1 + 10 * (name: 1 + accounts: 10 + 3 * 2)
+			expectedComplexity: 171,
+			expectedDepth:      3,
+		},
+		{
+			name:  "fragments are included",
+			query: `query { allAccounts(perPage: 2) { ...AccountFields } } fragment AccountFields on Account { id emailAddress }`,
+			// 1 + 2 * 2
+			expectedComplexity: 5,
+			expectedDepth:      2,
+		},
+		{
+			name:               "introspection is ignored",
+			query:              `{ __schema { types { name fields { name type { name ofType { name } } } } } }`,
+			expectedComplexity: 0,
+			expectedDepth:      0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, errs := gqlparser.LoadQuery(schema, tt.query)
+			require.Empty(t, errs)
+			require.Len(t, doc.Operations, 1)
+
+			result := complexity.Calculate(doc.Operations[0], tt.variables, 50)
+
+			assert.Equal(t, tt.expectedComplexity, result.Complexity, "complexity")
+			assert.Equal(t, tt.expectedDepth, result.Depth, "depth")
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package complexity
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	"myvendor.mytld/myproject/backend/api"
+)
+
+const (
+	extensionName = "ComplexityLimit"
+	// responseExtensionKey is the key of the computed complexity in the extensions of a response
+	responseExtensionKey = "complexity"
+)
+
+// Limit is a handler extension that rejects operations exceeding a maximum depth or complexity before execution.
+// The computed complexity is added to the response extensions and recorded as a metric.
+type Limit struct {
+	// MaxDepth of fields in an operation, 0 disables the check
+	MaxDepth int
+	// MaxComplexity of an operation, 0 disables the check
+	MaxComplexity int
+	// DefaultListSize is used as a multiplier for list fields if no multiplier argument is set
+	DefaultListSize int
+
+	complexityHistogram metric.Int64Histogram
+}
+
+// Stats are stored in the operation context and exposed in the response extensions
+type Stats struct {
+	Complexity    int `json:"complexity"`
+	Depth         int `json:"depth"`
+	MaxComplexity int `json:"maxComplexity,omitempty"`
+	MaxDepth      int `json:"maxDepth,omitempty"`
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+	graphql.ResponseInterceptor
+} = &Limit{}
+
+// NewLimit creates a new complexity limit extension.
+func NewLimit(maxDepth, maxComplexity, defaultListSize int, meterProvider metric.MeterProvider) *Limit {
+	if meterProvider == nil {
+		meterProvider = noop.NewMeterProvider()
+	}
+	meter := meterProvider.Meter("myvendor.mytld/myproject/backend/api/graph/complexity")
+
+	complexityHistogram, err := meter.Int64Histogram(
+		"graphql.operation.complexity",
+		metric.WithDescription("Computed complexity of GraphQL operations."),
+		metric.WithUnit("{complexity}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Limit{
+		MaxDepth:            maxDepth,
+		MaxComplexity:       maxComplexity,
+		DefaultListSize:     defaultListSize,
+		complexityHistogram: complexityHistogram,
+	}
+}
+
+func (l *Limit) ExtensionName() string {
+	return extensionName
+}
+
+func (l *Limit) Validate(_ graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (l *Limit) MutateOperationContext(ctx context.Context, rc *graphql.OperationContext) *gqlerror.Error {
+	if rc.Operation == nil {
+		return nil
+	}
+
+	result := Calculate(rc.Operation, rc.Variables, l.DefaultListSize)
+
+	rc.Stats.SetExtension(extensionName, &Stats{
+		Complexity:    result.Complexity,
+		Depth:         result.Depth,
+		MaxComplexity: l.MaxComplexity,
+		MaxDepth:      l.MaxDepth,
+	})
+
+	var err *gqlerror.Error
+	switch {
+	case l.MaxDepth > 0 && result.Depth > l.MaxDepth:
+		err = gqlerror.Errorf("%s: operation has depth %d, limit is %d", api.ErrDepthLimitExceeded, result.Depth, l.MaxDepth)
+		err.Extensions = api.ErrDepthLimitExceeded.Extensions()
+		err.Extensions["depth"] = result.Depth
+		err.Extensions["limit"] = l.MaxDepth
+	case l.MaxComplexity > 0 && result.Complexity > l.MaxComplexity:
+		err = gqlerror.Errorf("%s: operation has complexity %d, limit is %d", api.ErrComplexityLimitExceeded, result.Complexity, l.MaxComplexity)
+		err.Extensions = api.ErrComplexityLimitExceeded.Extensions()
+		err.Extensions["complexity"] = result.Complexity
+		err.Extensions["limit"] = l.MaxComplexity
+	}
+
+	l.complexityHistogram.Record(ctx, int64(result.Complexity), metric.WithAttributes(
+		attribute.String("graphql.operation.type", string(rc.Operation.Operation)),
+		attribute.Bool("rejected", err != nil),
+	))
+
+	return err
+}
+
+func (l *Limit) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	if graphql.HasOperationContext(ctx) {
+		if stats := GetStats(graphql.GetOperationContext(ctx)); stats != nil {
+			graphql.RegisterExtension(ctx, responseExtensionKey, stats)
+		}
+	}
+	return next(ctx)
+}
+
+// GetStats gets the computed complexity of an operation (if the extension is used)
+func GetStats(rc *graphql.OperationContext) *Stats {
+	if rc == nil {
+		return nil
+	}
+	stats, _ := rc.Stats.GetExtension(extensionName).(*Stats)
+	return stats
+}
@@ -6,17 +6,25 @@ import (
 	"myvendor.mytld/myproject/backend/api/graph/model"
 	model2 "myvendor.mytld/myproject/backend/domain/model"
 	"myvendor.mytld/myproject/backend/domain/query"
+	"myvendor.mytld/myproject/backend/finder"
 )
 
 func MapToAccount(record model2.Account) *model.Account {
+	var organisation *model.Organisation
+	if record.Organisation != nil {
+		organisation = MapToOrganisation(*record.Organisation)
+	}
 	return &model.Account{
 		ID:             record.ID,
 		EmailAddress:   record.EmailAddress,
 		Role:           record.Role,
 		LastLogin:      record.LastLogin,
 		OrganisationID: uuidOrNil(record.OrganisationID),
+		Organisation:   organisation,
 		CreatedAt:      record.CreatedAt,
 		UpdatedAt:      record.UpdatedAt,
+		Version:        record.Version,
+		DeletedAt:      record.DeletedAt,
 	}
 }
 
@@ -28,6 +36,28 @@ func MapToAccounts(records []model2.Account) []*model.Account {
 	return result
 }
 
+func MapToAccountConnection(conn finder.Connection[model2.Account], totalCount int) *model.AccountConnection {
+	edges := make([]*model.AccountEdge, len(conn.Edges))
+	for i, edge := range conn.Edges {
+		edges[i] = &model.AccountEdge{
+			Cursor: edge.Cursor,
+			Node:   MapToAccount(edge.Node),
+		}
+	}
+	return &model.AccountConnection{
+		Edges:      edges,
+		PageInfo:   MapToPageInfo(conn.PageInfo),
+		TotalCount: totalCount,
+	}
+}
+
+func MapFromAccountOrder(order *model.AccountOrder) (sortField *string, sortDirection *model.OrderDirection) {
+	if order == nil {
+		return nil, nil
+	}
+	return ToPtr(order.Field.String()), order.Direction
+}
+
 func MapFromAccountFilter(filter *model.AccountFilter) query.AccountsQuery {
 	if filter == nil {
 		return query.AccountsQuery{}
@@ -36,6 +66,7 @@ func MapFromAccountFilter(filter *model.AccountFilter) query.AccountsQuery {
 		IDs:            filter.Ids,
 		SearchTerm:     ToVal(filter.Q),
 		OrganisationID: filter.OrganisationID,
+		WithDeleted:    ToVal(filter.WithDeleted),
 	}
 }
 
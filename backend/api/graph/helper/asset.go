@@ -0,0 +1,32 @@
+package helper
+
+import (
+	"myvendor.mytld/myproject/backend/api/graph/model"
+	model2 "myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/imaging"
+)
+
+func MapToAsset(record model2.Asset) *model.Asset {
+	return &model.Asset{
+		ID:             record.ID,
+		OrganisationID: uuidOrNil(record.OrganisationID),
+		Filename:       record.Filename,
+		ContentType:    record.ContentType,
+		Size:           record.Size,
+		Checksum:       record.Checksum,
+		Width:          record.Width,
+		Height:         record.Height,
+		CreatedAt:      record.CreatedAt,
+	}
+}
+
+func MapFromImageVariant(variant model.ImageVariant) imaging.Variant {
+	switch variant {
+	case model.ImageVariantSmall:
+		return imaging.VariantSmall
+	case model.ImageVariantLarge:
+		return imaging.VariantLarge
+	default:
+		return imaging.VariantThumbnail
+	}
+}
@@ -0,0 +1,80 @@
+package helper
+
+import (
+	"encoding/json"
+	"sort"
+
+	"myvendor.mytld/myproject/backend/api/graph/model"
+	model2 "myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/query"
+)
+
+func MapToAuditEntry(record model2.AuditEntry) *model.AuditEntry {
+	return &model.AuditEntry{
+		ID:                    record.ID,
+		OccurredAt:            record.OccurredAt,
+		ActorAccountID:        uuidOrNil(record.ActorAccountID),
+		ImpersonatorAccountID: uuidOrNil(record.ImpersonatorAccountID),
+		OrganisationID:        uuidOrNil(record.OrganisationID),
+		CommandType:           record.CommandType,
+		TargetIds:             record.TargetIDs,
+		Changes:               mapToAuditChanges(record.Changes),
+		RequestID:             record.RequestID,
+		IPAddress:             record.IPAddress,
+	}
+}
+
+func MapToAuditEntries(records []model2.AuditEntry) []*model.AuditEntry {
+	result := make([]*model.AuditEntry, len(records))
+	for i, record := range records {
+		result[i] = MapToAuditEntry(record)
+	}
+	return result
+}
+
+// mapToAuditChanges maps the stored changes, values are kept in their JSON encoding
+func mapToAuditChanges(changes json.RawMessage) []*model.AuditChange {
+	var decoded map[string]struct {
+		Before   json.RawMessage `json:"before"`
+		After    json.RawMessage `json:"after"`
+		Redacted bool            `json:"redacted"`
+	}
+	// Entries are written by the audit package only, so decoding does not fail for valid records
+	_ = json.Unmarshal(changes, &decoded)
+
+	result := make([]*model.AuditChange, 0, len(decoded))
+	for field, change := range decoded {
+		result = append(result, &model.AuditChange{
+			Field:    field,
+			Before:   jsonValueOrNil(change.Before),
+			After:    jsonValueOrNil(change.After),
+			Redacted: change.Redacted,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Field < result[j].Field
+	})
+	return result
+}
+
+func jsonValueOrNil(value json.RawMessage) *string {
+	if len(value) == 0 || string(value) == "null" {
+		return nil
+	}
+	s := string(value)
+	return &s
+}
+
+func MapFromAuditEntryFilter(filter *model.AuditEntryFilter) query.AuditEntriesQuery {
+	if filter == nil {
+		return query.AuditEntriesQuery{}
+	}
+	return query.AuditEntriesQuery{
+		OrganisationID: filter.OrganisationID,
+		ActorAccountID: filter.ActorAccountID,
+		CommandType:    filter.CommandType,
+		TargetID:       filter.TargetID,
+		OccurredFrom:   filter.OccurredFrom,
+		OccurredTo:     filter.OccurredTo,
+	}
+}
@@ -5,12 +5,15 @@ import (
 
 	"github.com/gofrs/uuid"
 
+	"myvendor.mytld/myproject/backend/api/graph/model"
 	"myvendor.mytld/myproject/backend/domain/types"
 	"myvendor.mytld/myproject/backend/finder"
+	"myvendor.mytld/myproject/backend/persistence/repository"
 )
 
 const (
-	defaultPerPage = 50
+	// DefaultPerPage is applied to API listings if no limit is given
+	DefaultPerPage = 50
 	maxPerPage     = 1000
 )
 
@@ -19,7 +22,7 @@ var ErrMaxPerPageExceeded = errors.New("perPage exceeds maximum")
 func MapToPaging(page *int, perPage *int, sortField *string, sortOrder *string) (finder.Paging, error) {
 	paging := finder.Paging{
 		// Always apply a default per page for API listings
-		PerPage:   ToPtr(defaultPerPage),
+		PerPage:   ToPtr(DefaultPerPage),
 		SortField: sortField,
 		SortOrder: sortOrder,
 	}
@@ -36,6 +39,42 @@ func MapToPaging(page *int, perPage *int, sortField *string, sortOrder *string)
 	return paging, nil
 }
 
+// MapToCursorPaging maps arguments of a connection field to cursor paging.
+// If neither first nor last is given, the default per page limit is applied as first.
+func MapToCursorPaging(first *int, after *string, last *int, before *string, sortField *string, sortDirection *model.OrderDirection) (finder.CursorPaging, error) {
+	paging := finder.CursorPaging{
+		First:     first,
+		After:     after,
+		Last:      last,
+		Before:    before,
+		SortField: sortField,
+	}
+	if first == nil && last == nil {
+		if before != nil {
+			paging.Last = ToPtr(DefaultPerPage)
+		} else {
+			paging.First = ToPtr(DefaultPerPage)
+		}
+	}
+	if (paging.First != nil && *paging.First > maxPerPage) || (paging.Last != nil && *paging.Last > maxPerPage) {
+		return paging, ErrMaxPerPageExceeded
+	}
+	if sortDirection != nil && *sortDirection == model.OrderDirectionDesc {
+		paging.SortOrder = ToPtr(repository.SortOrderDesc)
+	}
+
+	return paging, nil
+}
+
+func MapToPageInfo(pageInfo finder.PageInfo) *model.PageInfo {
+	return &model.PageInfo{
+		HasNextPage:     pageInfo.HasNextPage,
+		HasPreviousPage: pageInfo.HasPreviousPage,
+		StartCursor:     pageInfo.StartCursor,
+		EndCursor:       pageInfo.EndCursor,
+	}
+}
+
 func ToPtr[T any](value T) *T {
 	return &value
 }
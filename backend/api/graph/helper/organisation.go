@@ -6,6 +6,7 @@ import (
 	"myvendor.mytld/myproject/backend/api/graph/model"
 	model2 "myvendor.mytld/myproject/backend/domain/model"
 	"myvendor.mytld/myproject/backend/domain/query"
+	"myvendor.mytld/myproject/backend/finder"
 )
 
 func MapToOrganisation(record model2.Organisation) *model.Organisation {
@@ -14,6 +15,8 @@ func MapToOrganisation(record model2.Organisation) *model.Organisation {
 		Name:      record.Name,
 		CreatedAt: record.CreatedAt,
 		UpdatedAt: record.UpdatedAt,
+		Version:   record.Version,
+		DeletedAt: record.DeletedAt,
 	}
 }
 
@@ -25,13 +28,36 @@ func MapToOrganisations(records []model2.Organisation) []*model.Organisation {
 	return result
 }
 
+func MapToOrganisationConnection(conn finder.Connection[model2.Organisation], totalCount int) *model.OrganisationConnection {
+	edges := make([]*model.OrganisationEdge, len(conn.Edges))
+	for i, edge := range conn.Edges {
+		edges[i] = &model.OrganisationEdge{
+			Cursor: edge.Cursor,
+			Node:   MapToOrganisation(edge.Node),
+		}
+	}
+	return &model.OrganisationConnection{
+		Edges:      edges,
+		PageInfo:   MapToPageInfo(conn.PageInfo),
+		TotalCount: totalCount,
+	}
+}
+
+func MapFromOrganisationOrder(order *model.OrganisationOrder) (sortField *string, sortDirection *model.OrderDirection) {
+	if order == nil {
+		return nil, nil
+	}
+	return ToPtr(order.Field.String()), order.Direction
+}
+
 func MapToOrganisationsQuery(filter *model.OrganisationFilter) query.OrganisationsQuery {
 	if filter == nil {
 		return query.OrganisationsQuery{}
 	}
 	return query.OrganisationsQuery{
-		IDs:        filter.Ids,
-		SearchTerm: ToVal(filter.Q),
+		IDs:         filter.Ids,
+		SearchTerm:  ToVal(filter.Q),
+		WithDeleted: ToVal(filter.WithDeleted),
 	}
 }
 
@@ -0,0 +1,51 @@
+package helper
+
+import (
+	"myvendor.mytld/myproject/backend/api/graph/model"
+	model2 "myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/query"
+	"myvendor.mytld/myproject/backend/domain/types"
+)
+
+func MapToSearchQuery(q string, limit *int) (query.SearchQuery, error) {
+	searchQuery := query.SearchQuery{
+		SearchTerm: q,
+		Limit:      DefaultPerPage,
+	}
+	if limit != nil {
+		searchQuery.Limit = *limit
+	}
+	if searchQuery.Limit < 1 {
+		return searchQuery, types.FieldError{
+			Field:     "limit",
+			Code:      types.ErrorCodeMustBeAtLeast,
+			Arguments: []string{"1"},
+		}
+	}
+	if searchQuery.Limit > maxPerPage {
+		return searchQuery, ErrMaxPerPageExceeded
+	}
+	return searchQuery, nil
+}
+
+func MapToSearchResults(records []model2.SearchResult) []*model.SearchResult {
+	result := make([]*model.SearchResult, 0, len(records))
+	for _, record := range records {
+		var node model.SearchResultNode
+		switch {
+		case record.Account != nil:
+			node = MapToAccount(*record.Account)
+		case record.Organisation != nil:
+			node = MapToOrganisation(*record.Organisation)
+		default:
+			continue
+		}
+		result = append(result, &model.SearchResult{
+			Node:      node,
+			Rank:      record.Rank,
+			Text:      record.Text,
+			Highlight: record.Highlight,
+		})
+	}
+	return result
+}
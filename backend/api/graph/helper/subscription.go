@@ -0,0 +1,60 @@
+package helper
+
+import (
+	"context"
+
+	"myvendor.mytld/myproject/backend/api/graph/model"
+	"myvendor.mytld/myproject/backend/persistence/notification"
+)
+
+func MapToChangeAction(action notification.ChangeAction) model.ChangeAction {
+	switch action {
+	case notification.ChangeActionCreated:
+		return model.ChangeActionCreated
+	case notification.ChangeActionDeleted:
+		return model.ChangeActionDeleted
+	case notification.ChangeActionRestored:
+		return model.ChangeActionRestored
+	default:
+		return model.ChangeActionUpdated
+	}
+}
+
+func MapToAccountChangedEvent(event notification.AccountChanged) *model.AccountChangedEvent {
+	return &model.AccountChangedEvent{
+		Action:                 MapToChangeAction(event.Action),
+		AccountID:              event.AccountID,
+		OrganisationID:         uuidOrNil(event.OrganisationID),
+		PreviousOrganisationID: uuidOrNil(event.PreviousOrganisationID),
+	}
+}
+
+func MapToOrganisationChangedEvent(event notification.OrganisationChanged) *model.OrganisationChangedEvent {
+	return &model.OrganisationChangedEvent{
+		Action:         MapToChangeAction(event.Action),
+		OrganisationID: event.OrganisationID,
+	}
+}
+
+// MapEvents maps events of a subscription until the events channel is closed or the context is done.
+// Events are skipped if mapFn returns nil.
+func MapEvents[E any, R any](ctx context.Context, events <-chan E, mapFn func(event E) *R) <-chan *R {
+	results := make(chan *R)
+	go func() {
+		defer close(results)
+
+		for event := range events {
+			result := mapFn(event)
+			if result == nil {
+				continue
+			}
+
+			select {
+			case results <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return results
+}
@@ -0,0 +1,72 @@
+package helper
+
+import (
+	"myvendor.mytld/myproject/backend/api/graph/model"
+	model2 "myvendor.mytld/myproject/backend/domain/model"
+)
+
+func MapToWebhookEndpoint(record model2.WebhookEndpoint) *model.WebhookEndpoint {
+	eventTypes := make([]model.WebhookEventType, len(record.EventTypes))
+	for i, eventType := range record.EventTypes {
+		eventTypes[i] = model.WebhookEventType(eventType)
+	}
+
+	return &model.WebhookEndpoint{
+		ID:             record.ID,
+		OrganisationID: record.OrganisationID,
+		URL:            record.URL,
+		Secret:         record.Secret,
+		EventTypes:     eventTypes,
+		CreatedAt:      record.CreatedAt,
+		UpdatedAt:      record.UpdatedAt,
+	}
+}
+
+func MapToWebhookEndpoints(records []model2.WebhookEndpoint) []*model.WebhookEndpoint {
+	result := make([]*model.WebhookEndpoint, len(records))
+	for i, record := range records {
+		result[i] = MapToWebhookEndpoint(record)
+	}
+	return result
+}
+
+func MapToWebhookDelivery(record model2.WebhookDelivery) *model.WebhookDelivery {
+	return &model.WebhookDelivery{
+		ID:                record.ID,
+		WebhookEndpointID: record.WebhookEndpointID,
+		EventID:           record.EventID,
+		EventType:         model.WebhookEventType(record.EventType),
+		Payload:           string(record.Payload),
+		Status:            model.WebhookDeliveryStatus(record.Status),
+		Attempts:          record.Attempts,
+		ResponseStatus:    record.ResponseStatus,
+		LastError:         record.LastError,
+		CreatedAt:         record.CreatedAt,
+		LastAttemptAt:     record.LastAttemptAt,
+		DeliveredAt:       record.DeliveredAt,
+	}
+}
+
+func MapToWebhookDeliveries(records []model2.WebhookDelivery) []*model.WebhookDelivery {
+	result := make([]*model.WebhookDelivery, len(records))
+	for i, record := range records {
+		result[i] = MapToWebhookDelivery(record)
+	}
+	return result
+}
+
+func MapFromWebhookEventTypes(eventTypes []model.WebhookEventType) []string {
+	result := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		result[i] = string(eventType)
+	}
+	return result
+}
+
+func MapFromWebhookDeliveryStatus(status *model.WebhookDeliveryStatus) *model2.WebhookDeliveryStatus {
+	if status == nil {
+		return nil
+	}
+	result := model2.WebhookDeliveryStatus(*status)
+	return &result
+}
@@ -0,0 +1,236 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	logger "github.com/apex/log"
+	"github.com/friendsofgo/errors"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/security/authentication"
+)
+
+const (
+	// HeaderKey is the request header for sending an idempotency key
+	HeaderKey = "Idempotency-Key"
+	// HeaderReplayed is set on responses that were replayed from a stored response
+	HeaderReplayed = "Idempotent-Replayed"
+	// ArgumentName is the optional argument of mutation fields for clients that cannot set headers
+	ArgumentName = "idempotencyKey"
+	// MaxKeyLength is the maximum length of a key, clients should use a UUID
+	MaxKeyLength = 255
+)
+
+// processingTimeout is the time after which a key of a request that did not complete (e.g. a crashed server) can be used again
+const processingTimeout = time.Minute
+
+// Extension is a handler extension that stores the response of mutations by an idempotency key of the client.
+// A retry with the same key gets the stored response without executing the mutation again.
+// Keys are scoped by the authenticated account, so unauthenticated mutations (e.g. login) are not handled.
+type Extension struct {
+	DB         *sql.DB
+	TimeSource types.TimeSource
+	// Retention of stored responses, a key can be used again after that
+	Retention time.Duration
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationInterceptor
+} = Extension{}
+
+func (e Extension) ExtensionName() string {
+	return "IdempotencyKey"
+}
+
+func (e Extension) Validate(_ graphql.ExecutableSchema) error {
+	if e.Retention <= 0 {
+		return errors.New("retention must be positive")
+	}
+	return nil
+}
+
+func (e Extension) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	opCtx := graphql.GetOperationContext(ctx)
+	if opCtx.Operation == nil || opCtx.Operation.Operation != ast.Mutation {
+		return next(ctx)
+	}
+	authCtx := authentication.GetAuthContext(ctx)
+	if !authCtx.Authenticated {
+		return next(ctx)
+	}
+
+	key, ok := requestKey(ctx, opCtx)
+	if !ok {
+		return errorResponse(api.ErrIdempotencyKeyInvalid)
+	}
+	if key == "" {
+		return next(ctx)
+	}
+
+	log := logger.FromContext(ctx).
+		WithField("component", "idempotency").
+		WithField("idempotencyKey", key)
+
+	requestHash := RequestHash(opCtx)
+
+	stored, err := e.acquire(ctx, authCtx, key, requestHash)
+	var typedErr api.TypedError
+	if errors.As(err, &typedErr) {
+		return errorResponse(typedErr)
+	} else if err != nil {
+		log.WithError(err).Error("Could not acquire idempotency key")
+		return graphql.OneShot(graphql.ErrorResponse(ctx, "internal server error"))
+	}
+	if stored != nil {
+		api.GetHTTPResponse(ctx).Header().Set(HeaderReplayed, "true")
+		return graphql.OneShot(stored)
+	}
+
+	resp := next(ctx)(ctx)
+
+	// The response must be stored (or the key released) even if the request was cancelled
+	err = e.complete(context.WithoutCancel(ctx), authCtx, key, resp)
+	if err != nil {
+		log.WithError(err).Error("Could not store response for idempotency key")
+	}
+
+	return graphql.OneShot(resp)
+}
+
+// acquire inserts the key for processing the request.
+// It returns the stored response if the key was used for the same request before.
+func (e Extension) acquire(ctx context.Context, authCtx authentication.AuthContext, key, requestHash string) (*graphql.Response, error) {
+	// An expired key is deleted and inserted again, so a second attempt is needed
+	for attempt := 0; attempt < 2; attempt++ {
+		now := e.TimeSource.Now()
+		inserted, err := repository.InsertIdempotencyKey(ctx, e.DB, repository.IdempotencyKeyToChangeSet(model.IdempotencyKey{
+			AccountID:   authCtx.AccountID,
+			Key:         key,
+			RequestHash: requestHash,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(processingTimeout),
+		}))
+		if err != nil {
+			return nil, errors.Wrap(err, "inserting idempotency key")
+		}
+		if inserted {
+			return nil, nil
+		}
+
+		record, err := repository.FindIdempotencyKey(ctx, e.DB, authCtx.AccountID, key)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrap(err, "finding idempotency key")
+		}
+
+		if !record.ExpiresAt.After(now) {
+			err = repository.DeleteIdempotencyKey(ctx, e.DB, authCtx.AccountID, key)
+			if err != nil {
+				return nil, errors.Wrap(err, "deleting expired idempotency key")
+			}
+			continue
+		}
+		if record.RequestHash != requestHash {
+			return nil, api.ErrIdempotencyKeyConflict
+		}
+		if !record.Completed() {
+			return nil, api.ErrIdempotencyKeyInProgress
+		}
+
+		var resp graphql.Response
+		err = json.Unmarshal(record.Response, &resp)
+		if err != nil {
+			return nil, errors.Wrap(err, "decoding stored response")
+		}
+		return &resp, nil
+	}
+
+	return nil, api.ErrIdempotencyKeyInProgress
+}
+
+// complete stores the response for the key.
+// Responses with unexpected errors (without extensions) are not stored and the key is released, so the request can be retried.
+func (e Extension) complete(ctx context.Context, authCtx authentication.AuthContext, key string, resp *graphql.Response) error {
+	if resp == nil || !storable(resp) {
+		return repository.DeleteIdempotencyKey(ctx, e.DB, authCtx.AccountID, key)
+	}
+
+	encoded, err := json.Marshal(resp)
+	if err != nil {
+		return errors.Wrap(err, "encoding response")
+	}
+	response := json.RawMessage(encoded)
+
+	now := e.TimeSource.Now()
+	completedAt := &now
+	expiresAt := now.Add(e.Retention)
+	return repository.UpdateIdempotencyKey(ctx, e.DB, authCtx.AccountID, key, repository.IdempotencyKeyChangeSet{
+		Response:    &response,
+		CompletedAt: &completedAt,
+		ExpiresAt:   &expiresAt,
+	})
+}
+
+func storable(resp *graphql.Response) bool {
+	for _, err := range resp.Errors {
+		if len(err.Extensions) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// requestKey gets the key from the header or the argument of the mutation fields.
+// It returns false if the key is too long or different keys are given.
+func requestKey(ctx context.Context, opCtx *graphql.OperationContext) (string, bool) {
+	key := api.GetHTTPRequest(ctx).Header.Get(HeaderKey)
+
+	for _, field := range graphql.CollectFields(opCtx, opCtx.Operation.SelectionSet, []string{"Mutation"}) {
+		argumentKey, _ := field.ArgumentMap(opCtx.Variables)[ArgumentName].(string)
+		if argumentKey == "" {
+			continue
+		}
+		if key != "" && key != argumentKey {
+			return "", false
+		}
+		key = argumentKey
+	}
+
+	return key, len(key) <= MaxKeyLength
+}
+
+// RequestHash identifies the request of an operation by the query, operation name and variables
+func RequestHash(opCtx *graphql.OperationContext) string {
+	// Keys of maps are sorted when encoded, so equal variables have the same encoding
+	variables, _ := json.Marshal(opCtx.Variables)
+
+	h := sha256.New()
+	h.Write([]byte(opCtx.RawQuery))
+	h.Write([]byte{0})
+	h.Write([]byte(opCtx.OperationName))
+	h.Write([]byte{0})
+	h.Write(variables)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func errorResponse(err api.TypedError) graphql.ResponseHandler {
+	return graphql.OneShot(&graphql.Response{
+		Errors: gqlerror.List{{
+			Message:    err.Error(),
+			Extensions: err.Extensions(),
+		}},
+	})
+}
@@ -0,0 +1,119 @@
+package loader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// FetchFunc fetches values for multiple keys at once.
+// Values and errors must be returned in the order of keys.
+type FetchFunc[K comparable, V any] func(ctx context.Context, keys []K) ([]V, []error)
+
+// BatchLoader collects keys that are loaded within a short wait duration and fetches them with a single call.
+// Results are cached per key, so a BatchLoader must only be used for a single request.
+type BatchLoader[K comparable, V any] struct {
+	fetch    FetchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	batch   *batch[K, V]
+	results map[K]*result[V]
+}
+
+type batch[K comparable, V any] struct {
+	keys []K
+	// results of the batch in the order of keys
+	results []*result[V]
+	done    chan struct{}
+}
+
+type result[V any] struct {
+	// done is closed by the batch after value and err are set
+	done  chan struct{}
+	value V
+	err   error
+}
+
+const (
+	defaultWait     = 2 * time.Millisecond
+	defaultMaxBatch = 100
+)
+
+// NewBatchLoader creates a new batch loader that calls fetch for collected keys.
+func NewBatchLoader[K comparable, V any](fetch FetchFunc[K, V]) *BatchLoader[K, V] {
+	return &BatchLoader[K, V]{
+		fetch:    fetch,
+		wait:     defaultWait,
+		maxBatch: defaultMaxBatch,
+		results:  make(map[K]*result[V]),
+	}
+}
+
+// Load a value for the given key. It blocks until the batch containing the key is fetched.
+func (l *BatchLoader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	res := l.enqueue(ctx, key)
+
+	select {
+	case <-res.done:
+		return res.value, res.err
+	case <-ctx.Done():
+		var value V
+		return value, ctx.Err()
+	}
+}
+
+func (l *BatchLoader[K, V]) enqueue(ctx context.Context, key K) *result[V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if res, ok := l.results[key]; ok {
+		return res
+	}
+
+	res := &result[V]{done: make(chan struct{})}
+	l.results[key] = res
+
+	if l.batch == nil {
+		l.batch = &batch[K, V]{done: make(chan struct{})}
+		go l.dispatchAfterWait(ctx, l.batch)
+	}
+	l.batch.keys = append(l.batch.keys, key)
+	l.batch.results = append(l.batch.results, res)
+
+	if len(l.batch.keys) >= l.maxBatch {
+		close(l.batch.done)
+		l.batch = nil
+	}
+
+	return res
+}
+
+func (l *BatchLoader[K, V]) dispatchAfterWait(ctx context.Context, b *batch[K, V]) {
+	timer := time.NewTimer(l.wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		l.mu.Lock()
+		// The batch could have been closed by reaching the max batch size in the meantime
+		if l.batch == b {
+			l.batch = nil
+		}
+		l.mu.Unlock()
+	case <-b.done:
+	}
+
+	// Keys cannot be added anymore after the batch was detached from the loader
+	values, errs := l.fetch(ctx, b.keys)
+	for i, res := range b.results {
+		if i < len(values) {
+			res.value = values[i]
+		}
+		if i < len(errs) {
+			res.err = errs[i]
+		}
+		close(res.done)
+	}
+}
@@ -0,0 +1,62 @@
+package loader_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api/graph/loader"
+)
+
+var errOdd = errors.New("odd key")
+
+func TestBatchLoader_Load(t *testing.T) {
+	var fetchCalls atomic.Int32
+	var fetchedKeys []int
+
+	l := loader.NewBatchLoader(func(ctx context.Context, keys []int) ([]string, []error) {
+		fetchCalls.Add(1)
+		fetchedKeys = append(fetchedKeys, keys...)
+
+		values := make([]string, len(keys))
+		errs := make([]error, len(keys))
+		for i, key := range keys {
+			if key%2 == 1 {
+				errs[i] = errOdd
+				continue
+			}
+			values[i] = string(rune('a' + key))
+		}
+		return values, errs
+	})
+
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	values := make([]string, 5)
+	errs := make([]error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(key int) {
+			defer wg.Done()
+			values[key], errs[key] = l.Load(ctx, key)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), fetchCalls.Load(), "fetch calls")
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4}, fetchedKeys, "fetched keys")
+	assert.Equal(t, []string{"a", "", "c", "", "e"}, values)
+	assert.Equal(t, []error{nil, errOdd, nil, errOdd, nil}, errs)
+
+	// Loading a key again uses the cached result
+	value, err := l.Load(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "c", value)
+	assert.Equal(t, int32(1), fetchCalls.Load(), "fetch calls after cached load")
+}
@@ -0,0 +1,77 @@
+package loader
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/finder"
+)
+
+// Loaders are request-scoped batch loaders for resolving nested fields without N+1 queries
+type Loaders struct {
+	finder *finder.Finder
+
+	OrganisationByID *BatchLoader[uuid.UUID, model.Organisation]
+
+	mu                             sync.Mutex
+	accountsByOrganisationIDSorted map[accountsSortKey]*BatchLoader[uuid.UUID, []model.Account]
+}
+
+type accountsSortKey struct {
+	sortField string
+	sortOrder string
+	perPage   int
+}
+
+func NewLoaders(f *finder.Finder) *Loaders {
+	return &Loaders{
+		finder: f,
+		OrganisationByID: NewBatchLoader(func(ctx context.Context, ids []uuid.UUID) ([]model.Organisation, []error) {
+			return f.QueryOrganisationsByIDs(ctx, ids, nil)
+		}),
+		accountsByOrganisationIDSorted: make(map[accountsSortKey]*BatchLoader[uuid.UUID, []model.Account]),
+	}
+}
+
+// AccountsByOrganisationID returns a loader for accounts of an organisation with the given sorting and limit per organisation.
+// Organisations that are loaded with the same sorting and limit are batched.
+func (l *Loaders) AccountsByOrganisationID(paging finder.Paging) *BatchLoader[uuid.UUID, []model.Account] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := accountsSortKey{}
+	if paging.SortField != nil {
+		key.sortField = *paging.SortField
+	}
+	if paging.SortOrder != nil {
+		key.sortOrder = *paging.SortOrder
+	}
+	if paging.PerPage != nil {
+		key.perPage = *paging.PerPage
+	}
+
+	batchLoader, ok := l.accountsByOrganisationIDSorted[key]
+	if !ok {
+		batchLoader = NewBatchLoader(func(ctx context.Context, organisationIDs []uuid.UUID) ([][]model.Account, []error) {
+			return l.finder.QueryAccountsByOrganisationIDs(ctx, organisationIDs, nil, paging)
+		})
+		l.accountsByOrganisationIDSorted[key] = batchLoader
+	}
+	return batchLoader
+}
+
+type ctxKey string
+
+const loadersKey ctxKey = "loaders"
+
+func WithLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey, loaders)
+}
+
+// For gets the loaders for the current request from context
+func For(ctx context.Context) *Loaders {
+	return ctx.Value(loadersKey).(*Loaders) //nolint:forcetypeassert
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"myvendor.mytld/myproject/backend/api/graph/loader"
+	"myvendor.mytld/myproject/backend/finder"
+)
+
+// LoadersOperationMiddleware adds new batch loaders to the context of every operation,
+// so cached results are never shared between requests.
+func LoadersOperationMiddleware(f *finder.Finder) graphql.OperationMiddleware {
+	return func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		return next(loader.WithLoaders(ctx, loader.NewLoaders(f)))
+	}
+}
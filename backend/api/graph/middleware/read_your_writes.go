@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"myvendor.mytld/myproject/backend/finder"
+)
+
+// ReadYourWritesOperationMiddleware routes queries of mutations and subscriptions to the primary database.
+// Mutations read the results of their commands and subscriptions read records right after change notifications,
+// both could not be replicated to a read replica yet.
+func ReadYourWritesOperationMiddleware(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	opCtx := graphql.GetOperationContext(ctx)
+	if opCtx.Operation != nil && opCtx.Operation.Operation != ast.Query {
+		ctx = finder.WithPrimary(ctx)
+	}
+	return next(ctx)
+}
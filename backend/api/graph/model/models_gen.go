@@ -3,29 +3,141 @@
 package model
 
 import (
+	"fmt"
+	"io"
+	"strconv"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"myvendor.mytld/myproject/backend/domain/types"
 )
 
+// Record found by a search
+type SearchResultNode interface {
+	IsSearchResultNode()
+}
+
 type Account struct {
 	ID             uuid.UUID  `json:"id"`
 	EmailAddress   string     `json:"emailAddress"`
 	Role           types.Role `json:"role"`
 	LastLogin      *time.Time `json:"lastLogin,omitempty"`
 	OrganisationID *uuid.UUID `json:"organisationId,omitempty"`
-	CreatedAt      time.Time  `json:"createdAt"`
-	UpdatedAt      time.Time  `json:"updatedAt"`
+	// Organisation of the account (if assigned)
+	Organisation *Organisation `json:"organisation,omitempty"`
+	CreatedAt    time.Time     `json:"createdAt"`
+	UpdatedAt    time.Time     `json:"updatedAt"`
+	// Incremented on every change, send it as expectedVersion to detect concurrent updates
+	Version int `json:"version"`
+	// Set if the account was deleted, it can be restored until it is purged
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+func (Account) IsSearchResultNode() {}
+
+type AccountChangedEvent struct {
+	Action         ChangeAction `json:"action"`
+	AccountID      uuid.UUID    `json:"accountId"`
+	OrganisationID *uuid.UUID   `json:"organisationId,omitempty"`
+	// The organisation before the change, only set if the account was moved to another organisation
+	PreviousOrganisationID *uuid.UUID `json:"previousOrganisationId,omitempty"`
+	// The account after the change, null if it was deleted or moved to an organisation that is not visible
+	Account *Account `json:"account,omitempty"`
+}
+
+type AccountConnection struct {
+	Edges    []*AccountEdge `json:"edges"`
+	PageInfo *PageInfo      `json:"pageInfo"`
+	// Total count of accounts matching the filter (ignoring pagination)
+	TotalCount int `json:"totalCount"`
+}
+
+type AccountEdge struct {
+	Cursor string   `json:"cursor"`
+	Node   *Account `json:"node"`
 }
 
 type AccountFilter struct {
 	// Filter by multiple ids for fetching references
 	Ids []uuid.UUID `json:"ids,omitempty"`
-	// Filter by a query string on all text fields
+	// Filter by words (matching prefixes) or a part of the email address, results are ordered by relevance if no sortField is given
 	Q *string `json:"q,omitempty"`
 	// Filter by organisation id
 	OrganisationID *uuid.UUID `json:"organisationId,omitempty"`
+	// Include deleted accounts (system administrators only)
+	WithDeleted *bool `json:"withDeleted,omitempty"`
+}
+
+type AccountOrder struct {
+	Field AccountOrderField `json:"field"`
+	// Defaults to Asc
+	Direction *OrderDirection `json:"direction,omitempty"`
+}
+
+type Asset struct {
+	ID uuid.UUID `json:"id"`
+	// Owner organisation, not set for assets of system administrators
+	OrganisationID *uuid.UUID `json:"organisationId,omitempty"`
+	Filename       string     `json:"filename"`
+	// Detected MIME type of the content
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	// Hex encoded SHA-256 checksum of the content
+	Checksum string `json:"checksum"`
+	// Signed path for downloading the content, it expires after a short time
+	DownloadURL string `json:"downloadUrl"`
+	// Width in pixels, only set for images
+	Width *int `json:"width,omitempty"`
+	// Height in pixels, only set for images
+	Height *int `json:"height,omitempty"`
+	// Signed path for a resized variant of an image, not set for other files
+	VariantURL *string   `json:"variantUrl,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// Change of a field by a command
+type AuditChange struct {
+	Field string `json:"field"`
+	// JSON encoded value before the command, null if it was not set
+	Before *string `json:"before,omitempty"`
+	// JSON encoded value after the command, null if it was removed
+	After *string `json:"after,omitempty"`
+	// Set for secrets (e.g. passwords), their values are not recorded
+	Redacted bool `json:"redacted"`
+}
+
+// An administrative command recorded in the audit log
+type AuditEntry struct {
+	ID         uuid.UUID `json:"id"`
+	OccurredAt time.Time `json:"occurredAt"`
+	// Account that executed the command, not set for commands of the CLI
+	ActorAccountID *uuid.UUID `json:"actorAccountId,omitempty"`
+	// Account that acted on behalf of the actor
+	ImpersonatorAccountID *uuid.UUID `json:"impersonatorAccountId,omitempty"`
+	OrganisationID        *uuid.UUID `json:"organisationId,omitempty"`
+	// Name of the command (e.g. AccountUpdate)
+	CommandType string `json:"commandType"`
+	// Records affected by the command
+	TargetIds []uuid.UUID `json:"targetIds"`
+	// Changed fields ordered by name
+	Changes   []*AuditChange `json:"changes"`
+	RequestID *string        `json:"requestId,omitempty"`
+	IPAddress *string        `json:"ipAddress,omitempty"`
+}
+
+type AuditEntryFilter struct {
+	// Filter by organisation id
+	OrganisationID *uuid.UUID `json:"organisationId,omitempty"`
+	// Filter by the account that executed the command
+	ActorAccountID *uuid.UUID `json:"actorAccountId,omitempty"`
+	// Filter by the name of the command
+	CommandType *string `json:"commandType,omitempty"`
+	// Filter by a record that was affected by the command
+	TargetID *uuid.UUID `json:"targetId,omitempty"`
+	// Start of the time range (inclusive)
+	OccurredFrom *time.Time `json:"occurredFrom,omitempty"`
+	// End of the time range (exclusive)
+	OccurredTo *time.Time `json:"occurredTo,omitempty"`
 }
 
 // A generic application error (for expected errors)
@@ -82,17 +194,60 @@ type Mutation struct {
 }
 
 type Organisation struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	// Accounts assigned to the organisation, at most first accounts are returned
+	Accounts  []*Account `json:"accounts"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	// Incremented on every change, send it as expectedVersion to detect concurrent updates
+	Version int `json:"version"`
+	// Set if the organisation was deleted, it can be restored until it is purged
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+func (Organisation) IsSearchResultNode() {}
+
+type OrganisationChangedEvent struct {
+	Action         ChangeAction `json:"action"`
+	OrganisationID uuid.UUID    `json:"organisationId"`
+	// The organisation after the change, null if it was deleted
+	Organisation *Organisation `json:"organisation,omitempty"`
+}
+
+type OrganisationConnection struct {
+	Edges    []*OrganisationEdge `json:"edges"`
+	PageInfo *PageInfo           `json:"pageInfo"`
+	// Total count of organisations matching the filter (ignoring pagination)
+	TotalCount int `json:"totalCount"`
+}
+
+type OrganisationEdge struct {
+	Cursor string        `json:"cursor"`
+	Node   *Organisation `json:"node"`
 }
 
 type OrganisationFilter struct {
 	// Filter by multiple ids for fetching references
 	Ids []uuid.UUID `json:"ids,omitempty"`
-	// Filter by a query string on all text fields
+	// Filter by words of the name (matching prefixes), results are ordered by relevance if no sortField is given
 	Q *string `json:"q,omitempty"`
+	// Include deleted organisations (system administrators only)
+	WithDeleted *bool `json:"withDeleted,omitempty"`
+}
+
+type OrganisationOrder struct {
+	Field OrganisationOrderField `json:"field"`
+	// Defaults to Asc
+	Direction *OrderDirection `json:"direction,omitempty"`
+}
+
+// Information about pagination in a connection
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
 }
 
 type Query struct {
@@ -102,3 +257,372 @@ type Result struct {
 	// An error if the operation failed
 	Error *FieldsError `json:"error,omitempty"`
 }
+
+// Result of a search with the matched text of the record
+type SearchResult struct {
+	Node SearchResultNode `json:"node"`
+	// Relevance for the search term between 0 and 1, higher is better
+	Rank float64 `json:"rank"`
+	// Searched text of the record (email address of accounts, name of organisations)
+	Text string `json:"text"`
+	// HTML escaped text with matched words of the search term wrapped in <b> tags
+	Highlight string `json:"highlight"`
+}
+
+type Subscription struct {
+}
+
+type WebhookDelivery struct {
+	ID                uuid.UUID `json:"id"`
+	WebhookEndpointID uuid.UUID `json:"webhookEndpointId"`
+	// ID of the event, it is sent as Webhook-Id and stays the same for retries
+	EventID   uuid.UUID        `json:"eventId"`
+	EventType WebhookEventType `json:"eventType"`
+	// JSON request body
+	Payload  string                `json:"payload"`
+	Status   WebhookDeliveryStatus `json:"status"`
+	Attempts int                   `json:"attempts"`
+	// HTTP status code of the last attempt, not set if no response was received
+	ResponseStatus *int       `json:"responseStatus,omitempty"`
+	LastError      *string    `json:"lastError,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	LastAttemptAt  *time.Time `json:"lastAttemptAt,omitempty"`
+	DeliveredAt    *time.Time `json:"deliveredAt,omitempty"`
+}
+
+// An HTTP endpoint that receives events of an organisation as signed POST requests
+type WebhookEndpoint struct {
+	ID             uuid.UUID `json:"id"`
+	OrganisationID uuid.UUID `json:"organisationId"`
+	URL            string    `json:"url"`
+	// Key for verifying the Webhook-Signature header (HMAC-SHA256 of Webhook-Timestamp, a dot and the body)
+	Secret     string             `json:"secret"`
+	EventTypes []WebhookEventType `json:"eventTypes"`
+	// Log of events sent to the endpoint, newest first if no sortField is given
+	Deliveries []*WebhookDelivery `json:"deliveries"`
+	CreatedAt  time.Time          `json:"createdAt"`
+	UpdatedAt  time.Time          `json:"updatedAt"`
+}
+
+type AccountOrderField string
+
+const (
+	AccountOrderFieldID           AccountOrderField = "id"
+	AccountOrderFieldEmailAddress AccountOrderField = "emailAddress"
+	AccountOrderFieldRole         AccountOrderField = "role"
+	AccountOrderFieldCreatedAt    AccountOrderField = "createdAt"
+	AccountOrderFieldUpdatedAt    AccountOrderField = "updatedAt"
+)
+
+var AllAccountOrderField = []AccountOrderField{
+	AccountOrderFieldID,
+	AccountOrderFieldEmailAddress,
+	AccountOrderFieldRole,
+	AccountOrderFieldCreatedAt,
+	AccountOrderFieldUpdatedAt,
+}
+
+func (e AccountOrderField) IsValid() bool {
+	switch e {
+	case AccountOrderFieldID, AccountOrderFieldEmailAddress, AccountOrderFieldRole, AccountOrderFieldCreatedAt, AccountOrderFieldUpdatedAt:
+		return true
+	}
+	return false
+}
+
+func (e AccountOrderField) String() string {
+	return string(e)
+}
+
+func (e *AccountOrderField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AccountOrderField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AccountOrderField", str)
+	}
+	return nil
+}
+
+func (e AccountOrderField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ChangeAction string
+
+const (
+	ChangeActionCreated  ChangeAction = "Created"
+	ChangeActionUpdated  ChangeAction = "Updated"
+	ChangeActionDeleted  ChangeAction = "Deleted"
+	ChangeActionRestored ChangeAction = "Restored"
+)
+
+var AllChangeAction = []ChangeAction{
+	ChangeActionCreated,
+	ChangeActionUpdated,
+	ChangeActionDeleted,
+	ChangeActionRestored,
+}
+
+func (e ChangeAction) IsValid() bool {
+	switch e {
+	case ChangeActionCreated, ChangeActionUpdated, ChangeActionDeleted, ChangeActionRestored:
+		return true
+	}
+	return false
+}
+
+func (e ChangeAction) String() string {
+	return string(e)
+}
+
+func (e *ChangeAction) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ChangeAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ChangeAction", str)
+	}
+	return nil
+}
+
+func (e ChangeAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Predefined sizes of images, they are scaled down to fit into the size
+type ImageVariant string
+
+const (
+	// 160x160 pixels
+	ImageVariantThumbnail ImageVariant = "Thumbnail"
+	// 480x480 pixels
+	ImageVariantSmall ImageVariant = "Small"
+	// 1600x1600 pixels
+	ImageVariantLarge ImageVariant = "Large"
+)
+
+var AllImageVariant = []ImageVariant{
+	ImageVariantThumbnail,
+	ImageVariantSmall,
+	ImageVariantLarge,
+}
+
+func (e ImageVariant) IsValid() bool {
+	switch e {
+	case ImageVariantThumbnail, ImageVariantSmall, ImageVariantLarge:
+		return true
+	}
+	return false
+}
+
+func (e ImageVariant) String() string {
+	return string(e)
+}
+
+func (e *ImageVariant) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ImageVariant(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ImageVariant", str)
+	}
+	return nil
+}
+
+func (e ImageVariant) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type OrderDirection string
+
+const (
+	OrderDirectionAsc  OrderDirection = "Asc"
+	OrderDirectionDesc OrderDirection = "Desc"
+)
+
+var AllOrderDirection = []OrderDirection{
+	OrderDirectionAsc,
+	OrderDirectionDesc,
+}
+
+func (e OrderDirection) IsValid() bool {
+	switch e {
+	case OrderDirectionAsc, OrderDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e OrderDirection) String() string {
+	return string(e)
+}
+
+func (e *OrderDirection) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderDirection", str)
+	}
+	return nil
+}
+
+func (e OrderDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type OrganisationOrderField string
+
+const (
+	OrganisationOrderFieldID        OrganisationOrderField = "id"
+	OrganisationOrderFieldName      OrganisationOrderField = "name"
+	OrganisationOrderFieldCreatedAt OrganisationOrderField = "createdAt"
+	OrganisationOrderFieldUpdatedAt OrganisationOrderField = "updatedAt"
+)
+
+var AllOrganisationOrderField = []OrganisationOrderField{
+	OrganisationOrderFieldID,
+	OrganisationOrderFieldName,
+	OrganisationOrderFieldCreatedAt,
+	OrganisationOrderFieldUpdatedAt,
+}
+
+func (e OrganisationOrderField) IsValid() bool {
+	switch e {
+	case OrganisationOrderFieldID, OrganisationOrderFieldName, OrganisationOrderFieldCreatedAt, OrganisationOrderFieldUpdatedAt:
+		return true
+	}
+	return false
+}
+
+func (e OrganisationOrderField) String() string {
+	return string(e)
+}
+
+func (e *OrganisationOrderField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrganisationOrderField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrganisationOrderField", str)
+	}
+	return nil
+}
+
+func (e OrganisationOrderField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	// Sending or waiting for a retry
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	// Not accepted after all attempts, it can be redelivered
+	WebhookDeliveryStatusFailed WebhookDeliveryStatus = "failed"
+)
+
+var AllWebhookDeliveryStatus = []WebhookDeliveryStatus{
+	WebhookDeliveryStatusPending,
+	WebhookDeliveryStatusSucceeded,
+	WebhookDeliveryStatusFailed,
+}
+
+func (e WebhookDeliveryStatus) IsValid() bool {
+	switch e {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusSucceeded, WebhookDeliveryStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e WebhookDeliveryStatus) String() string {
+	return string(e)
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookDeliveryStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookDeliveryStatus", str)
+	}
+	return nil
+}
+
+func (e WebhookDeliveryStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type WebhookEventType string
+
+const (
+	WebhookEventTypeAccountCreated       WebhookEventType = "AccountCreated"
+	WebhookEventTypeAccountUpdated       WebhookEventType = "AccountUpdated"
+	WebhookEventTypeAccountDeleted       WebhookEventType = "AccountDeleted"
+	WebhookEventTypeAccountRestored      WebhookEventType = "AccountRestored"
+	WebhookEventTypeOrganisationCreated  WebhookEventType = "OrganisationCreated"
+	WebhookEventTypeOrganisationUpdated  WebhookEventType = "OrganisationUpdated"
+	WebhookEventTypeOrganisationDeleted  WebhookEventType = "OrganisationDeleted"
+	WebhookEventTypeOrganisationRestored WebhookEventType = "OrganisationRestored"
+)
+
+var AllWebhookEventType = []WebhookEventType{
+	WebhookEventTypeAccountCreated,
+	WebhookEventTypeAccountUpdated,
+	WebhookEventTypeAccountDeleted,
+	WebhookEventTypeAccountRestored,
+	WebhookEventTypeOrganisationCreated,
+	WebhookEventTypeOrganisationUpdated,
+	WebhookEventTypeOrganisationDeleted,
+	WebhookEventTypeOrganisationRestored,
+}
+
+func (e WebhookEventType) IsValid() bool {
+	switch e {
+	case WebhookEventTypeAccountCreated, WebhookEventTypeAccountUpdated, WebhookEventTypeAccountDeleted, WebhookEventTypeAccountRestored, WebhookEventTypeOrganisationCreated, WebhookEventTypeOrganisationUpdated, WebhookEventTypeOrganisationDeleted, WebhookEventTypeOrganisationRestored:
+		return true
+	}
+	return false
+}
+
+func (e WebhookEventType) String() string {
+	return string(e)
+}
+
+func (e *WebhookEventType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookEventType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookEventType", str)
+	}
+	return nil
+}
+
+func (e WebhookEventType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
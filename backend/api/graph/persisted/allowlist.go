@@ -0,0 +1,68 @@
+package persisted
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/security/authentication"
+)
+
+// Allowlist is a handler extension that only executes operations from a manifest of persisted queries.
+// Clients can send the hash of a document (in the format of automatic persisted queries) instead of the query.
+// System administrators are allowed to send any query (e.g. for an admin UI or the playground).
+type Allowlist struct {
+	Manifest Manifest
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationParameterMutator
+} = Allowlist{}
+
+func (a Allowlist) ExtensionName() string {
+	return "PersistedQueryAllowlist"
+}
+
+func (a Allowlist) Validate(_ graphql.ExecutableSchema) error {
+	return a.Manifest.Validate()
+}
+
+func (a Allowlist) MutateOperationParameters(ctx context.Context, rawParams *graphql.RawParams) *gqlerror.Error {
+	if rawParams.Query == "" {
+		document, ok := a.Manifest[persistedQueryHash(rawParams)]
+		if !ok {
+			return typedError(api.ErrPersistedQueryNotFound)
+		}
+		rawParams.Query = document
+		return nil
+	}
+
+	if _, ok := a.Manifest[Hash(rawParams.Query)]; ok {
+		return nil
+	}
+
+	authCtx := authentication.GetAuthContext(ctx)
+	if authCtx.Authenticated && authCtx.Role == types.RoleSystemAdministrator {
+		return nil
+	}
+
+	return typedError(api.ErrOperationNotAllowlisted)
+}
+
+// persistedQueryHash gets the hash from the extension used for automatic persisted queries
+func persistedQueryHash(rawParams *graphql.RawParams) string {
+	extension, _ := rawParams.Extensions["persistedQuery"].(map[string]any)
+	hash, _ := extension["sha256Hash"].(string)
+	return hash
+}
+
+func typedError(err api.TypedError) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message:    err.Error(),
+		Extensions: err.Extensions(),
+	}
+}
@@ -0,0 +1,70 @@
+package persisted
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+
+	"github.com/friendsofgo/errors"
+	"github.com/networkteam/qrb/qrbsql"
+
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+// Manifest maps the SHA-256 hash (hex encoded) of a GraphQL document to the document
+type Manifest map[string]string
+
+// Hash computes the hash of a document as it is used for automatic persisted queries
+func Hash(document string) string {
+	sum := sha256.Sum256([]byte(document))
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadManifestFile reads a manifest from a JSON file with an object of hashes to documents.
+func LoadManifestFile(path string) (Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading manifest file")
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, errors.Wrap(err, "decoding manifest file")
+	}
+	// A nil manifest would disable the allowlist
+	if manifest == nil {
+		manifest = Manifest{}
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// LoadManifestFromDB reads all persisted queries from the database.
+func LoadManifestFromDB(ctx context.Context, executor qrbsql.Executor) (Manifest, error) {
+	records, err := repository.FindAllPersistedQueries(ctx, executor)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding persisted queries")
+	}
+
+	manifest := make(Manifest, len(records))
+	for _, record := range records {
+		manifest[record.Hash] = record.Document
+	}
+	return manifest, nil
+}
+
+// Validate checks that every hash matches its document
+func (m Manifest) Validate() error {
+	for hash, document := range m {
+		if Hash(document) != hash {
+			return errors.Errorf("hash %s does not match document", hash)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package persisted_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api/graph/persisted"
+)
+
+func TestLoadManifestFile(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("valid manifest", func(t *testing.T) {
+		path := filepath.Join(dir, "valid.json")
+		document := "query Echo { echo(hello: \"world\") }"
+		require.NoError(t, os.WriteFile(path, []byte(`{"`+persisted.Hash(document)+`": "query Echo { echo(hello: \"world\") }"}`), 0o600))
+
+		manifest, err := persisted.LoadManifestFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, persisted.Manifest{persisted.Hash(document): document}, manifest)
+	})
+
+	t.Run("hash not matching document", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"abc": "query { echo(hello: \"world\") }"}`), 0o600))
+
+		_, err := persisted.LoadManifestFile(path)
+		require.Error(t, err)
+	})
+
+	t.Run("empty manifest", func(t *testing.T) {
+		path := filepath.Join(dir, "empty.json")
+		require.NoError(t, os.WriteFile(path, []byte(`null`), 0o600))
+
+		manifest, err := persisted.LoadManifestFile(path)
+		require.NoError(t, err)
+		assert.NotNil(t, manifest, "manifest must not be nil to keep the allowlist enabled")
+	})
+}
@@ -21,8 +21,9 @@ func NewResolver(deps api.ResolverDependencies, config api.ResolverConfig) *Reso
 		handler: handler.NewHandler(deps.DB, deps.Config, handler.Deps{
 			TimeSource:    deps.TimeSource,
 			Mailer:        deps.Mailer,
+			Storage:       deps.Storage,
 			MeterProvider: deps.MeterProvider,
 		}),
-		finder: finder.NewFinder(deps.DB, deps.TimeSource),
+		finder: finder.NewFinder(deps.DB, deps.TimeSource, finder.WithReplica(deps.Replica)),
 	}
 }
@@ -0,0 +1,25 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.49
+
+import (
+	"context"
+
+	"myvendor.mytld/myproject/backend/api/graph/helper"
+	"myvendor.mytld/myproject/backend/api/graph/model"
+)
+
+// Search is the resolver for the search field.
+func (r *queryResolver) Search(ctx context.Context, q string, limit *int) ([]*model.SearchResult, error) {
+	query, err := helper.MapToSearchQuery(q, limit)
+	if err != nil {
+		return nil, err
+	}
+	records, err := r.finder.QuerySearch(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return helper.MapToSearchResults(records), nil
+}
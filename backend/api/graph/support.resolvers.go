@@ -0,0 +1,38 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.49
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/api/graph/model"
+	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/security/authentication"
+)
+
+// SubmitSupportRequest is the resolver for the submitSupportRequest field.
+func (r *mutationResolver) SubmitSupportRequest(ctx context.Context, subject string, message string, attachment *graphql.Upload) (*model.Result, error) {
+	authCtx := authentication.GetAuthContext(ctx)
+
+	cmd, err := command.NewSupportRequestSubmitCmd(authCtx.AccountID, subject, message)
+	if err != nil {
+		return nil, err
+	}
+	if attachment != nil {
+		err = cmd.Attach(attachment.Filename, attachment.Size, attachment.File)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = r.handler.SupportRequestSubmit(ctx, cmd)
+	if err != nil {
+		return api.ResultFromErr(err)
+	}
+
+	return &model.Result{}, nil
+}
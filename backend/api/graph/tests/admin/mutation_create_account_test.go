@@ -31,6 +31,8 @@ const createAccountGQL = `
 `
 
 func TestMutationResolver_CreateAccount(t *testing.T) {
+	t.Parallel()
+
 	type result struct {
 		Data struct {
 			Result *struct {
@@ -66,6 +68,29 @@ func TestMutationResolver_CreateAccount(t *testing.T) {
 				assert.Equal(t, "test@acme.com", account.EmailAddress)
 			},
 		},
+		{
+			name:          "with SystemAdministrator and deleted organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base", "deleted"},
+			variables: map[string]interface{}{
+				"role":           "OrganisationAdministrator",
+				"emailAddress":   "test@othercorp.com",
+				"password":       "myRandomPassword",
+				"organisationId": "dba20d09-a3df-4975-9406-2fb6fd8f0940",
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireErrors(t, res.GraphqlErrors, test_graphql.GraphqlError{
+					Extensions: test_graphql.GraphqlErrorExtensions{
+						Type:  "validationFailed",
+						Field: "organisationId",
+						Code:  "notExists",
+					},
+				})
+
+				_, err := repository.FindAccountByEmailAddress(context.Background(), db, "test@othercorp.com", nil)
+				require.ErrorIs(t, err, repository.ErrNotFound)
+			},
+		},
 		{
 			name:          "with SystemAdministrator and existing email address",
 			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
@@ -134,11 +159,9 @@ func TestMutationResolver_CreateAccount(t *testing.T) {
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			db := test_db.CreateTestDatabase(t)
+			db := test_db.CreateTestDatabaseWithFixtures(t, tc.fixtures...)
 			timeSource := test.FixedTime()
 
-			test_db.ExecFixtures(t, db, tc.fixtures...)
-
 			query := test_graphql.GraphqlQuery{
 				Query:     createAccountGQL,
 				Variables: tc.variables,
@@ -0,0 +1,207 @@
+package admin_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/api/graph/idempotency"
+	api_handler "myvendor.mytld/myproject/backend/api/handler"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/test"
+	test_auth "myvendor.mytld/myproject/backend/test/auth"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+	test_graphql "myvendor.mytld/myproject/backend/test/graphql"
+)
+
+const createOrganisationWithIdempotencyKeyGQL = `
+	mutation CreateOrganisation($name: String!, $idempotencyKey: String) {
+		result: createOrganisation(
+			name: $name,
+			idempotencyKey: $idempotencyKey,
+		) {
+			id
+		}
+	}
+`
+
+type createOrganisationIdempotencyResult struct {
+	Data struct {
+		Result *struct {
+			ID uuid.UUID
+		}
+	}
+	test_graphql.GraphqlErrors
+}
+
+func TestMutationResolver_CreateOrganisation_IdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name    string
+		headers []string
+		// variables of the first and second request
+		variables [2]map[string]any
+		expects   func(t *testing.T, db *sql.DB, first, second createOrganisationIdempotencyResult, replayed string)
+	}{
+		{
+			name:    "same request with header",
+			headers: []string{"create-next-big-thing", "create-next-big-thing"},
+			variables: [2]map[string]any{
+				{"name": "Next big thing"},
+				{"name": "Next big thing"},
+			},
+			expects: func(t *testing.T, db *sql.DB, first, second createOrganisationIdempotencyResult, replayed string) {
+				test_graphql.RequireNoErrors(t, first.GraphqlErrors)
+				test_graphql.RequireNoErrors(t, second.GraphqlErrors)
+
+				require.NotNil(t, first.Data.Result)
+				require.NotNil(t, second.Data.Result)
+				assert.Equal(t, first.Data.Result.ID, second.Data.Result.ID)
+				assert.Equal(t, "true", replayed)
+
+				assertOrganisationsNamed(t, db, "Next big thing", 1)
+			},
+		},
+		{
+			name: "same request with argument",
+			variables: [2]map[string]any{
+				{"name": "Next big thing", "idempotencyKey": "create-next-big-thing"},
+				{"name": "Next big thing", "idempotencyKey": "create-next-big-thing"},
+			},
+			expects: func(t *testing.T, db *sql.DB, first, second createOrganisationIdempotencyResult, replayed string) {
+				test_graphql.RequireNoErrors(t, first.GraphqlErrors)
+				test_graphql.RequireNoErrors(t, second.GraphqlErrors)
+
+				require.NotNil(t, first.Data.Result)
+				require.NotNil(t, second.Data.Result)
+				assert.Equal(t, first.Data.Result.ID, second.Data.Result.ID)
+				assert.Equal(t, "true", replayed)
+
+				assertOrganisationsNamed(t, db, "Next big thing", 1)
+			},
+		},
+		{
+			name:    "different input with same key",
+			headers: []string{"create-organisation", "create-organisation"},
+			variables: [2]map[string]any{
+				{"name": "Next big thing"},
+				{"name": "Another big thing"},
+			},
+			expects: func(t *testing.T, db *sql.DB, first, second createOrganisationIdempotencyResult, replayed string) {
+				test_graphql.RequireNoErrors(t, first.GraphqlErrors)
+				test_graphql.RequireErrors(t, second.GraphqlErrors, test_graphql.GraphqlError{
+					Extensions: test_graphql.GraphqlErrorExtensions{Type: "idempotencyKeyConflict"},
+				})
+				assert.Empty(t, replayed)
+
+				assertOrganisationsNamed(t, db, "Another big thing", 0)
+			},
+		},
+		{
+			name:    "different keys",
+			headers: []string{"create-organisation-1", "create-organisation-2"},
+			variables: [2]map[string]any{
+				{"name": "Next big thing"},
+				{"name": "Next big thing"},
+			},
+			expects: func(t *testing.T, db *sql.DB, first, second createOrganisationIdempotencyResult, replayed string) {
+				test_graphql.RequireNoErrors(t, first.GraphqlErrors)
+				test_graphql.RequireNoErrors(t, second.GraphqlErrors)
+				assert.Empty(t, replayed)
+
+				assertOrganisationsNamed(t, db, "Next big thing", 2)
+			},
+		},
+		{
+			name:    "different keys in header and argument",
+			headers: []string{"create-organisation-1", "create-organisation-1"},
+			variables: [2]map[string]any{
+				{"name": "Next big thing"},
+				{"name": "Next big thing", "idempotencyKey": "create-organisation-2"},
+			},
+			expects: func(t *testing.T, db *sql.DB, first, second createOrganisationIdempotencyResult, replayed string) {
+				test_graphql.RequireNoErrors(t, first.GraphqlErrors)
+				test_graphql.RequireErrors(t, second.GraphqlErrors, test_graphql.GraphqlError{
+					Extensions: test_graphql.GraphqlErrorExtensions{Type: "idempotencyKeyInvalid"},
+				})
+
+				assertOrganisationsNamed(t, db, "Next big thing", 1)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+			timeSource := test.FixedTime()
+
+			var results [2]createOrganisationIdempotencyResult
+			var replayed string
+			for i := range results {
+				req := test_graphql.NewRequest(t, test_graphql.GraphqlQuery{
+					Query:     createOrganisationWithIdempotencyKeyGQL,
+					Variables: tc.variables[i],
+				})
+				if len(tc.headers) > i {
+					req.Header.Set(idempotency.HeaderKey, tc.headers[i])
+				}
+				test_auth.ApplyFixedAuthValuesSystemAdministrator(t, timeSource, req)
+				rec := test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource}, req, &results[i])
+				replayed = rec.Header().Get(idempotency.HeaderReplayed)
+			}
+
+			tc.expects(t, db, results[0], results[1], replayed)
+		})
+	}
+}
+
+func TestMutationResolver_CreateOrganisation_IdempotencyKeyExpired(t *testing.T) {
+	t.Parallel()
+
+	db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+	timeSource := test.FixedTime()
+
+	createOrganisation := func(timeSource test.FixedTimeSource) createOrganisationIdempotencyResult {
+		req := test_graphql.NewRequest(t, test_graphql.GraphqlQuery{
+			Query:     createOrganisationWithIdempotencyKeyGQL,
+			Variables: map[string]any{"name": "Next big thing"},
+		})
+		req.Header.Set(idempotency.HeaderKey, "create-next-big-thing")
+		test_auth.ApplyFixedAuthValuesSystemAdministrator(t, timeSource, req)
+
+		var res createOrganisationIdempotencyResult
+		test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource}, req, &res)
+		test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+		require.NotNil(t, res.Data.Result)
+		return res
+	}
+
+	first := createOrganisation(timeSource)
+	// The key can be used again after the default retention
+	second := createOrganisation(timeSource.Add(api_handler.DefaultIdempotencyKeyRetention + time.Minute))
+
+	assert.NotEqual(t, first.Data.Result.ID, second.Data.Result.ID)
+	assertOrganisationsNamed(t, db, "Next big thing", 2)
+}
+
+func assertOrganisationsNamed(t *testing.T, db *sql.DB, name string, expected int) {
+	t.Helper()
+
+	organisations, err := repository.FindAllOrganisations(context.Background(), db, repository.OrganisationsFilter{})
+	require.NoError(t, err)
+
+	var count int
+	for _, organisation := range organisations {
+		if organisation.Name == name {
+			count++
+		}
+	}
+	assert.Equal(t, expected, count, "organisations named %q", name)
+}
@@ -28,6 +28,8 @@ const createOrganisationGQL = `
 `
 
 func TestMutationResolver_CreateOrganisation(t *testing.T) {
+	t.Parallel()
+
 	type result struct {
 		Data struct {
 			Result *struct {
@@ -76,11 +78,9 @@ func TestMutationResolver_CreateOrganisation(t *testing.T) {
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			db := test_db.CreateTestDatabase(t)
+			db := test_db.CreateTestDatabaseWithFixtures(t, tc.fixtures...)
 			timeSource := test.FixedTime()
 
-			test_db.ExecFixtures(t, db, tc.fixtures...)
-
 			query := test_graphql.GraphqlQuery{
 				Query:     createOrganisationGQL,
 				Variables: tc.variables,
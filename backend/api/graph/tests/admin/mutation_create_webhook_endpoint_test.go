@@ -0,0 +1,165 @@
+package admin_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/test"
+	test_auth "myvendor.mytld/myproject/backend/test/auth"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+	test_graphql "myvendor.mytld/myproject/backend/test/graphql"
+)
+
+const createWebhookEndpointGQL = `
+	mutation CreateWebhookEndpoint($organisationId: UUID!, $url: String!, $eventTypes: [WebhookEventType!]!) {
+		result: createWebhookEndpoint(
+			organisationId: $organisationId,
+			url: $url,
+			eventTypes: $eventTypes,
+		) {
+			id
+			organisationId
+			url
+			secret
+			eventTypes
+		}
+	}
+`
+
+func TestMutationResolver_CreateWebhookEndpoint(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Data struct {
+			Result *struct {
+				ID             uuid.UUID
+				OrganisationID uuid.UUID
+				URL            string
+				Secret         string
+				EventTypes     []string
+			}
+		}
+		test_graphql.GraphqlErrors
+	}
+
+	tt := []struct {
+		name          string
+		applyAuthFunc test_auth.ApplyAuthValuesFunc
+		fixtures      []string
+		variables     map[string]interface{}
+		expects       func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result)
+	}{
+		{
+			name:          "with SystemAdministrator",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base"},
+			variables: map[string]interface{}{
+				"organisationId": "dba20d09-a3df-4975-9406-2fb6fd8f0940",
+				"url":            "https://example.com/hooks",
+				"eventTypes":     []string{"AccountCreated", "AccountDeleted"},
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotNil(t, res.Data.Result)
+				assert.Equal(t, "https://example.com/hooks", res.Data.Result.URL)
+				assert.Equal(t, []string{"AccountCreated", "AccountDeleted"}, res.Data.Result.EventTypes)
+				assert.NotEmpty(t, res.Data.Result.Secret)
+
+				record, err := repository.FindWebhookEndpointByID(context.Background(), db, res.Data.Result.ID)
+				require.NoError(t, err)
+				assert.Equal(t, uuid.Must(uuid.FromString("dba20d09-a3df-4975-9406-2fb6fd8f0940")), record.OrganisationID)
+				assert.Equal(t, res.Data.Result.Secret, record.Secret)
+			},
+		},
+		{
+			name:          "with OrganisationAdministrator in same organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			fixtures:      []string{"base"},
+			variables: map[string]interface{}{
+				"organisationId": "6330de58-2761-411e-a243-bec6d0c53876",
+				"url":            "https://example.com/hooks",
+				"eventTypes":     []string{"OrganisationUpdated"},
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotNil(t, res.Data.Result)
+				assert.Equal(t, auth.OrganisationID.UUID, res.Data.Result.OrganisationID)
+			},
+		},
+		{
+			name:          "with OrganisationAdministrator in other organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			fixtures:      []string{"base"},
+			variables: map[string]interface{}{
+				"organisationId": "dba20d09-a3df-4975-9406-2fb6fd8f0940",
+				"url":            "https://example.com/hooks",
+				"eventTypes":     []string{"OrganisationUpdated"},
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNotAuthorizedError(t, res.GraphqlErrors)
+			},
+		},
+		{
+			name:          "with invalid url",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base"},
+			variables: map[string]interface{}{
+				"organisationId": "6330de58-2761-411e-a243-bec6d0c53876",
+				"url":            "example.com/hooks",
+				"eventTypes":     []string{"OrganisationUpdated"},
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireErrors(t, res.GraphqlErrors)
+
+				require.Len(t, res.GraphqlErrors.Errors, 1)
+				assert.Equal(t, "url", res.GraphqlErrors.Errors[0].Extensions.Field)
+				assert.Equal(t, "invalid", res.GraphqlErrors.Errors[0].Extensions.Code)
+			},
+		},
+		{
+			name:          "with unknown organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base"},
+			variables: map[string]interface{}{
+				"organisationId": "2c1a4b8e-1f64-4a5b-9a5f-0c0b7e9f2d11",
+				"url":            "https://example.com/hooks",
+				"eventTypes":     []string{"OrganisationUpdated"},
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireErrors(t, res.GraphqlErrors)
+
+				require.Len(t, res.GraphqlErrors.Errors, 1)
+				assert.Equal(t, "organisationId", res.GraphqlErrors.Errors[0].Extensions.Field)
+				assert.Equal(t, "notExists", res.GraphqlErrors.Errors[0].Extensions.Code)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := test_db.CreateTestDatabaseWithFixtures(t, tc.fixtures...)
+			timeSource := test.FixedTime()
+
+			query := test_graphql.GraphqlQuery{
+				Query:     createWebhookEndpointGQL,
+				Variables: tc.variables,
+			}
+
+			var res result
+
+			req := test_graphql.NewRequest(t, query)
+			auth := tc.applyAuthFunc(t, timeSource, req)
+			test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource}, req, &res)
+			tc.expects(t, db, auth, res)
+		})
+	}
+}
@@ -27,6 +27,8 @@ const deleteAccountGQL = `
 `
 
 func TestMutationResolver_DeleteAccount(t *testing.T) {
+	t.Parallel()
+
 	type result struct {
 		Data struct {
 			Result *struct {
@@ -108,11 +110,9 @@ func TestMutationResolver_DeleteAccount(t *testing.T) {
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			db := test_db.CreateTestDatabase(t)
+			db := test_db.CreateTestDatabaseWithFixtures(t, tc.fixtures...)
 			timeSource := test.FixedTime()
 
-			test_db.ExecFixtures(t, db, tc.fixtures...)
-
 			query := test_graphql.GraphqlQuery{
 				Query:     deleteAccountGQL,
 				Variables: tc.variables,
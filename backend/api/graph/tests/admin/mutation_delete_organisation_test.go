@@ -27,6 +27,8 @@ const deleteOrganisationGQL = `
 `
 
 func TestMutationResolver_DeleteOrganisation(t *testing.T) {
+	t.Parallel()
+
 	type result struct {
 		Data struct {
 			Result *struct {
@@ -73,11 +75,9 @@ func TestMutationResolver_DeleteOrganisation(t *testing.T) {
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			db := test_db.CreateTestDatabase(t)
+			db := test_db.CreateTestDatabaseWithFixtures(t, tc.fixtures...)
 			timeSource := test.FixedTime()
 
-			test_db.ExecFixtures(t, db, tc.fixtures...)
-
 			query := test_graphql.GraphqlQuery{
 				Query:     deleteOrganisationGQL,
 				Variables: tc.variables,
@@ -0,0 +1,103 @@
+package admin_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/test"
+	test_auth "myvendor.mytld/myproject/backend/test/auth"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+	test_graphql "myvendor.mytld/myproject/backend/test/graphql"
+	"myvendor.mytld/myproject/backend/webhook"
+)
+
+const redeliverWebhookGQL = `
+	mutation RedeliverWebhook($deliveryId: UUID!) {
+		result: redeliverWebhook(deliveryId: $deliveryId) {
+			id
+			status
+			attempts
+		}
+	}
+`
+
+func TestMutationResolver_RedeliverWebhook(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Data struct {
+			Result *struct {
+				ID       uuid.UUID
+				Status   string
+				Attempts int
+			}
+		}
+		test_graphql.GraphqlErrors
+	}
+
+	tt := []struct {
+		name          string
+		applyAuthFunc test_auth.ApplyAuthValuesFunc
+		expects       func(t *testing.T, db *sql.DB, res result)
+	}{
+		{
+			name:          "with OrganisationAdministrator in same organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			expects: func(t *testing.T, db *sql.DB, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotNil(t, res.Data.Result)
+				assert.Equal(t, "pending", res.Data.Result.Status)
+				assert.Equal(t, 0, res.Data.Result.Attempts)
+
+				jobType := webhook.DeliverJob{}.JobType()
+				jobs, err := repository.FindAllJobs(context.Background(), db, repository.JobsFilter{JobType: &jobType})
+				require.NoError(t, err)
+				require.Len(t, jobs, 1)
+				assert.Equal(t, model.JobStatusPending, jobs[0].Status)
+				assert.JSONEq(t, `{"webhookDeliveryId":"`+fixtureWebhookDeliveryIDs[1].String()+`"}`, string(jobs[0].Payload))
+			},
+		},
+		{
+			name:          "with SystemAdministrator",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			expects: func(t *testing.T, db *sql.DB, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotNil(t, res.Data.Result)
+				assert.Equal(t, "pending", res.Data.Result.Status)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+			timeSource := test.FixedTime()
+
+			insertWebhookFixtures(t, db)
+
+			query := test_graphql.GraphqlQuery{
+				Query: redeliverWebhookGQL,
+				Variables: map[string]interface{}{
+					"deliveryId": fixtureWebhookDeliveryIDs[1],
+				},
+			}
+
+			var res result
+
+			req := test_graphql.NewRequest(t, query)
+			tc.applyAuthFunc(t, timeSource, req)
+			test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource}, req, &res)
+			tc.expects(t, db, res)
+		})
+	}
+}
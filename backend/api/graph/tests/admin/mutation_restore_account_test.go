@@ -0,0 +1,124 @@
+package admin_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/test"
+	test_auth "myvendor.mytld/myproject/backend/test/auth"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+	test_graphql "myvendor.mytld/myproject/backend/test/graphql"
+)
+
+const restoreAccountGQL = `
+	mutation RestoreAccount($id: UUID!) {
+		result: restoreAccount(
+			id: $id,
+		) {
+			id
+		}
+	}
+`
+
+func TestMutationResolver_RestoreAccount(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Data struct {
+			Result *struct {
+				ID uuid.UUID
+			}
+		}
+		test_graphql.GraphqlErrors
+	}
+
+	tt := []struct {
+		name          string
+		applyAuthFunc test_auth.ApplyAuthValuesFunc
+		fixtures      []string
+		variables     map[string]interface{}
+		expects       func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result)
+	}{
+		{
+			name:          "with SystemAdministrator",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base", "deleted"},
+			variables: map[string]interface{}{
+				"id": "f045e5d1-cdad-4964-a7e2-139c8a87346c",
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				_, err := repository.FindAccountByID(context.Background(), db, uuid.Must(uuid.FromString("f045e5d1-cdad-4964-a7e2-139c8a87346c")), nil)
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:          "with SystemAdministrator and deleted organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base", "deleted"},
+			variables: map[string]interface{}{
+				"id": "8b0c5a8e-7f3d-4b1a-9c62-0e4f5d6a7b81",
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireErrors(t, res.GraphqlErrors, test_graphql.GraphqlError{
+					Extensions: test_graphql.GraphqlErrorExtensions{
+						Type:  "validationFailed",
+						Field: "organisationId",
+						Code:  "notExists",
+					},
+				})
+			},
+		},
+		{
+			name:          "with OrganisationAdministrator and account of organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			fixtures:      []string{"base", "deleted"},
+			variables: map[string]interface{}{
+				"id": "f045e5d1-cdad-4964-a7e2-139c8a87346c",
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				_, err := repository.FindAccountByID(context.Background(), db, uuid.Must(uuid.FromString("f045e5d1-cdad-4964-a7e2-139c8a87346c")), nil)
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:          "with OrganisationAdministrator and account in other organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			fixtures:      []string{"base", "deleted"},
+			variables: map[string]interface{}{
+				"id": "2035f4da-f385-42c4-a609-02d9aa7290e5",
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNotAuthorizedError(t, res.GraphqlErrors)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := test_db.CreateTestDatabaseWithFixtures(t, tc.fixtures...)
+			timeSource := test.FixedTime()
+
+			query := test_graphql.GraphqlQuery{
+				Query:     restoreAccountGQL,
+				Variables: tc.variables,
+			}
+
+			var res result
+
+			req := test_graphql.NewRequest(t, query)
+			auth := tc.applyAuthFunc(t, timeSource, req)
+			test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource}, req, &res)
+			tc.expects(t, db, auth, res)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package admin_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/test"
+	test_auth "myvendor.mytld/myproject/backend/test/auth"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+	test_graphql "myvendor.mytld/myproject/backend/test/graphql"
+)
+
+const restoreOrganisationGQL = `
+	mutation RestoreOrganisation($id: UUID!) {
+		result: restoreOrganisation(
+			id: $id,
+		) {
+			id
+			deletedAt
+		}
+	}
+`
+
+func TestMutationResolver_RestoreOrganisation(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Data struct {
+			Result *struct {
+				ID        uuid.UUID
+				DeletedAt *string
+			}
+		}
+		test_graphql.GraphqlErrors
+	}
+
+	tt := []struct {
+		name          string
+		applyAuthFunc test_auth.ApplyAuthValuesFunc
+		fixtures      []string
+		variables     map[string]interface{}
+		expects       func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result)
+	}{
+		{
+			name:          "with SystemAdministrator",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base", "deleted"},
+			variables: map[string]interface{}{
+				"id": "dba20d09-a3df-4975-9406-2fb6fd8f0940",
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotNil(t, res.Data.Result)
+				assert.Nil(t, res.Data.Result.DeletedAt)
+
+				_, err := repository.FindOrganisationByID(context.Background(), db, res.Data.Result.ID, nil)
+				require.NoError(t, err)
+
+				// Account deleted together with the organisation is restored
+				_, err = repository.FindAccountByID(context.Background(), db, uuid.Must(uuid.FromString("2035f4da-f385-42c4-a609-02d9aa7290e5")), nil)
+				require.NoError(t, err)
+
+				// Account deleted before stays deleted
+				_, err = repository.FindAccountByID(context.Background(), db, uuid.Must(uuid.FromString("8b0c5a8e-7f3d-4b1a-9c62-0e4f5d6a7b81")), nil)
+				require.ErrorIs(t, err, repository.ErrNotFound)
+			},
+		},
+		{
+			name:          "with SystemAdministrator and not deleted organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base", "deleted"},
+			variables: map[string]interface{}{
+				"id": "6330de58-2761-411e-a243-bec6d0c53876",
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireErrors(t, res.GraphqlErrors, test_graphql.GraphqlError{
+					Extensions: test_graphql.GraphqlErrorExtensions{
+						Type:  "validationFailed",
+						Field: "organisationId",
+						Code:  "notExists",
+					},
+				})
+			},
+		},
+		{
+			name:          "with OrganisationAdministrator",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			fixtures:      []string{"base", "deleted"},
+			variables: map[string]interface{}{
+				"id": "dba20d09-a3df-4975-9406-2fb6fd8f0940",
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNotAuthorizedError(t, res.GraphqlErrors)
+
+				_, err := repository.FindOrganisationByID(context.Background(), db, uuid.Must(uuid.FromString("dba20d09-a3df-4975-9406-2fb6fd8f0940")), nil)
+				require.ErrorIs(t, err, repository.ErrNotFound)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := test_db.CreateTestDatabaseWithFixtures(t, tc.fixtures...)
+			timeSource := test.FixedTime()
+
+			query := test_graphql.GraphqlQuery{
+				Query:     restoreOrganisationGQL,
+				Variables: tc.variables,
+			}
+
+			var res result
+
+			req := test_graphql.NewRequest(t, query)
+			auth := tc.applyAuthFunc(t, timeSource, req)
+			test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource}, req, &res)
+			tc.expects(t, db, auth, res)
+		})
+	}
+}
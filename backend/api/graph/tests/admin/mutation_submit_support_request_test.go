@@ -0,0 +1,292 @@
+package admin_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomail "github.com/wneessen/go-mail"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/domain"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/mail"
+	"myvendor.mytld/myproject/backend/mail/fixture"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/test"
+	test_auth "myvendor.mytld/myproject/backend/test/auth"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+	test_graphql "myvendor.mytld/myproject/backend/test/graphql"
+	test_mail "myvendor.mytld/myproject/backend/test/mail"
+)
+
+const submitSupportRequestGQL = `
+	mutation SubmitSupportRequest($subject: String!, $message: String!, $attachment: Upload) {
+		result: submitSupportRequest(
+			subject: $subject,
+			message: $message,
+			attachment: $attachment,
+		) {
+			error {
+				path
+				code
+				arguments
+			}
+		}
+	}
+`
+
+func TestMutationResolver_SubmitSupportRequest(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Data struct {
+			Result *struct {
+				Error *struct {
+					Path      []string
+					Code      string
+					Arguments []string
+				}
+			}
+		}
+		test_graphql.GraphqlErrors
+	}
+
+	// Minimal PNG signature and IHDR chunk header, enough for content type detection
+	pngContent := "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+
+	tt := []struct {
+		name          string
+		applyAuthFunc test_auth.ApplyAuthValuesFunc
+		fixtures      []string
+		// previousRequests of the authenticated account that were submitted before
+		previousRequests int
+		subject          string
+		message          string
+		attachmentName   string
+		attachment       string
+		expects          func(t *testing.T, db *sql.DB, sender *fixture.Sender, res result)
+	}{
+		{
+			name:          "with OrganisationAdministrator without attachment",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			fixtures:      []string{"base"},
+			subject:       "Question",
+			message:       "How do I invite a colleague?",
+			expects: func(t *testing.T, db *sql.DB, sender *fixture.Sender, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotNil(t, res.Data.Result)
+				require.Nil(t, res.Data.Result.Error)
+
+				require.NotEmpty(t, sender.LastMail)
+				msg := test_mail.RequireParseMailMessage(t, sender.LastMail)
+				test_mail.AssertMailMessageHeaderEquals(t, msg, "From", "<admin+acmeinc@example.com>")
+				test_mail.AssertMailMessageHeaderEquals(t, msg, "To", "<app@example.com>")
+				test_mail.AssertMailMessageHeaderEquals(t, msg, "Subject", "Neue Kontaktanfrage von admin+acmeinc@example.com (Acme Inc.)")
+				test_mail.AssertMailMessageBodyContains(t, msg, "Betreff: Question")
+				test_mail.AssertMailMessageBodyContains(t, msg, "Nachricht: How do I invite a colleague?")
+
+				count, err := repository.CountSupportRequestsSince(context.Background(), db, uuid.Must(uuid.FromString("3ad082c7-cbda-49e1-a707-c53e1962be65")), test.FixedTime().Now())
+				require.NoError(t, err)
+				assert.Equal(t, 1, count)
+			},
+		},
+		{
+			name:           "with OrganisationAdministrator and attachment",
+			applyAuthFunc:  test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			fixtures:       []string{"base"},
+			subject:        "Bug report",
+			message:        "The dashboard looks broken, see screenshot.",
+			attachmentName: "screenshot.png",
+			attachment:     pngContent,
+			expects: func(t *testing.T, db *sql.DB, sender *fixture.Sender, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotNil(t, res.Data.Result)
+				require.Nil(t, res.Data.Result.Error)
+
+				require.NotEmpty(t, sender.LastMail)
+				msg := test_mail.RequireParseMailMessage(t, sender.LastMail)
+				test_mail.AssertMailMessageHeaderEquals(t, msg, "Subject", "Neue Kontaktanfrage von admin+acmeinc@example.com (Acme Inc.)")
+				test_mail.AssertMailMessageHasFileAttachment(t, msg, "screenshot.png")
+			},
+		},
+		{
+			name:          "with SystemAdministrator without organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base"},
+			subject:       "Question",
+			message:       "Where are the logs?",
+			expects: func(t *testing.T, db *sql.DB, sender *fixture.Sender, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotNil(t, res.Data.Result)
+				require.Nil(t, res.Data.Result.Error)
+
+				msg := test_mail.RequireParseMailMessage(t, sender.LastMail)
+				test_mail.AssertMailMessageHeaderEquals(t, msg, "From", "<admin@example.com>")
+			},
+		},
+		{
+			name:           "with disallowed attachment content type",
+			applyAuthFunc:  test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			fixtures:       []string{"base"},
+			subject:        "Bug report",
+			message:        "See attached page.",
+			attachmentName: "page.html",
+			attachment:     "<!DOCTYPE html><html><body>Hello</body></html>",
+			expects: func(t *testing.T, db *sql.DB, sender *fixture.Sender, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotNil(t, res.Data.Result)
+				require.NotNil(t, res.Data.Result.Error)
+				assert.Equal(t, []string{"attachment"}, res.Data.Result.Error.Path)
+				assert.Equal(t, types.ErrorCodeContentTypeNotAllowed, res.Data.Result.Error.Code)
+
+				assert.Empty(t, sender.LastMail)
+			},
+		},
+		{
+			name:          "with blank message",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			fixtures:      []string{"base"},
+			subject:       "Question",
+			message:       " ",
+			expects: func(t *testing.T, db *sql.DB, sender *fixture.Sender, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotNil(t, res.Data.Result.Error)
+				assert.Equal(t, []string{"message"}, res.Data.Result.Error.Path)
+				assert.Equal(t, types.ErrorCodeRequired, res.Data.Result.Error.Code)
+
+				assert.Empty(t, sender.LastMail)
+			},
+		},
+		{
+			name:             "with exceeded rate limit",
+			applyAuthFunc:    test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			fixtures:         []string{"base"},
+			previousRequests: domain.DefaultConfig().SupportRequestLimit,
+			subject:          "Question",
+			message:          "Are you there?",
+			expects: func(t *testing.T, db *sql.DB, sender *fixture.Sender, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotNil(t, res.Data.Result.Error)
+				assert.Empty(t, res.Data.Result.Error.Path)
+				assert.Equal(t, types.ErrorCodeRateLimitExceeded, res.Data.Result.Error.Code)
+
+				assert.Empty(t, sender.LastMail)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := test_db.CreateTestDatabaseWithFixtures(t, tc.fixtures...)
+			timeSource := test.FixedTime()
+
+			sender := fixture.NewSender()
+			mailer := mail.NewMailer(sender, mail.DefaultConfig(domain.DefaultConfig()))
+
+			query := test_graphql.GraphqlQuery{
+				Query: submitSupportRequestGQL,
+				Variables: map[string]interface{}{
+					"subject":    tc.subject,
+					"message":    tc.message,
+					"attachment": nil,
+				},
+			}
+
+			var req *http.Request
+			if tc.attachmentName != "" {
+				req = test_graphql.NewMultipartRequest(t, bytes.Buffer{}, query, map[string]test_graphql.MultipartFileInfo{
+					"0": {
+						Name:      tc.attachmentName,
+						Variables: []string{"variables.attachment"},
+						Reader:    strings.NewReader(tc.attachment),
+					},
+				})
+			} else {
+				req = test_graphql.NewRequest(t, query)
+			}
+			authData := tc.applyAuthFunc(t, timeSource, req)
+
+			for i := 0; i < tc.previousRequests; i++ {
+				id := uuid.Must(uuid.NewV7())
+				createdAt := timeSource.Now()
+				subject := "Previous request"
+				err := repository.InsertSupportRequest(context.Background(), db, repository.SupportRequestChangeSet{
+					ID:        &id,
+					AccountID: &authData.AccountID,
+					Subject:   &subject,
+					CreatedAt: &createdAt,
+				})
+				require.NoError(t, err)
+			}
+
+			var res result
+			test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource, Mailer: mailer}, req, &res)
+			tc.expects(t, db, sender, res)
+		})
+	}
+}
+
+// serializationFailureSender counts sent mails and fails like a transaction that has to be retried
+type serializationFailureSender struct {
+	calls int
+}
+
+func (s *serializationFailureSender) Send(_ context.Context, _ *gomail.Msg) error {
+	s.calls++
+	return &pgconn.PgError{Code: "40001"}
+}
+
+func TestMutationResolver_SubmitSupportRequest_NoRetry(t *testing.T) {
+	t.Parallel()
+
+	db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+	timeSource := test.FixedTime()
+
+	sender := &serializationFailureSender{}
+	mailer := mail.NewMailer(sender, mail.DefaultConfig(domain.DefaultConfig()))
+
+	query := test_graphql.GraphqlQuery{
+		Query: submitSupportRequestGQL,
+		Variables: map[string]interface{}{
+			"subject":    "Question",
+			"message":    "How do I invite a colleague?",
+			"attachment": nil,
+		},
+	}
+	req := test_graphql.NewMultipartRequest(t, bytes.Buffer{}, query, map[string]test_graphql.MultipartFileInfo{
+		"0": {
+			Name:      "screenshot.png",
+			Variables: []string{"variables.attachment"},
+			Reader:    strings.NewReader("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"),
+		},
+	})
+	authData := test_auth.ApplyFixedAuthValuesOrganisationAdministrator(t, timeSource, req)
+
+	var res struct {
+		test_graphql.GraphqlErrors
+	}
+	test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource, Mailer: mailer}, req, &res)
+
+	// The transaction is not retried, so the mail is not sent again
+	require.NotEmpty(t, res.Errors)
+	assert.Equal(t, 1, sender.calls)
+
+	count, err := repository.CountSupportRequestsSince(context.Background(), db, authData.AccountID, timeSource.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
@@ -18,13 +18,14 @@ import (
 )
 
 const updateAccountGQL = `
-	mutation UpdateAccount($id: UUID!, $role: Role!, $emailAddress: String!, $password: String, $organisationId: UUID) {
+	mutation UpdateAccount($id: UUID!, $role: Role!, $emailAddress: String!, $password: String, $organisationId: UUID, $expectedVersion: Int) {
 		result: updateAccount(
 			id: $id,
 			role: $role,
 			emailAddress: $emailAddress,
 			password: $password,
 			organisationId: $organisationId,
+			expectedVersion: $expectedVersion,
 		) {
 			id
 		}
@@ -32,6 +33,8 @@ const updateAccountGQL = `
 `
 
 func TestMutationResolver_UpdateAccount(t *testing.T) {
+	t.Parallel()
+
 	type result struct {
 		Data struct {
 			Result *struct {
@@ -67,6 +70,58 @@ func TestMutationResolver_UpdateAccount(t *testing.T) {
 				assert.Equal(t, "test@acme.com", account.EmailAddress)
 			},
 		},
+		{
+			name:          "with SystemAdministrator and outdated version",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base"},
+			variables: map[string]interface{}{
+				"id":              "d7037ad0-d4bb-4dcc-8759-d82fbb3354e8",
+				"role":            "SystemAdministrator",
+				"emailAddress":    "test@acme.com",
+				"organisationId":  nil,
+				"expectedVersion": 2,
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireErrors(t, res.GraphqlErrors, test_graphql.GraphqlError{
+					Extensions: test_graphql.GraphqlErrorExtensions{
+						Type:  "validationFailed",
+						Field: "expectedVersion",
+						Code:  "conflict",
+					},
+				})
+
+				account, err := repository.FindAccountByID(context.Background(), db, uuid.Must(uuid.FromString("d7037ad0-d4bb-4dcc-8759-d82fbb3354e8")), nil)
+				require.NoError(t, err)
+
+				assert.NotEqual(t, "test@acme.com", account.EmailAddress)
+				assert.Equal(t, 1, account.Version)
+			},
+		},
+		{
+			name:          "with SystemAdministrator and deleted organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base", "deleted"},
+			variables: map[string]interface{}{
+				"id":             "3ad082c7-cbda-49e1-a707-c53e1962be65",
+				"role":           "OrganisationAdministrator",
+				"emailAddress":   "test@acme.com",
+				"organisationId": "dba20d09-a3df-4975-9406-2fb6fd8f0940",
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireErrors(t, res.GraphqlErrors, test_graphql.GraphqlError{
+					Extensions: test_graphql.GraphqlErrorExtensions{
+						Type:  "validationFailed",
+						Field: "organisationId",
+						Code:  "notExists",
+					},
+				})
+
+				account, err := repository.FindAccountByID(context.Background(), db, uuid.Must(uuid.FromString("3ad082c7-cbda-49e1-a707-c53e1962be65")), nil)
+				require.NoError(t, err)
+
+				assert.Equal(t, "6330de58-2761-411e-a243-bec6d0c53876", account.OrganisationID.UUID.String())
+			},
+		},
 		{
 			name:          "with OrganisationAdministrator and valid values",
 			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
@@ -135,11 +190,9 @@ func TestMutationResolver_UpdateAccount(t *testing.T) {
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			db := test_db.CreateTestDatabase(t)
+			db := test_db.CreateTestDatabaseWithFixtures(t, tc.fixtures...)
 			timeSource := test.FixedTime()
 
-			test_db.ExecFixtures(t, db, tc.fixtures...)
-
 			query := test_graphql.GraphqlQuery{
 				Query:     updateAccountGQL,
 				Variables: tc.variables,
@@ -154,3 +207,47 @@ func TestMutationResolver_UpdateAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestMutationResolver_UpdateAccount_AfterLogin(t *testing.T) {
+	t.Parallel()
+
+	db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+	timeSource := test.FixedTime()
+	ctx := context.Background()
+	accountID := uuid.Must(uuid.FromString("d7037ad0-d4bb-4dcc-8759-d82fbb3354e8"))
+
+	// A login updates the last login, which must not cause a version conflict for an edit started before
+	now := timeSource.Now()
+	lastLogin := &now
+	err := repository.UpdateAccount(ctx, db, accountID, repository.AccountChangeSet{LastLogin: &lastLogin}, nil)
+	require.NoError(t, err)
+
+	account, err := repository.FindAccountByID(ctx, db, accountID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, account.Version)
+
+	query := test_graphql.GraphqlQuery{
+		Query: updateAccountGQL,
+		Variables: map[string]interface{}{
+			"id":              accountID.String(),
+			"role":            "SystemAdministrator",
+			"emailAddress":    "test@acme.com",
+			"organisationId":  nil,
+			"expectedVersion": 1,
+		},
+	}
+
+	var res struct {
+		test_graphql.GraphqlErrors
+	}
+
+	req := test_graphql.NewRequest(t, query)
+	test_auth.ApplyFixedAuthValuesSystemAdministrator(t, timeSource, req)
+	test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource}, req, &res)
+	test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+	account, err = repository.FindAccountByID(ctx, db, accountID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "test@acme.com", account.EmailAddress)
+	assert.Equal(t, 2, account.Version)
+}
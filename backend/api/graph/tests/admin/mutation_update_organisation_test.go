@@ -18,21 +18,26 @@ import (
 )
 
 const updateOrganisationGQL = `
-	mutation UpdateOrganisation($id: UUID!, $name: String!) {
+	mutation UpdateOrganisation($id: UUID!, $name: String!, $expectedVersion: Int) {
 		result: updateOrganisation(
 			id: $id,
 			name: $name,
+			expectedVersion: $expectedVersion,
 		) {
 			id
+			version
 		}
 	}
 `
 
 func TestMutationResolver_UpdateOrganisation(t *testing.T) {
+	t.Parallel()
+
 	type result struct {
 		Data struct {
 			Result *struct {
-				ID uuid.UUID
+				ID      uuid.UUID
+				Version int
 			}
 		}
 		test_graphql.GraphqlErrors
@@ -61,6 +66,47 @@ func TestMutationResolver_UpdateOrganisation(t *testing.T) {
 				require.NoError(t, err)
 
 				assert.Equal(t, "Acme Ltd.", organisation.Name)
+				assert.Equal(t, 2, res.Data.Result.Version)
+			},
+		},
+		{
+			name:          "with SystemAdministrator and current version",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base"},
+			variables: map[string]interface{}{
+				"id":              "6330de58-2761-411e-a243-bec6d0c53876",
+				"name":            "Acme Ltd.",
+				"expectedVersion": 1,
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotNil(t, res.Data.Result)
+				assert.Equal(t, 2, res.Data.Result.Version)
+			},
+		},
+		{
+			name:          "with SystemAdministrator and outdated version",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base"},
+			variables: map[string]interface{}{
+				"id":              "6330de58-2761-411e-a243-bec6d0c53876",
+				"name":            "Acme Ltd.",
+				"expectedVersion": 2,
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireErrors(t, res.GraphqlErrors, test_graphql.GraphqlError{
+					Extensions: test_graphql.GraphqlErrorExtensions{
+						Type:  "validationFailed",
+						Field: "expectedVersion",
+						Code:  "conflict",
+					},
+				})
+
+				organisation, err := repository.FindOrganisationByID(context.Background(), db, uuid.Must(uuid.FromString("6330de58-2761-411e-a243-bec6d0c53876")), nil)
+				require.NoError(t, err)
+				assert.NotEqual(t, "Acme Ltd.", organisation.Name)
+				assert.Equal(t, 1, organisation.Version)
 			},
 		},
 		{
@@ -79,11 +125,9 @@ func TestMutationResolver_UpdateOrganisation(t *testing.T) {
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			db := test_db.CreateTestDatabase(t)
+			db := test_db.CreateTestDatabaseWithFixtures(t, tc.fixtures...)
 			timeSource := test.FixedTime()
 
-			test_db.ExecFixtures(t, db, tc.fixtures...)
-
 			query := test_graphql.GraphqlQuery{
 				Query:     updateOrganisationGQL,
 				Variables: tc.variables,
@@ -0,0 +1,221 @@
+package admin_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/storage"
+	"myvendor.mytld/myproject/backend/storage/local"
+	"myvendor.mytld/myproject/backend/test"
+	test_auth "myvendor.mytld/myproject/backend/test/auth"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+	test_graphql "myvendor.mytld/myproject/backend/test/graphql"
+)
+
+const uploadAssetGQL = `
+	mutation UploadAsset($file: Upload!, $organisationId: UUID) {
+		result: uploadAsset(
+			file: $file,
+			organisationId: $organisationId,
+		) {
+			id
+			organisationId
+			filename
+			contentType
+			size
+			checksum
+			downloadUrl
+			width
+			height
+			variantUrl(variant: Thumbnail)
+		}
+	}
+`
+
+func TestMutationResolver_UploadAsset(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Data struct {
+			Result *struct {
+				ID             uuid.UUID
+				OrganisationID *uuid.UUID
+				Filename       string
+				ContentType    string
+				Size           int64
+				Checksum       string
+				DownloadURL    string
+				Width          *int
+				Height         *int
+				VariantURL     *string
+			}
+		}
+		test_graphql.GraphqlErrors
+	}
+
+	tt := []struct {
+		name          string
+		applyAuthFunc test_auth.ApplyAuthValuesFunc
+		fixtures      []string
+		variables     map[string]interface{}
+		filename      string
+		content       string
+		expects       func(t *testing.T, db *sql.DB, fileStorage storage.Storage, res result)
+	}{
+		{
+			name:          "with OrganisationAdministrator and own organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			fixtures:      []string{"base"},
+			variables: map[string]interface{}{
+				"organisationId": "6330de58-2761-411e-a243-bec6d0c53876",
+			},
+			filename: "hello.txt",
+			content:  "Hello",
+			expects: func(t *testing.T, db *sql.DB, fileStorage storage.Storage, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotNil(t, res.Data.Result)
+				assert.Equal(t, "hello.txt", res.Data.Result.Filename)
+				assert.Equal(t, "text/plain", res.Data.Result.ContentType)
+				assert.Equal(t, int64(5), res.Data.Result.Size)
+				assert.Equal(t, "185f8db32271fe25f561a6fc938b2e264306ec304eda518007d1764826381969", res.Data.Result.Checksum)
+				assert.True(t, strings.HasPrefix(res.Data.Result.DownloadURL, api.AssetDownloadPath(res.Data.Result.ID)+"?"))
+				assert.Nil(t, res.Data.Result.Width)
+				assert.Nil(t, res.Data.Result.VariantURL)
+
+				record, err := repository.FindAssetByID(context.Background(), db, res.Data.Result.ID)
+				require.NoError(t, err)
+				assert.Equal(t, "6330de58-2761-411e-a243-bec6d0c53876", record.OrganisationID.UUID.String())
+
+				r, err := fileStorage.Get(context.Background(), record.StorageKey)
+				require.NoError(t, err)
+				defer r.Close()
+				content, err := io.ReadAll(r)
+				require.NoError(t, err)
+				assert.Equal(t, "Hello", string(content))
+			},
+		},
+		{
+			name:          "with OrganisationAdministrator and image",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			fixtures:      []string{"base"},
+			variables: map[string]interface{}{
+				"organisationId": "6330de58-2761-411e-a243-bec6d0c53876",
+			},
+			filename: "logo.png",
+			content:  testPNG(t, 64, 32),
+			expects: func(t *testing.T, db *sql.DB, fileStorage storage.Storage, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotNil(t, res.Data.Result)
+				assert.Equal(t, "image/png", res.Data.Result.ContentType)
+				require.NotNil(t, res.Data.Result.Width)
+				require.NotNil(t, res.Data.Result.Height)
+				assert.Equal(t, 64, *res.Data.Result.Width)
+				assert.Equal(t, 32, *res.Data.Result.Height)
+				require.NotNil(t, res.Data.Result.VariantURL)
+				assert.True(t, strings.HasPrefix(*res.Data.Result.VariantURL, api.AssetVariantPath(res.Data.Result.ID, "thumbnail")+"?"))
+			},
+		},
+		{
+			name:          "with SystemAdministrator and image exceeding dimensions",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base"},
+			variables:     map[string]interface{}{},
+			filename:      "wide.png",
+			content:       testPNG(t, 8193, 1),
+			expects: func(t *testing.T, db *sql.DB, fileStorage storage.Storage, res result) {
+				test_graphql.RequireErrors(t, res.GraphqlErrors, test_graphql.GraphqlError{
+					Extensions: test_graphql.GraphqlErrorExtensions{
+						Type:  "validationFailed",
+						Field: "file",
+						Code:  "imageWidthMustBeAtMost",
+					},
+				})
+			},
+		},
+		{
+			name:          "with OrganisationAdministrator and other organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			fixtures:      []string{"base"},
+			variables: map[string]interface{}{
+				"organisationId": "dba20d09-a3df-4975-9406-2fb6fd8f0940",
+			},
+			filename: "hello.txt",
+			content:  "Hello",
+			expects: func(t *testing.T, db *sql.DB, fileStorage storage.Storage, res result) {
+				test_graphql.RequireNotAuthorizedError(t, res.GraphqlErrors)
+			},
+		},
+		{
+			name:          "with SystemAdministrator and disallowed content type",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base"},
+			variables:     map[string]interface{}{},
+			filename:      "page.html",
+			content:       "<!DOCTYPE html><html><body>Hello</body></html>",
+			expects: func(t *testing.T, db *sql.DB, fileStorage storage.Storage, res result) {
+				test_graphql.RequireErrors(t, res.GraphqlErrors, test_graphql.GraphqlError{
+					Extensions: test_graphql.GraphqlErrorExtensions{
+						Type:  "validationFailed",
+						Field: "file",
+						Code:  "contentTypeNotAllowed",
+					},
+				})
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := test_db.CreateTestDatabaseWithFixtures(t, tc.fixtures...)
+			timeSource := test.FixedTime()
+
+			fileStorage, err := local.NewStorage(t.TempDir())
+			require.NoError(t, err)
+
+			variables := map[string]interface{}{"file": nil}
+			for k, v := range tc.variables {
+				variables[k] = v
+			}
+			query := test_graphql.GraphqlQuery{
+				Query:     uploadAssetGQL,
+				Variables: variables,
+			}
+
+			var res result
+
+			req := test_graphql.NewMultipartRequest(t, bytes.Buffer{}, query, map[string]test_graphql.MultipartFileInfo{
+				"0": {
+					Name:      tc.filename,
+					Variables: []string{"variables.file"},
+					Reader:    strings.NewReader(tc.content),
+				},
+			})
+			tc.applyAuthFunc(t, timeSource, req)
+			test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource, Storage: fileStorage}, req, &res)
+			tc.expects(t, db, fileStorage, res)
+		})
+	}
+}
+
+func testPNG(t *testing.T, width, height int) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	require.NoError(t, err)
+	return buf.String()
+}
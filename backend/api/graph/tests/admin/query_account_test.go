@@ -12,6 +12,7 @@ import (
 	"myvendor.mytld/myproject/backend/test"
 	test_auth "myvendor.mytld/myproject/backend/test/auth"
 	test_db "myvendor.mytld/myproject/backend/test/db"
+	"myvendor.mytld/myproject/backend/test/fixtures/builder"
 	test_graphql "myvendor.mytld/myproject/backend/test/graphql"
 )
 
@@ -25,6 +26,8 @@ const accountGQL = `
 `
 
 func TestQueryResolver_Account(t *testing.T) {
+	t.Parallel()
+
 	type result struct {
 		Data struct {
 			Result *struct {
@@ -82,11 +85,9 @@ func TestQueryResolver_Account(t *testing.T) {
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			db := test_db.CreateTestDatabase(t)
+			db := test_db.CreateTestDatabaseWithFixtures(t, tc.fixtures...)
 			timeSource := test.FixedTime()
 
-			test_db.ExecFixtures(t, db, tc.fixtures...)
-
 			query := test_graphql.GraphqlQuery{
 				Query:     accountGQL,
 				Variables: tc.variables,
@@ -101,3 +102,76 @@ func TestQueryResolver_Account(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryResolver_Account_WithBuiltFixtures(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Data struct {
+			Result *struct {
+				ID           uuid.UUID
+				EmailAddress string
+			}
+		}
+		test_graphql.GraphqlErrors
+	}
+
+	db := test_db.CreateTestDatabase(t)
+	timeSource := test.FixedTime()
+
+	organisation := builder.Organisation(t, db).WithName("Fresh Corp").Create()
+	colleague := builder.Account(t, db).WithOrganisation(organisation).Create()
+	otherAccount := builder.Account(t, db).WithOrganisation(builder.Organisation(t, db).Create()).Create()
+	deletedColleague := builder.Account(t, db).WithOrganisation(organisation).WithDeletedAt(timeSource.Now()).Create()
+
+	tt := []struct {
+		name    string
+		id      uuid.UUID
+		expects func(t *testing.T, res result)
+	}{
+		{
+			name: "account in same organisation",
+			id:   colleague.ID,
+			expects: func(t *testing.T, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotNil(t, res.Data.Result, "result")
+				assert.Equal(t, colleague.EmailAddress, res.Data.Result.EmailAddress)
+			},
+		},
+		{
+			name: "account in other organisation",
+			id:   otherAccount.ID,
+			expects: func(t *testing.T, res result) {
+				test_graphql.RequireNotAuthorizedError(t, res.GraphqlErrors)
+			},
+		},
+		{
+			name: "deleted account in same organisation",
+			id:   deletedColleague.ID,
+			expects: func(t *testing.T, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				assert.Nil(t, res.Data.Result, "result")
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			query := test_graphql.GraphqlQuery{
+				Query: accountGQL,
+				Variables: map[string]interface{}{
+					"id": tc.id,
+				},
+			}
+
+			var res result
+
+			req := test_graphql.NewRequest(t, query)
+			builder.Account(t, db).WithOrganisation(organisation).Authenticate(timeSource, req)
+			test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource}, req, &res)
+			tc.expects(t, res)
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package admin_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/test"
+	test_auth "myvendor.mytld/myproject/backend/test/auth"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+	test_graphql "myvendor.mytld/myproject/backend/test/graphql"
+)
+
+const accountsConnectionGQL = `
+	query AccountsConnection($first: Int, $after: String, $last: Int, $before: String, $filter: AccountFilter, $orderBy: AccountOrder) {
+		result: accountsConnection(first: $first, after: $after, last: $last, before: $before, filter: $filter, orderBy: $orderBy) {
+			edges {
+				cursor
+				node {
+					id
+					emailAddress
+				}
+			}
+			pageInfo {
+				hasNextPage
+				hasPreviousPage
+				startCursor
+				endCursor
+			}
+			totalCount
+		}
+	}
+`
+
+type accountsConnectionResult struct {
+	Data struct {
+		Result *struct {
+			Edges []struct {
+				Cursor string
+				Node   struct {
+					ID           uuid.UUID
+					EmailAddress string
+				}
+			}
+			PageInfo struct {
+				HasNextPage     bool
+				HasPreviousPage bool
+				StartCursor     *string
+				EndCursor       *string
+			}
+			TotalCount int
+		}
+	}
+	test_graphql.GraphqlErrors
+}
+
+func (r accountsConnectionResult) ids() []uuid.UUID {
+	ids := make([]uuid.UUID, len(r.Data.Result.Edges))
+	for i, edge := range r.Data.Result.Edges {
+		ids[i] = edge.Node.ID
+	}
+	return ids
+}
+
+func TestQueryResolver_AccountsConnection(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name          string
+		applyAuthFunc test_auth.ApplyAuthValuesFunc
+		fixtures      []string
+		variables     map[string]interface{}
+		expects       func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res accountsConnectionResult)
+	}{
+		{
+			name:          "with SystemAdministrator and no arguments",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base"},
+			variables:     map[string]interface{}{},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res accountsConnectionResult) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				assert.Equal(t, []uuid.UUID{
+					uuid.FromStringOrNil("2035f4da-f385-42c4-a609-02d9aa7290e5"),
+					uuid.FromStringOrNil("3ad082c7-cbda-49e1-a707-c53e1962be65"),
+					uuid.FromStringOrNil("d7037ad0-d4bb-4dcc-8759-d82fbb3354e8"),
+					uuid.FromStringOrNil("f045e5d1-cdad-4964-a7e2-139c8a87346c"),
+				}, res.ids(), "result.edges.node.id")
+				assert.False(t, res.Data.Result.PageInfo.HasNextPage, "result.pageInfo.hasNextPage")
+				assert.False(t, res.Data.Result.PageInfo.HasPreviousPage, "result.pageInfo.hasPreviousPage")
+				assert.Equal(t, 4, res.Data.Result.TotalCount, "result.totalCount")
+			},
+		},
+		{
+			name:          "with SystemAdministrator and first",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base"},
+			variables: map[string]interface{}{
+				"first": 2,
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res accountsConnectionResult) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.Len(t, res.Data.Result.Edges, 2, "result.edges")
+				assert.True(t, res.Data.Result.PageInfo.HasNextPage, "result.pageInfo.hasNextPage")
+				assert.False(t, res.Data.Result.PageInfo.HasPreviousPage, "result.pageInfo.hasPreviousPage")
+				assert.Equal(t, res.Data.Result.Edges[1].Cursor, *res.Data.Result.PageInfo.EndCursor, "result.pageInfo.endCursor")
+				assert.Equal(t, 4, res.Data.Result.TotalCount, "result.totalCount")
+			},
+		},
+		{
+			name:          "with SystemAdministrator and last ordered by createdAt descending",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base"},
+			variables: map[string]interface{}{
+				"last": 1,
+				"orderBy": map[string]interface{}{
+					"field":     "createdAt",
+					"direction": "Desc",
+				},
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res accountsConnectionResult) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.Len(t, res.Data.Result.Edges, 1, "result.edges")
+				assert.False(t, res.Data.Result.PageInfo.HasNextPage, "result.pageInfo.hasNextPage")
+				assert.True(t, res.Data.Result.PageInfo.HasPreviousPage, "result.pageInfo.hasPreviousPage")
+			},
+		},
+		{
+			name:          "with OrganisationAdministrator",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			fixtures:      []string{"base"},
+			variables:     map[string]interface{}{},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res accountsConnectionResult) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				assert.Equal(t, []uuid.UUID{
+					uuid.FromStringOrNil("3ad082c7-cbda-49e1-a707-c53e1962be65"),
+					uuid.FromStringOrNil("f045e5d1-cdad-4964-a7e2-139c8a87346c"),
+				}, res.ids(), "result.edges.node.id")
+				assert.Equal(t, 2, res.Data.Result.TotalCount, "result.totalCount")
+			},
+		},
+		{
+			name:          "with invalid cursor",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base"},
+			variables: map[string]interface{}{
+				"after": "not-a-cursor",
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res accountsConnectionResult) {
+				require.NotEmpty(t, res.Errors, "errors")
+				assert.Nil(t, res.Data.Result, "result")
+			},
+		},
+		{
+			name:          "with first exceeding maximum",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base"},
+			variables: map[string]interface{}{
+				"first": 1001,
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res accountsConnectionResult) {
+				require.NotEmpty(t, res.Errors, "errors")
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := test_db.CreateTestDatabaseWithFixtures(t, tc.fixtures...)
+			timeSource := test.FixedTime()
+
+			query := test_graphql.GraphqlQuery{
+				Query:     accountsConnectionGQL,
+				Variables: tc.variables,
+			}
+
+			var res accountsConnectionResult
+
+			req := test_graphql.NewRequest(t, query)
+			auth := tc.applyAuthFunc(t, timeSource, req)
+			test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource}, req, &res)
+			tc.expects(t, db, auth, res)
+		})
+	}
+}
+
+func TestQueryResolver_AccountsConnection_PagingThroughAllRecords(t *testing.T) {
+	t.Parallel()
+
+	db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+	timeSource := test.FixedTime()
+
+	queryPage := func(t *testing.T, variables map[string]interface{}) accountsConnectionResult {
+		t.Helper()
+
+		var res accountsConnectionResult
+		req := test_graphql.NewRequest(t, test_graphql.GraphqlQuery{
+			Query:     accountsConnectionGQL,
+			Variables: variables,
+		})
+		test_auth.ApplyFixedAuthValuesSystemAdministrator(t, timeSource, req)
+		test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource}, req, &res)
+		test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+		return res
+	}
+
+	orderBy := map[string]interface{}{
+		"field":     "emailAddress",
+		"direction": "Desc",
+	}
+
+	var forwardIDs []uuid.UUID
+	var after *string
+	for {
+		res := queryPage(t, map[string]interface{}{
+			"first":   3,
+			"after":   after,
+			"orderBy": orderBy,
+		})
+		forwardIDs = append(forwardIDs, res.ids()...)
+		if !res.Data.Result.PageInfo.HasNextPage {
+			break
+		}
+		after = res.Data.Result.PageInfo.EndCursor
+	}
+	require.Len(t, forwardIDs, 4, "forward ids")
+
+	var backwardIDs []uuid.UUID
+	var before *string
+	for {
+		res := queryPage(t, map[string]interface{}{
+			"last":    3,
+			"before":  before,
+			"orderBy": orderBy,
+		})
+		backwardIDs = append(res.ids(), backwardIDs...)
+		if !res.Data.Result.PageInfo.HasPreviousPage {
+			break
+		}
+		before = res.Data.Result.PageInfo.StartCursor
+	}
+
+	assert.Equal(t, forwardIDs, backwardIDs, "backward paging returns the same order")
+}
@@ -27,6 +27,8 @@ const allAccountsGQL = `
 `
 
 func TestQueryResolver_AllAccounts(t *testing.T) {
+	t.Parallel()
+
 	type result struct {
 		Data struct {
 			Result []struct {
@@ -103,6 +105,34 @@ func TestQueryResolver_AllAccounts(t *testing.T) {
 				assert.Equal(t, 2, res.Data.Meta.Count, "meta.count")
 			},
 		},
+		{
+			name:          "with SystemAdministrator and deleted accounts",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base", "deleted"},
+			variables:     map[string]interface{}{},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				assert.Len(t, res.Data.Result, 2, "result")
+				assert.Equal(t, 2, res.Data.Meta.Count, "meta.count")
+			},
+		},
+		{
+			name:          "with SystemAdministrator and withDeleted filter",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base", "deleted"},
+			variables: map[string]interface{}{
+				"filter": map[string]interface{}{
+					"withDeleted": true,
+				},
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				assert.Len(t, res.Data.Result, 5, "result")
+				assert.Equal(t, 5, res.Data.Meta.Count, "meta.count")
+			},
+		},
 		{
 			name:          "with OrganisationAdministrator and no filter",
 			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
@@ -121,15 +151,26 @@ func TestQueryResolver_AllAccounts(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:          "with OrganisationAdministrator and withDeleted filter",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			fixtures:      []string{"base", "deleted"},
+			variables: map[string]interface{}{
+				"filter": map[string]interface{}{
+					"withDeleted": true,
+				},
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNotAuthorizedError(t, res.GraphqlErrors)
+			},
+		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			db := test_db.CreateTestDatabase(t)
+			db := test_db.CreateTestDatabaseWithFixtures(t, tc.fixtures...)
 			timeSource := test.FixedTime()
 
-			test_db.ExecFixtures(t, db, tc.fixtures...)
-
 			query := test_graphql.GraphqlQuery{
 				Query:     allAccountsGQL,
 				Variables: tc.variables,
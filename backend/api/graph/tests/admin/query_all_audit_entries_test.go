@@ -0,0 +1,281 @@
+package admin_test
+
+import (
+	"bytes"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/storage/local"
+	"myvendor.mytld/myproject/backend/test"
+	test_auth "myvendor.mytld/myproject/backend/test/auth"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+	test_graphql "myvendor.mytld/myproject/backend/test/graphql"
+)
+
+const allAuditEntriesGQL = `
+	query AllAuditEntries($filter: AuditEntryFilter) {
+		result: allAuditEntries(filter: $filter) {
+			actorAccountId
+			organisationId
+			commandType
+			targetIds
+			changes {
+				field
+				before
+				after
+				redacted
+			}
+			ipAddress
+		}
+		meta: _allAuditEntriesMeta(filter: $filter) {
+			count
+		}
+	}
+`
+
+func TestQueryResolver_AllAuditEntries(t *testing.T) {
+	t.Parallel()
+
+	type auditChange struct {
+		Field    string
+		Before   *string
+		After    *string
+		Redacted bool
+	}
+	type auditEntry struct {
+		ActorAccountID *uuid.UUID
+		OrganisationID *uuid.UUID
+		CommandType    string
+		TargetIDs      []uuid.UUID `json:"targetIds"`
+		Changes        []auditChange
+		IPAddress      *string
+	}
+	type result struct {
+		Data struct {
+			Result []auditEntry
+			Meta   struct {
+				Count int
+			}
+		}
+		test_graphql.GraphqlErrors
+	}
+
+	acmeID := uuid.Must(uuid.FromString("6330de58-2761-411e-a243-bec6d0c53876"))
+	otherCorpID := uuid.Must(uuid.FromString("dba20d09-a3df-4975-9406-2fb6fd8f0940"))
+
+	// Commands that are executed by a system administrator before querying the audit log
+	setup := func(t *testing.T, db *sql.DB, timeSource test.FixedTimeSource) {
+		for i, variables := range []map[string]interface{}{
+			{"id": acmeID.String(), "name": "Acme Ltd."},
+			{"id": otherCorpID.String(), "name": "Other Corp Ltd."},
+		} {
+			req := test_graphql.NewRequest(t, test_graphql.GraphqlQuery{
+				Query:     updateOrganisationGQL,
+				Variables: variables,
+			})
+			// Commands are executed one minute apart to get a defined order
+			commandTimeSource := timeSource.Add(time.Duration(i) * time.Minute)
+			test_auth.ApplyFixedAuthValuesSystemAdministrator(t, commandTimeSource, req)
+
+			var res struct{ test_graphql.GraphqlErrors }
+			test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: commandTimeSource}, req, &res)
+			test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+		}
+	}
+
+	tt := []struct {
+		name          string
+		applyAuthFunc test_auth.ApplyAuthValuesFunc
+		variables     map[string]interface{}
+		expects       func(t *testing.T, res result)
+	}{
+		{
+			name:          "with SystemAdministrator",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			expects: func(t *testing.T, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.Len(t, res.Data.Result, 2)
+				assert.Equal(t, 2, res.Data.Meta.Count)
+
+				// Newest first
+				entry := res.Data.Result[0]
+				assert.Equal(t, "OrganisationUpdate", entry.CommandType)
+				assert.Equal(t, &otherCorpID, entry.OrganisationID)
+				assert.Equal(t, []uuid.UUID{otherCorpID}, entry.TargetIDs)
+				assert.Equal(t, uuid.Must(uuid.FromString("d7037ad0-d4bb-4dcc-8759-d82fbb3354e8")), *entry.ActorAccountID)
+				assert.Equal(t, []auditChange{
+					{Field: "name", Before: test_graphql.ToPtr(`"Other Corp"`), After: test_graphql.ToPtr(`"Other Corp Ltd."`)},
+				}, entry.Changes)
+				require.NotNil(t, entry.IPAddress)
+				assert.Equal(t, "192.0.2.1", *entry.IPAddress)
+			},
+		},
+		{
+			name:          "with SystemAdministrator and filter by target",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			variables: map[string]interface{}{
+				"filter": map[string]interface{}{
+					"targetId": acmeID.String(),
+				},
+			},
+			expects: func(t *testing.T, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.Len(t, res.Data.Result, 1)
+				assert.Equal(t, &acmeID, res.Data.Result[0].OrganisationID)
+				assert.Equal(t, 1, res.Data.Meta.Count)
+			},
+		},
+		{
+			name:          "with OrganisationAdministrator gets only entries of own organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			expects: func(t *testing.T, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.Len(t, res.Data.Result, 1)
+				assert.Equal(t, &acmeID, res.Data.Result[0].OrganisationID)
+				assert.Equal(t, 1, res.Data.Meta.Count)
+			},
+		},
+		{
+			name:          "with OrganisationAdministrator and filter by other organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			variables: map[string]interface{}{
+				"filter": map[string]interface{}{
+					"organisationId": otherCorpID.String(),
+				},
+			},
+			expects: func(t *testing.T, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				// The filter is overridden with the organisation of the administrator
+				require.Len(t, res.Data.Result, 1)
+				assert.Equal(t, &acmeID, res.Data.Result[0].OrganisationID)
+			},
+		},
+		{
+			name: "without authentication",
+			expects: func(t *testing.T, res result) {
+				test_graphql.RequireNotAuthorizedError(t, res.GraphqlErrors)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+			timeSource := test.FixedTime()
+
+			setup(t, db, timeSource)
+
+			req := test_graphql.NewRequest(t, test_graphql.GraphqlQuery{
+				Query:     allAuditEntriesGQL,
+				Variables: tc.variables,
+			})
+			if tc.applyAuthFunc != nil {
+				tc.applyAuthFunc(t, timeSource, req)
+			}
+
+			var res result
+			test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource}, req, &res)
+
+			tc.expects(t, res)
+		})
+	}
+}
+
+func TestQueryResolver_AllAuditEntries_AssetDelete(t *testing.T) {
+	t.Parallel()
+
+	db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+	timeSource := test.FixedTime()
+	fileStorage, err := local.NewStorage(t.TempDir())
+	require.NoError(t, err)
+
+	acmeID := uuid.Must(uuid.FromString("6330de58-2761-411e-a243-bec6d0c53876"))
+
+	// Upload and delete an asset, one minute apart to get a defined order
+	req := test_graphql.NewMultipartRequest(t, bytes.Buffer{}, test_graphql.GraphqlQuery{
+		Query:     uploadAssetGQL,
+		Variables: map[string]interface{}{"file": nil, "organisationId": acmeID.String()},
+	}, map[string]test_graphql.MultipartFileInfo{
+		"0": {
+			Name:      "hello.txt",
+			Variables: []string{"variables.file"},
+			Reader:    strings.NewReader("Hello"),
+		},
+	})
+	test_auth.ApplyFixedAuthValuesOrganisationAdministrator(t, timeSource, req)
+
+	var uploadRes struct {
+		Data struct {
+			Result struct {
+				ID uuid.UUID
+			}
+		}
+		test_graphql.GraphqlErrors
+	}
+	test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource, Storage: fileStorage}, req, &uploadRes)
+	test_graphql.RequireNoErrors(t, uploadRes.GraphqlErrors)
+	assetID := uploadRes.Data.Result.ID
+
+	deleteTimeSource := timeSource.Add(time.Minute)
+	req = test_graphql.NewRequest(t, test_graphql.GraphqlQuery{
+		Query:     `mutation DeleteAsset($id: UUID!) { result: deleteAsset(id: $id) { id } }`,
+		Variables: map[string]interface{}{"id": assetID.String()},
+	})
+	test_auth.ApplyFixedAuthValuesOrganisationAdministrator(t, deleteTimeSource, req)
+
+	var deleteRes struct{ test_graphql.GraphqlErrors }
+	test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: deleteTimeSource, Storage: fileStorage}, req, &deleteRes)
+	test_graphql.RequireNoErrors(t, deleteRes.GraphqlErrors)
+
+	req = test_graphql.NewRequest(t, test_graphql.GraphqlQuery{
+		Query: allAuditEntriesGQL,
+		Variables: map[string]interface{}{
+			"filter": map[string]interface{}{
+				"targetId": assetID.String(),
+			},
+		},
+	})
+	test_auth.ApplyFixedAuthValuesSystemAdministrator(t, deleteTimeSource, req)
+
+	var res struct {
+		Data struct {
+			Result []struct {
+				OrganisationID *uuid.UUID
+				CommandType    string
+				TargetIDs      []uuid.UUID `json:"targetIds"`
+				Changes        []struct {
+					Field  string
+					Before *string
+					After  *string
+				}
+			}
+		}
+		test_graphql.GraphqlErrors
+	}
+	test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: deleteTimeSource}, req, &res)
+	test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+	// Newest first
+	require.Len(t, res.Data.Result, 2)
+	deleteEntry := res.Data.Result[0]
+	assert.Equal(t, "AssetDelete", deleteEntry.CommandType)
+	assert.Equal(t, &acmeID, deleteEntry.OrganisationID)
+	assert.Equal(t, []uuid.UUID{assetID}, deleteEntry.TargetIDs)
+	require.Len(t, deleteEntry.Changes, 3)
+	assert.Equal(t, "contentType", deleteEntry.Changes[0].Field)
+	assert.Equal(t, test_graphql.ToPtr(`"text/plain"`), deleteEntry.Changes[0].Before)
+	assert.Nil(t, deleteEntry.Changes[0].After)
+
+	assert.Equal(t, "AssetCreate", res.Data.Result[1].CommandType)
+}
@@ -28,6 +28,8 @@ const allOrganisationsGQL = `
 `
 
 func TestQueryResolver_AllOrganisations(t *testing.T) {
+	t.Parallel()
+
 	type result struct {
 		Data struct {
 			Result []struct {
@@ -78,11 +80,9 @@ func TestQueryResolver_AllOrganisations(t *testing.T) {
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
-			db := test_db.CreateTestDatabase(t)
+			db := test_db.CreateTestDatabaseWithFixtures(t, tc.fixtures...)
 			timeSource := test.FixedTime()
 
-			test_db.ExecFixtures(t, db, tc.fixtures...)
-
 			query := test_graphql.GraphqlQuery{
 				Query:     allOrganisationsGQL,
 				Variables: tc.variables,
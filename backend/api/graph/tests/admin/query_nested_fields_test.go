@@ -0,0 +1,203 @@
+package admin_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/test"
+	test_auth "myvendor.mytld/myproject/backend/test/auth"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+	test_graphql "myvendor.mytld/myproject/backend/test/graphql"
+)
+
+const nestedFieldsGQL = `
+	query NestedFields {
+		accounts: allAccounts(sortField: "emailAddress") {
+			id
+			organisation {
+				id
+				name
+			}
+		}
+		organisations: allOrganisations(sortField: "name") {
+			id
+			accounts(sortField: "emailAddress") {
+				id
+				emailAddress
+			}
+		}
+	}
+`
+
+func TestQueryResolver_NestedFields(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Data struct {
+			Accounts []struct {
+				ID           uuid.UUID
+				Organisation *struct {
+					ID   uuid.UUID
+					Name string
+				}
+			}
+			Organisations []struct {
+				ID       uuid.UUID
+				Accounts []struct {
+					ID           uuid.UUID
+					EmailAddress string
+				}
+			}
+		}
+		test_graphql.GraphqlErrors
+	}
+
+	tt := []struct {
+		name          string
+		applyAuthFunc test_auth.ApplyAuthValuesFunc
+		fixtures      []string
+		expects       func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result)
+	}{
+		{
+			name:          "with SystemAdministrator",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base"},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.Len(t, res.Data.Accounts, 4, "accounts")
+				organisationNames := make(map[uuid.UUID]string)
+				for _, account := range res.Data.Accounts {
+					if account.ID == uuid.FromStringOrNil("d7037ad0-d4bb-4dcc-8759-d82fbb3354e8") {
+						assert.Nil(t, account.Organisation, "accounts.organisation of SystemAdministrator")
+						continue
+					}
+					require.NotNil(t, account.Organisation, "accounts.organisation")
+					organisationNames[account.ID] = account.Organisation.Name
+				}
+				assert.Equal(t, map[uuid.UUID]string{
+					uuid.FromStringOrNil("3ad082c7-cbda-49e1-a707-c53e1962be65"): "Acme Inc.",
+					uuid.FromStringOrNil("f045e5d1-cdad-4964-a7e2-139c8a87346c"): "Acme Inc.",
+					uuid.FromStringOrNil("2035f4da-f385-42c4-a609-02d9aa7290e5"): "Other Corp",
+				}, organisationNames, "accounts.organisation.name")
+
+				require.Len(t, res.Data.Organisations, 2, "organisations")
+				assert.Len(t, res.Data.Organisations[0].Accounts, 2, "organisations.0.accounts")
+				assert.Equal(t, "admin+acmeinc@example.com", res.Data.Organisations[0].Accounts[0].EmailAddress, "organisations.0.accounts.0.emailAddress")
+				assert.Len(t, res.Data.Organisations[1].Accounts, 1, "organisations.1.accounts")
+			},
+		},
+		{
+			name:          "with OrganisationAdministrator",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			fixtures:      []string{"base"},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.Len(t, res.Data.Accounts, 2, "accounts")
+				for _, account := range res.Data.Accounts {
+					require.NotNil(t, account.Organisation, "accounts.organisation")
+					assert.Equal(t, auth.OrganisationID.UUID, account.Organisation.ID, "accounts.organisation.id")
+				}
+
+				require.Len(t, res.Data.Organisations, 1, "organisations")
+				assert.Len(t, res.Data.Organisations[0].Accounts, 2, "organisations.0.accounts")
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := test_db.CreateTestDatabaseWithFixtures(t, tc.fixtures...)
+			timeSource := test.FixedTime()
+
+			query := test_graphql.GraphqlQuery{
+				Query: nestedFieldsGQL,
+			}
+
+			var res result
+
+			req := test_graphql.NewRequest(t, query)
+			auth := tc.applyAuthFunc(t, timeSource, req)
+			test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource}, req, &res)
+			tc.expects(t, db, auth, res)
+		})
+	}
+}
+
+func TestQueryResolver_NestedFields_OrganisationAccountsFirst(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Data struct {
+			AllOrganisations []struct {
+				Name     string
+				Accounts []struct {
+					EmailAddress string
+				}
+			}
+		}
+		test_graphql.GraphqlErrors
+	}
+
+	tt := []struct {
+		name    string
+		first   int
+		expects func(t *testing.T, res result)
+	}{
+		{
+			name:  "limits accounts per organisation",
+			first: 1,
+			expects: func(t *testing.T, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.Len(t, res.Data.AllOrganisations, 2, "allOrganisations")
+				require.Len(t, res.Data.AllOrganisations[0].Accounts, 1, "allOrganisations.0.accounts")
+				assert.Equal(t, "admin+acmeinc@example.com", res.Data.AllOrganisations[0].Accounts[0].EmailAddress, "allOrganisations.0.accounts.0.emailAddress")
+				assert.Len(t, res.Data.AllOrganisations[1].Accounts, 1, "allOrganisations.1.accounts")
+			},
+		},
+		{
+			name:  "exceeding maximum",
+			first: 1001,
+			expects: func(t *testing.T, res result) {
+				test_graphql.RequireErrors(t, res.GraphqlErrors)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+			timeSource := test.FixedTime()
+
+			query := test_graphql.GraphqlQuery{
+				Query: `
+					query ($first: Int) {
+						allOrganisations(sortField: "name") {
+							name
+							accounts(sortField: "emailAddress", first: $first) {
+								emailAddress
+							}
+						}
+					}
+				`,
+				Variables: map[string]interface{}{
+					"first": tc.first,
+				},
+			}
+
+			var res result
+
+			req := test_graphql.NewRequest(t, query)
+			test_auth.ApplyFixedAuthValuesSystemAdministrator(t, timeSource, req)
+			test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource}, req, &res)
+			tc.expects(t, res)
+		})
+	}
+}
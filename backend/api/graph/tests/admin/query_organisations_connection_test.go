@@ -0,0 +1,133 @@
+package admin_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/test"
+	test_auth "myvendor.mytld/myproject/backend/test/auth"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+	test_graphql "myvendor.mytld/myproject/backend/test/graphql"
+)
+
+const organisationsConnectionGQL = `
+	query OrganisationsConnection($first: Int, $after: String, $last: Int, $before: String, $filter: OrganisationFilter, $orderBy: OrganisationOrder) {
+		result: organisationsConnection(first: $first, after: $after, last: $last, before: $before, filter: $filter, orderBy: $orderBy) {
+			edges {
+				cursor
+				node {
+					id
+					name
+				}
+			}
+			pageInfo {
+				hasNextPage
+				hasPreviousPage
+			}
+			totalCount
+		}
+	}
+`
+
+func TestQueryResolver_OrganisationsConnection(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Data struct {
+			Result *struct {
+				Edges []struct {
+					Cursor string
+					Node   struct {
+						ID   uuid.UUID
+						Name string
+					}
+				}
+				PageInfo struct {
+					HasNextPage     bool
+					HasPreviousPage bool
+				}
+				TotalCount int
+			}
+		}
+		test_graphql.GraphqlErrors
+	}
+
+	tt := []struct {
+		name          string
+		applyAuthFunc test_auth.ApplyAuthValuesFunc
+		fixtures      []string
+		variables     map[string]interface{}
+		expects       func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result)
+	}{
+		{
+			name:          "with SystemAdministrator ordered by name descending",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base"},
+			variables: map[string]interface{}{
+				"first": 1,
+				"orderBy": map[string]interface{}{
+					"field":     "name",
+					"direction": "Desc",
+				},
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.Len(t, res.Data.Result.Edges, 1, "result.edges")
+				assert.Equal(t, "Other Corp", res.Data.Result.Edges[0].Node.Name, "result.edges.0.node.name")
+				assert.True(t, res.Data.Result.PageInfo.HasNextPage, "result.pageInfo.hasNextPage")
+				assert.False(t, res.Data.Result.PageInfo.HasPreviousPage, "result.pageInfo.hasPreviousPage")
+				assert.Equal(t, 2, res.Data.Result.TotalCount, "result.totalCount")
+			},
+		},
+		{
+			name:          "with OrganisationAdministrator",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			fixtures:      []string{"base"},
+			variables:     map[string]interface{}{},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.Len(t, res.Data.Result.Edges, 1, "result.edges")
+				assert.Equal(t, auth.OrganisationID.UUID, res.Data.Result.Edges[0].Node.ID, "result.edges.0.node.id")
+				assert.Equal(t, 1, res.Data.Result.TotalCount, "result.totalCount")
+			},
+		},
+		{
+			name:          "with first and last",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			fixtures:      []string{"base"},
+			variables: map[string]interface{}{
+				"first": 1,
+				"last":  1,
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				require.NotEmpty(t, res.Errors, "errors")
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := test_db.CreateTestDatabaseWithFixtures(t, tc.fixtures...)
+			timeSource := test.FixedTime()
+
+			query := test_graphql.GraphqlQuery{
+				Query:     organisationsConnectionGQL,
+				Variables: tc.variables,
+			}
+
+			var res result
+
+			req := test_graphql.NewRequest(t, query)
+			auth := tc.applyAuthFunc(t, timeSource, req)
+			test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource}, req, &res)
+			tc.expects(t, db, auth, res)
+		})
+	}
+}
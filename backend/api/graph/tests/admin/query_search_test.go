@@ -0,0 +1,209 @@
+package admin_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/test"
+	test_auth "myvendor.mytld/myproject/backend/test/auth"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+	test_graphql "myvendor.mytld/myproject/backend/test/graphql"
+)
+
+const searchGQL = `
+	query Search($q: String!, $limit: Int) {
+		result: search(q: $q, limit: $limit) {
+			node {
+				__typename
+				... on Account {
+					id
+					emailAddress
+				}
+				... on Organisation {
+					id
+					name
+				}
+			}
+			rank
+			text
+			highlight
+		}
+	}
+`
+
+func TestQueryResolver_Search(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Data struct {
+			Result []struct {
+				Node struct {
+					Typename     string `json:"__typename"`
+					ID           uuid.UUID
+					EmailAddress string
+					Name         string
+				}
+				Rank      float64
+				Text      string
+				Highlight string
+			}
+		}
+		test_graphql.GraphqlErrors
+	}
+
+	tt := []struct {
+		name          string
+		applyAuthFunc test_auth.ApplyAuthValuesFunc
+		variables     map[string]interface{}
+		expects       func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result)
+	}{
+		{
+			name:          "with SystemAdministrator and prefix of a word",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			variables: map[string]interface{}{
+				"q": "acme",
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				highlights := make(map[string]string)
+				for _, r := range res.Data.Result {
+					highlights[r.Text] = r.Highlight
+				}
+				assert.Equal(t, map[string]string{
+					"Acme Inc.":                      "<b>Acme</b> Inc.",
+					"admin+acmeinc@example.com":      "admin+<b>acme</b>inc@example.com",
+					"otheradmin+acmeinc@example.com": "otheradmin+<b>acme</b>inc@example.com",
+				}, highlights)
+			},
+		},
+		{
+			name:          "with SystemAdministrator and multiple words",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			variables: map[string]interface{}{
+				"q": "other corp",
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotEmpty(t, res.Data.Result)
+				assert.Equal(t, "Organisation", res.Data.Result[0].Node.Typename)
+				assert.Equal(t, "Other Corp", res.Data.Result[0].Node.Name)
+				assert.Equal(t, "<b>Other</b> <b>Corp</b>", res.Data.Result[0].Highlight)
+
+				for i := 1; i < len(res.Data.Result); i++ {
+					assert.GreaterOrEqual(t, res.Data.Result[i-1].Rank, res.Data.Result[i].Rank, "ordered by rank")
+				}
+			},
+		},
+		{
+			name:          "with SystemAdministrator and typo in email address",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			variables: map[string]interface{}{
+				"q": "admin@exmaple.com",
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotEmpty(t, res.Data.Result)
+				assert.Equal(t, "Account", res.Data.Result[0].Node.Typename)
+				assert.Equal(t, "admin@example.com", res.Data.Result[0].Node.EmailAddress)
+			},
+		},
+		{
+			name:          "with SystemAdministrator and limit",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			variables: map[string]interface{}{
+				"q":     "acme",
+				"limit": 1,
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.Len(t, res.Data.Result, 1)
+			},
+		},
+		{
+			name:          "with SystemAdministrator and limit less than 1",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			variables: map[string]interface{}{
+				"q":     "acme",
+				"limit": 0,
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireErrors(t, res.GraphqlErrors, test_graphql.GraphqlError{
+					Extensions: test_graphql.GraphqlErrorExtensions{
+						Type:  "validationFailed",
+						Field: "limit",
+						Code:  "mustBeAtLeast",
+					},
+				})
+			},
+		},
+		{
+			name:          "with OrganisationAdministrator and records of other organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			variables: map[string]interface{}{
+				"q": "othercorp",
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				assert.Empty(t, res.Data.Result)
+			},
+		},
+		{
+			name:          "with OrganisationAdministrator and records of own organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			variables: map[string]interface{}{
+				"q": "acme",
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.Len(t, res.Data.Result, 3)
+				for _, r := range res.Data.Result {
+					if r.Node.Typename == "Organisation" {
+						assert.Equal(t, auth.OrganisationID.UUID, r.Node.ID)
+					}
+				}
+			},
+		},
+		{
+			name: "without authentication",
+			variables: map[string]interface{}{
+				"q": "acme",
+			},
+			expects: func(t *testing.T, db *sql.DB, auth test_auth.FixedAuthTokenData, res result) {
+				test_graphql.RequireNotAuthorizedError(t, res.GraphqlErrors)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+			timeSource := test.FixedTime()
+
+			query := test_graphql.GraphqlQuery{
+				Query:     searchGQL,
+				Variables: tc.variables,
+			}
+
+			var res result
+
+			req := test_graphql.NewRequest(t, query)
+			var auth test_auth.FixedAuthTokenData
+			if tc.applyAuthFunc != nil {
+				auth = tc.applyAuthFunc(t, timeSource, req)
+			}
+			test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource}, req, &res)
+			tc.expects(t, db, auth, res)
+		})
+	}
+}
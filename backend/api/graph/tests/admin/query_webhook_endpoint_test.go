@@ -0,0 +1,172 @@
+package admin_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/test"
+	test_auth "myvendor.mytld/myproject/backend/test/auth"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+	test_graphql "myvendor.mytld/myproject/backend/test/graphql"
+)
+
+var (
+	fixtureWebhookEndpointID   = uuid.Must(uuid.FromString("01920a3c-5b6d-7e8f-9a0b-1c2d3e4f5a60"))
+	fixtureWebhookDeliveryIDs  = []uuid.UUID{uuid.Must(uuid.FromString("01920a3c-5b6d-7e8f-9a0b-1c2d3e4f5a61")), uuid.Must(uuid.FromString("01920a3c-5b6d-7e8f-9a0b-1c2d3e4f5a62"))}
+	fixtureWebhookOrganisation = uuid.Must(uuid.FromString("6330de58-2761-411e-a243-bec6d0c53876"))
+)
+
+// insertWebhookFixtures adds an endpoint of Acme Inc. with a succeeded and a failed delivery
+func insertWebhookFixtures(t *testing.T, db *sql.DB) {
+	t.Helper()
+
+	ctx := context.Background()
+	err := repository.InsertWebhookEndpoint(ctx, db, repository.WebhookEndpointToChangeSet(model.WebhookEndpoint{
+		ID:             fixtureWebhookEndpointID,
+		OrganisationID: fixtureWebhookOrganisation,
+		URL:            "https://example.com/hooks",
+		Secret:         "whsec_test",
+		EventTypes:     []string{"AccountCreated"},
+	}))
+	require.NoError(t, err)
+
+	createdAt := test.FixedTime().Now()
+	for i, status := range []model.WebhookDeliveryStatus{model.WebhookDeliveryStatusSucceeded, model.WebhookDeliveryStatusFailed} {
+		responseStatus := http.StatusOK
+		if status == model.WebhookDeliveryStatusFailed {
+			responseStatus = http.StatusInternalServerError
+		}
+		_, err = repository.InsertWebhookDelivery(ctx, db, repository.WebhookDeliveryToChangeSet(model.WebhookDelivery{
+			ID:                fixtureWebhookDeliveryIDs[i],
+			WebhookEndpointID: fixtureWebhookEndpointID,
+			OrganisationID:    fixtureWebhookOrganisation,
+			EventID:           uuid.Must(uuid.NewV7()),
+			EventType:         "AccountCreated",
+			Payload:           json.RawMessage(`{"type":"AccountCreated"}`),
+			Status:            status,
+			Attempts:          1,
+			ResponseStatus:    &responseStatus,
+			CreatedAt:         createdAt.Add(time.Duration(i) * time.Minute),
+		}))
+		require.NoError(t, err)
+	}
+}
+
+const webhookEndpointGQL = `
+	query WebhookEndpoint($id: UUID!, $status: WebhookDeliveryStatus) {
+		result: WebhookEndpoint(id: $id) {
+			id
+			url
+			deliveries(status: $status) {
+				id
+				status
+				responseStatus
+			}
+		}
+	}
+`
+
+func TestQueryResolver_WebhookEndpoint(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Data struct {
+			Result *struct {
+				ID         uuid.UUID
+				URL        string
+				Deliveries []struct {
+					ID             uuid.UUID
+					Status         string
+					ResponseStatus *int
+				}
+			}
+		}
+		test_graphql.GraphqlErrors
+	}
+
+	tt := []struct {
+		name          string
+		applyAuthFunc test_auth.ApplyAuthValuesFunc
+		variables     map[string]interface{}
+		expects       func(t *testing.T, res result)
+	}{
+		{
+			name:          "with OrganisationAdministrator in same organisation",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesOrganisationAdministrator,
+			variables: map[string]interface{}{
+				"id": fixtureWebhookEndpointID,
+			},
+			expects: func(t *testing.T, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotNil(t, res.Data.Result)
+				assert.Equal(t, "https://example.com/hooks", res.Data.Result.URL)
+				// Newest deliveries first
+				require.Len(t, res.Data.Result.Deliveries, 2)
+				assert.Equal(t, fixtureWebhookDeliveryIDs[1], res.Data.Result.Deliveries[0].ID)
+				assert.Equal(t, "failed", res.Data.Result.Deliveries[0].Status)
+				assert.Equal(t, test_graphql.ToPtr(http.StatusInternalServerError), res.Data.Result.Deliveries[0].ResponseStatus)
+				assert.Equal(t, fixtureWebhookDeliveryIDs[0], res.Data.Result.Deliveries[1].ID)
+			},
+		},
+		{
+			name:          "with SystemAdministrator and status filter",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			variables: map[string]interface{}{
+				"id":     fixtureWebhookEndpointID,
+				"status": "succeeded",
+			},
+			expects: func(t *testing.T, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				require.NotNil(t, res.Data.Result)
+				require.Len(t, res.Data.Result.Deliveries, 1)
+				assert.Equal(t, fixtureWebhookDeliveryIDs[0], res.Data.Result.Deliveries[0].ID)
+			},
+		},
+		{
+			name:          "with not existing id",
+			applyAuthFunc: test_auth.ApplyFixedAuthValuesSystemAdministrator,
+			variables: map[string]interface{}{
+				"id": "2c1a4b8e-1f64-4a5b-9a5f-0c0b7e9f2d11",
+			},
+			expects: func(t *testing.T, res result) {
+				test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+
+				assert.Nil(t, res.Data.Result)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+			timeSource := test.FixedTime()
+
+			insertWebhookFixtures(t, db)
+
+			query := test_graphql.GraphqlQuery{
+				Query:     webhookEndpointGQL,
+				Variables: tc.variables,
+			}
+
+			var res result
+
+			req := test_graphql.NewRequest(t, query)
+			tc.applyAuthFunc(t, timeSource, req)
+			test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource}, req, &res)
+			tc.expects(t, res)
+		})
+	}
+}
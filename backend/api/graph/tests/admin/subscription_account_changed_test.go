@@ -0,0 +1,188 @@
+package admin_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	graphql_ws "github.com/korylprince/go-graphql-ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/test"
+	test_auth "myvendor.mytld/myproject/backend/test/auth"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+	test_graphql "myvendor.mytld/myproject/backend/test/graphql"
+)
+
+const accountChangedGQL = `
+	subscription AccountChanged {
+		accountChanged {
+			action
+			accountId
+			organisationId
+			account {
+				emailAddress
+			}
+		}
+	}
+`
+
+func TestSubscriptionResolver_AccountChanged(t *testing.T) {
+	t.Parallel()
+
+	type notification struct {
+		AccountChanged struct {
+			Action         string
+			AccountID      uuid.UUID
+			OrganisationID *uuid.UUID
+			Account        *struct {
+				EmailAddress string
+			}
+		}
+	}
+
+	db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+	timeSource := test.FixedTime()
+
+	deps := api.ResolverDependencies{DB: db, TimeSource: timeSource}
+
+	// Subscribes as OrganisationAdministrator of Acme Inc.
+	notifications := test_graphql.ServerAndSubscribe[notification](t, deps, &graphql_ws.MessagePayloadStart{
+		Query: accountChangedGQL,
+	})
+
+	updateAccount := func(id, emailAddress, organisationID string) {
+		var res struct {
+			test_graphql.GraphqlErrors
+		}
+		req := test_graphql.NewRequest(t, test_graphql.GraphqlQuery{
+			Query: updateAccountGQL,
+			Variables: map[string]interface{}{
+				"id":             id,
+				"role":           "OrganisationAdministrator",
+				"emailAddress":   emailAddress,
+				"organisationId": organisationID,
+			},
+		})
+		test_auth.ApplyFixedAuthValuesSystemAdministrator(t, timeSource, req)
+		test_graphql.Handle(t, deps, req, &res)
+		test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+	}
+
+	// The subscription is started asynchronously, so changes are repeated until a notification is received.
+	// A change in another organisation is always made first and must never be received.
+	var received notification
+	for i := 0; ; i++ {
+		require.Less(t, i, 50, "no notification received")
+
+		updateAccount("2035f4da-f385-42c4-a609-02d9aa7290e5", fmt.Sprintf("admin+othercorp-%d@example.com", i), "dba20d09-a3df-4975-9406-2fb6fd8f0940")
+		updateAccount("f045e5d1-cdad-4964-a7e2-139c8a87346c", fmt.Sprintf("otheradmin+acmeinc-%d@example.com", i), "6330de58-2761-411e-a243-bec6d0c53876")
+
+		select {
+		case received = <-notifications:
+		case <-time.After(100 * time.Millisecond):
+			continue
+		}
+		break
+	}
+
+	assert.Equal(t, "Updated", received.AccountChanged.Action)
+	assert.Equal(t, uuid.Must(uuid.FromString("f045e5d1-cdad-4964-a7e2-139c8a87346c")), received.AccountChanged.AccountID)
+	require.NotNil(t, received.AccountChanged.OrganisationID)
+	assert.Equal(t, uuid.Must(uuid.FromString("6330de58-2761-411e-a243-bec6d0c53876")), *received.AccountChanged.OrganisationID)
+	require.NotNil(t, received.AccountChanged.Account)
+	assert.Contains(t, received.AccountChanged.Account.EmailAddress, "otheradmin+acmeinc-")
+}
+
+func TestSubscriptionResolver_AccountChanged_MovedToOtherOrganisation(t *testing.T) {
+	t.Parallel()
+
+	type notification struct {
+		AccountChanged struct {
+			Action                 string
+			AccountID              uuid.UUID
+			OrganisationID         *uuid.UUID
+			PreviousOrganisationID *uuid.UUID
+			Account                *struct {
+				EmailAddress string
+			}
+		}
+	}
+
+	db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+	timeSource := test.FixedTime()
+
+	deps := api.ResolverDependencies{DB: db, TimeSource: timeSource}
+
+	// Subscribes as OrganisationAdministrator of Acme Inc.
+	notifications := test_graphql.ServerAndSubscribe[notification](t, deps, &graphql_ws.MessagePayloadStart{
+		Query: `
+			subscription AccountChanged {
+				accountChanged {
+					action
+					accountId
+					organisationId
+					previousOrganisationId
+					account {
+						emailAddress
+					}
+				}
+			}
+		`,
+	})
+
+	updateAccount := func(id, emailAddress, organisationID string) {
+		var res struct {
+			test_graphql.GraphqlErrors
+		}
+		req := test_graphql.NewRequest(t, test_graphql.GraphqlQuery{
+			Query: updateAccountGQL,
+			Variables: map[string]interface{}{
+				"id":             id,
+				"role":           "OrganisationAdministrator",
+				"emailAddress":   emailAddress,
+				"organisationId": organisationID,
+			},
+		})
+		test_auth.ApplyFixedAuthValuesSystemAdministrator(t, timeSource, req)
+		test_graphql.Handle(t, deps, req, &res)
+		test_graphql.RequireNoErrors(t, res.GraphqlErrors)
+	}
+
+	// The subscription is started asynchronously, so changes are repeated until a notification is received
+	for i := 0; ; i++ {
+		require.Less(t, i, 50, "no notification received")
+
+		updateAccount("f045e5d1-cdad-4964-a7e2-139c8a87346c", fmt.Sprintf("otheradmin+acmeinc-%d@example.com", i), "6330de58-2761-411e-a243-bec6d0c53876")
+
+		select {
+		case <-notifications:
+		case <-time.After(100 * time.Millisecond):
+			continue
+		}
+		break
+	}
+
+	// Move the account from Acme Inc. to Other Corp
+	updateAccount("f045e5d1-cdad-4964-a7e2-139c8a87346c", "otheradmin+moved@example.com", "dba20d09-a3df-4975-9406-2fb6fd8f0940")
+
+	// Skip notifications of the changes above that could still be pending
+	var received notification
+	for received.AccountChanged.PreviousOrganisationID == nil {
+		select {
+		case received = <-notifications:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "no notification for moved account received")
+		}
+	}
+
+	assert.Equal(t, "Updated", received.AccountChanged.Action)
+	assert.Equal(t, uuid.Must(uuid.FromString("f045e5d1-cdad-4964-a7e2-139c8a87346c")), received.AccountChanged.AccountID)
+	assert.Equal(t, uuid.Must(uuid.FromString("6330de58-2761-411e-a243-bec6d0c53876")), *received.AccountChanged.PreviousOrganisationID)
+	require.NotNil(t, received.AccountChanged.OrganisationID)
+	assert.Equal(t, uuid.Must(uuid.FromString("dba20d09-a3df-4975-9406-2fb6fd8f0940")), *received.AccountChanged.OrganisationID)
+	assert.Nil(t, received.AccountChanged.Account, "account is not visible in Acme Inc. anymore")
+}
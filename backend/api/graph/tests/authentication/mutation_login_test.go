@@ -10,6 +10,7 @@ import (
 	"myvendor.mytld/myproject/backend/api"
 	"myvendor.mytld/myproject/backend/test"
 	test_db "myvendor.mytld/myproject/backend/test/db"
+	"myvendor.mytld/myproject/backend/test/fixtures/builder"
 	test_graphql "myvendor.mytld/myproject/backend/test/graphql"
 	test_telemetry "myvendor.mytld/myproject/backend/test/telemetry"
 )
@@ -57,10 +58,10 @@ type loginResult struct {
 }
 
 func TestMutationResolver_Login_WithSystemAdministrator_Valid(t *testing.T) {
-	db := test_db.CreateTestDatabase(t)
-	timeSource := test.FixedTime()
+	t.Parallel()
 
-	test_db.ExecFixtures(t, db, "base")
+	db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+	timeSource := test.FixedTime()
 
 	query := test_graphql.GraphqlQuery{
 		Query: loginGQL,
@@ -113,10 +114,10 @@ func TestMutationResolver_Login_WithSystemAdministrator_Valid(t *testing.T) {
 }
 
 func TestMutationResolver_Login_WithSystemAdministrator_InvalidPassword(t *testing.T) {
-	db := test_db.CreateTestDatabase(t)
-	timeSource := test.FixedTime()
+	t.Parallel()
 
-	test_db.ExecFixtures(t, db, "base")
+	db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+	timeSource := test.FixedTime()
 
 	query := test_graphql.GraphqlQuery{
 		Query: loginGQL,
@@ -141,10 +142,10 @@ func TestMutationResolver_Login_WithSystemAdministrator_InvalidPassword(t *testi
 }
 
 func TestMutationResolver_Login_WithOrganisationAdministrator_Valid(t *testing.T) {
-	db := test_db.CreateTestDatabase(t)
-	timeSource := test.FixedTime()
+	t.Parallel()
 
-	test_db.ExecFixtures(t, db, "base")
+	db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+	timeSource := test.FixedTime()
 
 	query := test_graphql.GraphqlQuery{
 		Query: loginGQL,
@@ -169,3 +170,38 @@ func TestMutationResolver_Login_WithOrganisationAdministrator_Valid(t *testing.T
 	require.NotNil(t, result.Data.Result.Account.OrganisationID, "result.account.organisationId")
 	assert.Equal(t, organisationID, *result.Data.Result.Account.OrganisationID, "result.account.organisationId")
 }
+
+func TestMutationResolver_Login_WithBuiltAccount(t *testing.T) {
+	t.Parallel()
+
+	db := test_db.CreateTestDatabase(t)
+	timeSource := test.FixedTime()
+
+	organisation := builder.Organisation(t, db).Create()
+	account := builder.Account(t, db).
+		WithOrganisation(organisation).
+		WithEmailAddress("new-admin@example.com").
+		WithPassword("myOtherPassword").
+		Create()
+
+	query := test_graphql.GraphqlQuery{
+		Query: loginGQL,
+		Variables: map[string]interface{}{
+			"emailAddress": "new-admin@example.com",
+			"password":     "myOtherPassword",
+		},
+	}
+
+	var result loginResult
+
+	req := test_graphql.NewRequest(t, query)
+	test_graphql.Handle(t, api.ResolverDependencies{DB: db, TimeSource: timeSource}, req, &result)
+	test_graphql.RequireNoErrors(t, result.GraphqlErrors)
+
+	require.Nil(t, result.Data.Result.Error)
+
+	require.NotNil(t, result.Data.Result.Account, "result.account")
+	assert.Equal(t, account.ID, result.Data.Result.Account.ID, "result.account.id")
+	assert.Equal(t, "OrganisationAdministrator", result.Data.Result.Account.Role, "result.account.role")
+	assert.Equal(t, &organisation.ID, result.Data.Result.Account.OrganisationID, "result.account.organisationId")
+}
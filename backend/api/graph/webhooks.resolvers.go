@@ -0,0 +1,160 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.49
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"myvendor.mytld/myproject/backend/api/graph/generated"
+	"myvendor.mytld/myproject/backend/api/graph/helper"
+	"myvendor.mytld/myproject/backend/api/graph/model"
+	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/query"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+// CreateWebhookEndpoint is the resolver for the createWebhookEndpoint field.
+func (r *mutationResolver) CreateWebhookEndpoint(ctx context.Context, organisationID uuid.UUID, url string, eventTypes []model.WebhookEventType) (*model.WebhookEndpoint, error) {
+	cmd, err := command.NewWebhookEndpointCreateCmd(organisationID, url, helper.MapFromWebhookEventTypes(eventTypes))
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.handler.WebhookEndpointCreate(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := r.finder.QueryWebhookEndpoint(ctx, query.WebhookEndpointQuery{
+		WebhookEndpointID: cmd.WebhookEndpointID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return helper.MapToWebhookEndpoint(record), nil
+}
+
+// UpdateWebhookEndpoint is the resolver for the updateWebhookEndpoint field.
+func (r *mutationResolver) UpdateWebhookEndpoint(ctx context.Context, id uuid.UUID, url string, eventTypes []model.WebhookEventType) (*model.WebhookEndpoint, error) {
+	prevRecord, err := r.finder.QueryWebhookEndpoint(ctx, query.WebhookEndpointQuery{
+		WebhookEndpointID: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := command.NewWebhookEndpointUpdateCmd(id, prevRecord.OrganisationID, url, helper.MapFromWebhookEventTypes(eventTypes))
+	err = r.handler.WebhookEndpointUpdate(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := r.finder.QueryWebhookEndpoint(ctx, query.WebhookEndpointQuery{
+		WebhookEndpointID: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return helper.MapToWebhookEndpoint(record), nil
+}
+
+// DeleteWebhookEndpoint is the resolver for the deleteWebhookEndpoint field.
+func (r *mutationResolver) DeleteWebhookEndpoint(ctx context.Context, id uuid.UUID) (*model.WebhookEndpoint, error) {
+	record, err := r.finder.QueryWebhookEndpoint(ctx, query.WebhookEndpointQuery{
+		WebhookEndpointID: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := command.NewWebhookEndpointDeleteCmd(id, record.OrganisationID)
+	err = r.handler.WebhookEndpointDelete(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return helper.MapToWebhookEndpoint(record), nil
+}
+
+// RedeliverWebhook is the resolver for the redeliverWebhook field.
+func (r *mutationResolver) RedeliverWebhook(ctx context.Context, deliveryID uuid.UUID) (*model.WebhookDelivery, error) {
+	prevRecord, err := r.finder.QueryWebhookDelivery(ctx, query.WebhookDeliveryQuery{
+		WebhookDeliveryID: deliveryID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := command.NewWebhookRedeliverCmd(deliveryID, prevRecord.OrganisationID)
+	err = r.handler.WebhookRedeliver(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := r.finder.QueryWebhookDelivery(ctx, query.WebhookDeliveryQuery{
+		WebhookDeliveryID: deliveryID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return helper.MapToWebhookDelivery(record), nil
+}
+
+// WebhookEndpoint is the resolver for the WebhookEndpoint field.
+func (r *queryResolver) WebhookEndpoint(ctx context.Context, id uuid.UUID) (*model.WebhookEndpoint, error) {
+	record, err := r.finder.QueryWebhookEndpoint(ctx, query.WebhookEndpointQuery{
+		WebhookEndpointID: id,
+	})
+	if err == repository.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return helper.MapToWebhookEndpoint(record), nil
+}
+
+// AllWebhookEndpoints is the resolver for the allWebhookEndpoints field.
+func (r *queryResolver) AllWebhookEndpoints(ctx context.Context, page *int, perPage *int, sortField *string, sortOrder *string, organisationID *uuid.UUID) ([]*model.WebhookEndpoint, error) {
+	paging, err := helper.MapToPaging(page, perPage, sortField, sortOrder)
+	if err != nil {
+		return nil, err
+	}
+	records, err := r.finder.QueryWebhookEndpoints(ctx, query.WebhookEndpointsQuery{
+		OrganisationID: organisationID,
+	}, paging)
+	if err != nil {
+		return nil, err
+	}
+	return helper.MapToWebhookEndpoints(records), nil
+}
+
+// Deliveries is the resolver for the deliveries field.
+func (r *webhookEndpointResolver) Deliveries(ctx context.Context, obj *model.WebhookEndpoint, page *int, perPage *int, sortField *string, sortOrder *string, status *model.WebhookDeliveryStatus) ([]*model.WebhookDelivery, error) {
+	// Newest deliveries are the most relevant in a log
+	if sortField == nil {
+		sortField = helper.ToPtr("createdAt")
+		sortOrder = helper.ToPtr(repository.SortOrderDesc)
+	}
+	paging, err := helper.MapToPaging(page, perPage, sortField, sortOrder)
+	if err != nil {
+		return nil, err
+	}
+	records, err := r.finder.QueryWebhookDeliveries(ctx, query.WebhookDeliveriesQuery{
+		OrganisationID:    obj.OrganisationID,
+		WebhookEndpointID: obj.ID,
+		Status:            helper.MapFromWebhookDeliveryStatus(status),
+	}, paging)
+	if err != nil {
+		return nil, err
+	}
+	return helper.MapToWebhookDeliveries(records), nil
+}
+
+// WebhookEndpoint returns generated.WebhookEndpointResolver implementation.
+func (r *Resolver) WebhookEndpoint() generated.WebhookEndpointResolver {
+	return &webhookEndpointResolver{r}
+}
+
+type webhookEndpointResolver struct{ *Resolver }
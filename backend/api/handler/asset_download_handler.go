@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	logger "github.com/apex/log"
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/query"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/security/signedurl"
+	"myvendor.mytld/myproject/backend/storage"
+)
+
+type AssetFinder interface {
+	QueryAssetNotAuthorized(ctx context.Context, query query.AssetQuery) (model.Asset, error)
+}
+
+// NewAssetDownloadHandler serves the content of assets.
+// Requests are authorized by the signature of the URL, which is only handed out to callers that are allowed to view the asset.
+func NewAssetDownloadHandler(assetFinder AssetFinder, store storage.Storage, signer *signedurl.Signer, timeSource types.TimeSource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.FromContext(r.Context()).
+			WithField("handler", "assetDownload")
+
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		now := timeSource.Now()
+		if err := signer.Verify(r.URL, now); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		assetID, err := uuid.FromString(strings.TrimPrefix(r.URL.Path, api.AssetDownloadPathPrefix))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		record, err := assetFinder.QueryAssetNotAuthorized(r.Context(), query.AssetQuery{AssetID: assetID})
+		if errors.Is(err, repository.ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		} else if err != nil {
+			log.WithError(err).Error("Could not fetch asset")
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		etag := `"` + record.Checksum + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.Header().Set("ETag", etag)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		var content io.ReadCloser
+		if r.Method == http.MethodGet {
+			content, err = store.Get(r.Context(), record.StorageKey)
+			if err != nil {
+				log.WithError(err).WithField("assetID", assetID).Error("Could not open asset content")
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+				return
+			}
+			defer content.Close()
+		}
+
+		// Content of an asset never changes, so it can be cached until the URL expires
+		w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d, immutable", signedURLMaxAge(r, now)))
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Content-Type", record.ContentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(record.Size, 10))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": record.Filename}))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+
+		if content == nil {
+			return
+		}
+
+		_, err = io.Copy(w, content)
+		if err != nil {
+			log.WithError(err).WithField("assetID", assetID).Warn("Could not write asset content")
+		}
+	}
+}
+
+// signedURLMaxAge returns the seconds until the (already verified) signed URL of the request expires
+func signedURLMaxAge(r *http.Request, now time.Time) int64 {
+	expires, _ := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64)
+	return max(expires-now.Unix(), 0)
+}
@@ -0,0 +1,109 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/api/handler"
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/query"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/security/signedurl"
+	"myvendor.mytld/myproject/backend/storage/local"
+	"myvendor.mytld/myproject/backend/test"
+)
+
+type assetFinder map[uuid.UUID]model.Asset
+
+func (f assetFinder) QueryAssetNotAuthorized(_ context.Context, query query.AssetQuery) (model.Asset, error) {
+	record, ok := f[query.AssetID]
+	if !ok {
+		return record, repository.ErrNotFound
+	}
+	return record, nil
+}
+
+func TestNewAssetDownloadHandler(t *testing.T) {
+	ctx := context.Background()
+	timeSource := test.FixedTime()
+	now := timeSource.Now()
+
+	assetID := uuid.Must(uuid.FromString("0190e6a4-2a8e-7c6f-8a0a-2d9c1b2a3f4e"))
+	missingAssetID := uuid.Must(uuid.FromString("0190e6a4-2a8e-7c6f-8a0a-2d9c1b2a3f4f"))
+
+	store, err := local.NewStorage(t.TempDir())
+	require.NoError(t, err)
+	err = store.Put(ctx, "assets/system/"+assetID.String(), strings.NewReader("Hello"), 5, "text/plain")
+	require.NoError(t, err)
+
+	finder := assetFinder{
+		assetID: {
+			ID:          assetID,
+			StorageKey:  "assets/system/" + assetID.String(),
+			Filename:    "hello.txt",
+			ContentType: "text/plain",
+			Size:        5,
+			Checksum:    "185f8db32271fe25f561a6fc938b2e264306ec304eda518007d1764826381969",
+		},
+	}
+	signer := signedurl.NewSigner([]byte("secret"))
+
+	h := handler.NewAssetDownloadHandler(finder, store, signer, timeSource)
+
+	t.Run("valid signature", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, signer.Sign(api.AssetDownloadPath(assetID), now.Add(time.Minute)), nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "Hello", rec.Body.String())
+		assert.Equal(t, "text/plain", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "5", rec.Header().Get("Content-Length"))
+		assert.Equal(t, `attachment; filename=hello.txt`, rec.Header().Get("Content-Disposition"))
+		assert.Equal(t, "private, max-age=60, immutable", rec.Header().Get("Cache-Control"))
+		assert.Equal(t, `"185f8db32271fe25f561a6fc938b2e264306ec304eda518007d1764826381969"`, rec.Header().Get("ETag"))
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, signer.Sign(api.AssetDownloadPath(assetID), now.Add(time.Minute)), nil)
+		req.Header.Set("If-None-Match", `"185f8db32271fe25f561a6fc938b2e264306ec304eda518007d1764826381969"`)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, signer.Sign(api.AssetDownloadPath(assetID), now.Add(-time.Second)), nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, api.AssetDownloadPath(assetID), nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, signer.Sign(api.AssetDownloadPath(missingAssetID), now.Add(time.Minute)), nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	logger "github.com/apex/log"
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/domain/query"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/imaging"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/security/signedurl"
+	"myvendor.mytld/myproject/backend/storage"
+)
+
+// NewAssetVariantHandler serves resized variants of image assets.
+// Variants are generated on the first request and cached in the storage.
+//
+// Like downloads, requests are authorized by the signature of the URL instead of the auth context: variants are
+// embedded in img tags, which cannot send the auth token header. Signed URLs are only resolved for assets the
+// caller is allowed to view (see the asset resolvers), they expire after AssetDownloadURLExpiry and are bound
+// to the asset and the variant, so a tampered or expired URL is rejected.
+func NewAssetVariantHandler(assetFinder AssetFinder, store storage.Storage, signer *signedurl.Signer, timeSource types.TimeSource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := logger.FromContext(r.Context()).
+			WithField("handler", "assetVariant")
+
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		now := timeSource.Now()
+		if err := signer.Verify(r.URL, now); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		rawAssetID, variantName, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, api.AssetVariantPathPrefix), "/")
+		assetID, err := uuid.FromString(rawAssetID)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		variant, ok := imaging.VariantByName(variantName)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		record, err := assetFinder.QueryAssetNotAuthorized(r.Context(), query.AssetQuery{AssetID: assetID})
+		if errors.Is(err, repository.ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		} else if err != nil {
+			log.WithError(err).Error("Could not fetch asset")
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if !record.IsImage() {
+			http.NotFound(w, r)
+			return
+		}
+
+		contentType := imaging.VariantContentType(record.ContentType)
+
+		// The variant only depends on the original content, the variant and the content type
+		etag := fmt.Sprintf(`"%s-%s-%s"`, record.Checksum, variant.Name, strings.TrimPrefix(contentType, "image/"))
+
+		if r.Header.Get("If-None-Match") == etag {
+			w.Header().Set("ETag", etag)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		cacheKey := record.VariantStorageKey(variant.Name, contentType)
+		data, err := readStored(r, store, cacheKey)
+		if errors.Is(err, storage.ErrNotFound) {
+			data, err = generateVariant(r, store, record.StorageKey, variant, contentType)
+			if errors.Is(err, imaging.ErrUnsupportedFormat) {
+				http.Error(w, "image format not supported for variants", http.StatusUnsupportedMediaType)
+				return
+			} else if err != nil {
+				log.WithError(err).WithField("assetID", assetID).Error("Could not generate asset variant")
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+				return
+			}
+
+			// Concurrent requests might generate the same variant, the content is the same
+			err = store.Put(r.Context(), cacheKey, bytes.NewReader(data), int64(len(data)), contentType)
+			if err != nil {
+				log.WithError(err).WithField("assetID", assetID).Warn("Could not cache asset variant")
+			}
+		} else if err != nil {
+			log.WithError(err).WithField("assetID", assetID).Error("Could not read cached asset variant")
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d, immutable", signedURLMaxAge(r, now)))
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		_, err = w.Write(data)
+		if err != nil {
+			log.WithError(err).WithField("assetID", assetID).Warn("Could not write asset variant")
+		}
+	}
+}
+
+func generateVariant(r *http.Request, store storage.Storage, storageKey string, variant imaging.Variant, contentType string) ([]byte, error) {
+	original, err := readStored(r, store, storageKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading original")
+	}
+	return imaging.GenerateVariant(original, variant, contentType)
+}
+
+func readStored(r *http.Request, store storage.Storage, key string) ([]byte, error) {
+	content, err := store.Get(r.Context(), key)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	return io.ReadAll(content)
+}
@@ -0,0 +1,146 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/api/handler"
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/imaging"
+	"myvendor.mytld/myproject/backend/security/signedurl"
+	"myvendor.mytld/myproject/backend/storage/local"
+	"myvendor.mytld/myproject/backend/test"
+)
+
+func TestNewAssetVariantHandler(t *testing.T) {
+	ctx := context.Background()
+	timeSource := test.FixedTime()
+	now := timeSource.Now()
+
+	imageAssetID := uuid.Must(uuid.FromString("0190e6a4-2a8e-7c6f-8a0a-2d9c1b2a3f50"))
+	textAssetID := uuid.Must(uuid.FromString("0190e6a4-2a8e-7c6f-8a0a-2d9c1b2a3f51"))
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 400, 200))))
+	imageData := buf.Bytes()
+
+	store, err := local.NewStorage(t.TempDir())
+	require.NoError(t, err)
+	err = store.Put(ctx, "assets/system/"+imageAssetID.String(), bytes.NewReader(imageData), int64(len(imageData)), "image/png")
+	require.NoError(t, err)
+
+	width, height := 400, 200
+	imageAsset := model.Asset{
+		ID:          imageAssetID,
+		StorageKey:  "assets/system/" + imageAssetID.String(),
+		Filename:    "logo.png",
+		ContentType: "image/png",
+		Size:        int64(len(imageData)),
+		Checksum:    "abc123",
+		Width:       &width,
+		Height:      &height,
+	}
+	finder := assetFinder{
+		imageAssetID: imageAsset,
+		textAssetID: {
+			ID:          textAssetID,
+			StorageKey:  "assets/system/" + textAssetID.String(),
+			Filename:    "hello.txt",
+			ContentType: "text/plain",
+			Size:        5,
+		},
+	}
+	signer := signedurl.NewSigner([]byte("secret"))
+
+	h := handler.NewAssetVariantHandler(finder, store, signer, timeSource)
+
+	t.Run("generates and caches variant", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, signer.Sign(api.AssetVariantPath(imageAssetID, "thumbnail"), now.Add(time.Minute)), nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "private, max-age=60, immutable", rec.Header().Get("Cache-Control"))
+		assert.Equal(t, `"abc123-thumbnail-png"`, rec.Header().Get("ETag"))
+
+		info, err := imaging.DecodeInfo(rec.Body)
+		require.NoError(t, err)
+		assert.Equal(t, imaging.Info{Format: "png", Width: 160, Height: 80}, info)
+
+		cached, err := store.Get(ctx, imageAsset.VariantStorageKey("thumbnail", "image/png"))
+		require.NoError(t, err)
+		defer cached.Close()
+		cachedData, err := io.ReadAll(cached)
+		require.NoError(t, err)
+		assert.NotEmpty(t, cachedData)
+	})
+
+	t.Run("serves cached variant", func(t *testing.T) {
+		err := store.Put(ctx, imageAsset.VariantStorageKey("small", "image/png"), strings.NewReader("cached"), 6, "image/png")
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, signer.Sign(api.AssetVariantPath(imageAssetID, "small"), now.Add(time.Minute)), nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "cached", rec.Body.String())
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, signer.Sign(api.AssetVariantPath(imageAssetID, "thumbnail"), now.Add(time.Minute)), nil)
+		req.Header.Set("If-None-Match", `"abc123-thumbnail-png"`)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		path := signer.Sign(api.AssetVariantPath(imageAssetID, "thumbnail"), now.Add(time.Minute))
+		path = strings.Replace(path, "thumbnail", "large", 1)
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("expired signature", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, signer.Sign(api.AssetVariantPath(imageAssetID, "thumbnail"), now.Add(-time.Second)), nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("unknown variant", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, signer.Sign(api.AssetVariantPath(imageAssetID, "huge"), now.Add(time.Minute)), nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("no image", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, signer.Sign(api.AssetVariantPath(textAssetID, "thumbnail"), now.Add(time.Minute)), nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -18,9 +18,14 @@ import (
 
 	"myvendor.mytld/myproject/backend/api"
 	"myvendor.mytld/myproject/backend/api/graph"
+	"myvendor.mytld/myproject/backend/api/graph/complexity"
 	"myvendor.mytld/myproject/backend/api/graph/generated"
+	"myvendor.mytld/myproject/backend/api/graph/helper"
+	"myvendor.mytld/myproject/backend/api/graph/idempotency"
 	graphql_middleware "myvendor.mytld/myproject/backend/api/graph/middleware"
+	"myvendor.mytld/myproject/backend/api/graph/persisted"
 	http_middleware "myvendor.mytld/myproject/backend/api/http/middleware"
+	"myvendor.mytld/myproject/backend/finder"
 )
 
 type Config struct {
@@ -29,30 +34,61 @@ type Config struct {
 	EnableOpenTelemetry  bool
 	DisableRecover       bool
 	WebsocketAllowOrigin string
+	// MaxQueryDepth rejects operations with a deeper nesting of fields, 0 disables the limit
+	MaxQueryDepth int
+	// MaxQueryComplexity rejects operations with a higher computed complexity (see @cost directive), 0 disables the limit
+	MaxQueryComplexity int
+	// PersistedQueryAllowlist restricts operations of non-admin callers to the persisted queries in the manifest if set.
+	// Automatic persisted queries are disabled in that case, since clients must not register arbitrary queries.
+	PersistedQueryAllowlist persisted.Manifest
 	// Constant time duration for sensitive operations (e.g. login / request password reset / perform password reset / registration)
 	SensitiveOperationConstantTime time.Duration
+	// AssetDownloadURLExpiry is the validity of signed download URLs, defaults to DefaultAssetDownloadURLExpiry
+	AssetDownloadURLExpiry time.Duration
+	// IdempotencyKeyRetention is the time responses of mutations with an idempotency key are stored, defaults to DefaultIdempotencyKeyRetention
+	IdempotencyKeyRetention time.Duration
 }
 
+const DefaultAssetDownloadURLExpiry = 15 * time.Minute
+
+const DefaultIdempotencyKeyRetention = 24 * time.Hour
+
 const (
 	requestVariablesPrefix = "gql.request.variables"
 )
 
 func NewGraphqlHandler(deps api.ResolverDependencies, handlerConfig Config) http.Handler {
+	assetDownloadURLExpiry := handlerConfig.AssetDownloadURLExpiry
+	if assetDownloadURLExpiry == 0 {
+		assetDownloadURLExpiry = DefaultAssetDownloadURLExpiry
+	}
+	idempotencyKeyRetention := handlerConfig.IdempotencyKeyRetention
+	if idempotencyKeyRetention == 0 {
+		idempotencyKeyRetention = DefaultIdempotencyKeyRetention
+	}
+
 	config := generated.Config{
 		Resolvers: graph.NewResolver(deps, api.ResolverConfig{
 			SensitiveOperationConstantTime: handlerConfig.SensitiveOperationConstantTime,
+			AssetDownloadURLExpiry:         assetDownloadURLExpiry,
 		}),
 		Directives: generated.DirectiveRoot{
 			// No op implementation, will be checked in middleware
 			BypassAuthentication: func(ctx context.Context, _ any, next graphql.Resolver) (res any, err error) {
 				return next(ctx)
 			},
+			// No op implementation, only used for complexity calculation
+			Cost: func(ctx context.Context, _ any, next graphql.Resolver, _ *int, _ []string) (res any, err error) {
+				return next(ctx)
+			},
 		},
 	}
 	exec := generated.NewExecutableSchema(config)
 	srv := newDefaultServer(exec, handlerConfig)
 	srv.SetErrorPresenter(ErrorPresenter)
 
+	srv.Use(complexity.NewLimit(handlerConfig.MaxQueryDepth, handlerConfig.MaxQueryComplexity, helper.DefaultPerPage, deps.MeterProvider))
+
 	if handlerConfig.EnableOpenTelemetry {
 		srv.Use(otelgqlgen.Middleware(
 			otelgqlgen.WithRequestVariablesAttributesBuilder(
@@ -77,6 +113,15 @@ func NewGraphqlHandler(deps api.ResolverDependencies, handlerConfig Config) http
 		srv.AroundFields(graphql_middleware.LoggerFieldMiddleware)
 	}
 
+	srv.Use(idempotency.Extension{
+		DB:         deps.DB,
+		TimeSource: deps.TimeSource,
+		Retention:  idempotencyKeyRetention,
+	})
+
+	srv.AroundOperations(graphql_middleware.ReadYourWritesOperationMiddleware)
+	srv.AroundOperations(graphql_middleware.LoadersOperationMiddleware(finder.NewFinder(deps.DB, deps.TimeSource, finder.WithReplica(deps.Replica))))
+
 	srv.AroundFields(graphql_middleware.RequireAuthenticationFieldMiddleware)
 	srv.AroundFields(graphql_middleware.SentryGraphqlMiddleware)
 
@@ -119,9 +164,15 @@ func newDefaultServer(es graphql.ExecutableSchema, handlerConfig Config) *graphq
 	srv.SetQueryCache(lru.New(1000))
 
 	srv.Use(extension.Introspection{})
-	srv.Use(extension.AutomaticPersistedQuery{
-		Cache: lru.New(100),
-	})
+	if handlerConfig.PersistedQueryAllowlist != nil {
+		srv.Use(persisted.Allowlist{
+			Manifest: handlerConfig.PersistedQueryAllowlist,
+		})
+	} else {
+		srv.Use(extension.AutomaticPersistedQuery{
+			Cache: lru.New(100),
+		})
+	}
 
 	return srv
 }
@@ -0,0 +1,162 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/api/graph/persisted"
+	"myvendor.mytld/myproject/backend/api/handler"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/security/authentication"
+)
+
+type graphqlResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+	Extensions map[string]any `json:"extensions"`
+}
+
+func postGraphql(t *testing.T, h http.Handler, query string) (int, graphqlResponse) {
+	t.Helper()
+
+	return postGraphqlParams(t, h, authentication.AuthContext{}, map[string]any{"query": query})
+}
+
+func postGraphqlParams(t *testing.T, h http.Handler, authCtx authentication.AuthContext, params map[string]any) (int, graphqlResponse) {
+	t.Helper()
+
+	body, err := json.Marshal(params)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(authentication.WithAuthContext(req.Context(), authCtx))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var res graphqlResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	return rec.Code, res
+}
+
+func TestNewGraphqlHandler_ComplexityLimit(t *testing.T) {
+	h := handler.NewGraphqlHandler(api.ResolverDependencies{}, handler.Config{
+		DisableRecover:     true,
+		MaxQueryDepth:      3,
+		MaxQueryComplexity: 100,
+	})
+
+	t.Run("within limits", func(t *testing.T) {
+		code, res := postGraphql(t, h, `{ echo(hello: "world") }`)
+
+		require.Equal(t, http.StatusOK, code)
+		require.Empty(t, res.Errors)
+		assert.Equal(t, "Hello, world", res.Data["echo"])
+		assert.Equal(t, map[string]any{
+			"complexity":    float64(1),
+			"depth":         float64(1),
+			"maxComplexity": float64(100),
+			"maxDepth":      float64(3),
+		}, res.Extensions["complexity"])
+	})
+
+	t.Run("exceeding depth", func(t *testing.T) {
+		_, res := postGraphql(t, h, `{ allOrganisations(perPage: 1) { accounts { organisation { accounts { id } } } } }`)
+
+		require.Len(t, res.Errors, 1)
+		assert.Equal(t, "depthLimitExceeded", res.Errors[0].Extensions["type"])
+		assert.Nil(t, res.Data)
+	})
+
+	t.Run("exceeding complexity", func(t *testing.T) {
+		_, res := postGraphql(t, h, `{ allAccounts(perPage: 1000) { id emailAddress } }`)
+
+		require.Len(t, res.Errors, 1)
+		assert.Equal(t, "complexityLimitExceeded", res.Errors[0].Extensions["type"])
+		assert.Equal(t, float64(2001), res.Errors[0].Extensions["complexity"])
+	})
+}
+
+func TestNewGraphqlHandler_PersistedQueryAllowlist(t *testing.T) {
+	allowedQuery := `{ echo(hello: "persisted") }`
+	allowedHash := persisted.Hash(allowedQuery)
+
+	h := handler.NewGraphqlHandler(api.ResolverDependencies{}, handler.Config{
+		DisableRecover: true,
+		PersistedQueryAllowlist: persisted.Manifest{
+			allowedHash: allowedQuery,
+		},
+	})
+
+	sysAdminAuthCtx := authentication.AuthContext{
+		Authenticated: true,
+		Role:          types.RoleSystemAdministrator,
+	}
+
+	t.Run("persisted query by hash", func(t *testing.T) {
+		_, res := postGraphqlParams(t, h, authentication.AuthContext{}, map[string]any{
+			"extensions": map[string]any{
+				"persistedQuery": map[string]any{
+					"version":    1,
+					"sha256Hash": allowedHash,
+				},
+			},
+		})
+
+		require.Empty(t, res.Errors)
+		assert.Equal(t, "Hello, persisted", res.Data["echo"])
+	})
+
+	t.Run("persisted query by document", func(t *testing.T) {
+		_, res := postGraphqlParams(t, h, authentication.AuthContext{}, map[string]any{
+			"query": allowedQuery,
+		})
+
+		require.Empty(t, res.Errors)
+		assert.Equal(t, "Hello, persisted", res.Data["echo"])
+	})
+
+	t.Run("unknown hash", func(t *testing.T) {
+		_, res := postGraphqlParams(t, h, sysAdminAuthCtx, map[string]any{
+			"extensions": map[string]any{
+				"persistedQuery": map[string]any{
+					"version":    1,
+					"sha256Hash": persisted.Hash(`{ echo(hello: "other") }`),
+				},
+			},
+		})
+
+		require.Len(t, res.Errors, 1)
+		assert.Equal(t, "persistedQueryNotFound", res.Errors[0].Extensions["type"])
+	})
+
+	t.Run("not allowlisted query", func(t *testing.T) {
+		_, res := postGraphqlParams(t, h, authentication.AuthContext{}, map[string]any{
+			"query": `{ echo(hello: "other") }`,
+		})
+
+		require.Len(t, res.Errors, 1)
+		assert.Equal(t, "operationNotAllowlisted", res.Errors[0].Extensions["type"])
+		assert.Nil(t, res.Data)
+	})
+
+	t.Run("not allowlisted query by SystemAdministrator", func(t *testing.T) {
+		_, res := postGraphqlParams(t, h, sysAdminAuthCtx, map[string]any{
+			"query": `{ echo(hello: "other") }`,
+		})
+
+		require.Empty(t, res.Errors)
+		assert.Equal(t, "Hello, other", res.Data["echo"])
+	})
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+
+	apexlogutils_middleware "github.com/networkteam/apexlogutils/middleware"
+
+	"myvendor.mytld/myproject/backend/persistence/audit"
+)
+
+// AuditRequestMetadataMiddleware sets the request ID and client IP address for entries of the audit log.
+// It must run after proxy headers are handled, so the remote address is the one of the client.
+func AuditRequestMetadataMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ipAddress, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			// The remote address could be set without a port by proxy headers
+			ipAddress = r.RemoteAddr
+		}
+
+		ctx := audit.WithRequestMetadata(r.Context(), audit.RequestMetadata{
+			RequestID: apexlogutils_middleware.GetReqID(r.Context()),
+			IPAddress: ipAddress,
+		})
+		r = r.WithContext(ctx)
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -14,9 +14,7 @@ import (
 )
 
 func TestRefreshTokensMiddleware(t *testing.T) {
-	db := test_db.CreateTestDatabase(t)
-
-	test_db.ExecFixtures(t, db, "base")
+	db := test_db.CreateTestDatabaseWithFixtures(t, "base")
 
 	timeSource := test.FixedTime()
 
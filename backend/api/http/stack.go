@@ -25,8 +25,10 @@ func MiddlewareStackWithAuth(deps api.ResolverDependencies, h http.Handler) http
 // MiddlewareStackBasic combines all necessary middlewares for request processing and logging (without authentication)
 func MiddlewareStackBasic(h http.Handler) http.Handler {
 	return handlers.ProxyHeaders(
-		http_middleware.SentryMiddleware(
-			h,
+		http_middleware.AuditRequestMetadataMiddleware(
+			http_middleware.SentryMiddleware(
+				h,
+			),
 		),
 	)
 }
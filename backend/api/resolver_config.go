@@ -5,4 +5,6 @@ import "time"
 type ResolverConfig struct {
 	// Constant minimal time duration for sensitive operations (e.g. login / request password reset / perform password reset / registration)
 	SensitiveOperationConstantTime time.Duration
+	// Validity of signed download URLs for assets
+	AssetDownloadURLExpiry time.Duration
 }
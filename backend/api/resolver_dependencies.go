@@ -7,7 +7,11 @@ import (
 
 	"myvendor.mytld/myproject/backend/domain"
 	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/finder"
 	"myvendor.mytld/myproject/backend/mail"
+	"myvendor.mytld/myproject/backend/persistence/notification"
+	"myvendor.mytld/myproject/backend/security/signedurl"
+	"myvendor.mytld/myproject/backend/storage"
 )
 
 // ResolverDependencies provides common dependencies for api resolvers
@@ -17,4 +21,12 @@ type ResolverDependencies struct {
 	TimeSource    types.TimeSource
 	MeterProvider metric.MeterProvider
 	Mailer        *mail.Mailer
+	// Notifications receives change notifications for subscriptions
+	Notifications *notification.Listener
+	// Storage keeps the content of uploaded files
+	Storage storage.Storage
+	// AssetURLSigner signs download URLs of assets
+	AssetURLSigner *signedurl.Signer
+	// Replica is an optional read replica for queries
+	Replica *finder.Replica
 }
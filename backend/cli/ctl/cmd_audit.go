@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+	"github.com/urfave/cli/v2"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+// auditExportBatchSize is the number of entries fetched at once, so large logs are not loaded into memory
+const auditExportBatchSize = 1000
+
+func newAuditCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "audit",
+		Usage: "Inspect the audit log of administrative commands",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "export",
+				Usage: "Export audit entries as JSON lines (oldest first)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "organisation-id",
+						Usage: "Filter by organisation",
+					},
+					&cli.StringFlag{
+						Name:  "actor-account-id",
+						Usage: "Filter by the account that executed the command",
+					},
+					&cli.StringFlag{
+						Name:  "command-type",
+						Usage: "Filter by the name of the command (e.g. AccountUpdate)",
+					},
+					&cli.TimestampFlag{
+						Name:   "from",
+						Usage:  "Start of the time range (inclusive)",
+						Layout: time.RFC3339,
+					},
+					&cli.TimestampFlag{
+						Name:   "to",
+						Usage:  "End of the time range (exclusive)",
+						Layout: time.RFC3339,
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Write to a file instead of stdout",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					filter, err := auditEntriesFilterFromContext(c)
+					if err != nil {
+						return err
+					}
+
+					db, err := connectDatabase(c)
+					if err != nil {
+						return err
+					}
+
+					var out io.Writer = os.Stdout
+					if output := c.String("output"); output != "" {
+						f, err := os.Create(output)
+						if err != nil {
+							return errors.Wrap(err, "creating output file")
+						}
+						defer f.Close()
+						out = f
+					}
+					w := bufio.NewWriter(out)
+					enc := json.NewEncoder(w)
+
+					// Entry IDs are time ordered and unique, so paging by ID is stable while new entries are appended
+					for page := 0; ; page++ {
+						entries, err := repository.FindAllAuditEntries(c.Context, db, filter,
+							repository.WithLimit(auditExportBatchSize),
+							repository.WithOffset(page*auditExportBatchSize),
+							repository.WithSort("id", repository.SortOrderAsc),
+						)
+						if err != nil {
+							return errors.Wrap(err, "finding audit entries")
+						}
+
+						for _, entry := range entries {
+							err = enc.Encode(newExportedAuditEntry(entry))
+							if err != nil {
+								return errors.Wrap(err, "encoding audit entry")
+							}
+						}
+
+						if len(entries) < auditExportBatchSize {
+							break
+						}
+					}
+
+					return w.Flush()
+				},
+			},
+		},
+	}
+}
+
+func auditEntriesFilterFromContext(c *cli.Context) (repository.AuditEntriesFilter, error) {
+	var filter repository.AuditEntriesFilter
+
+	parseID := func(name string) (*uuid.UUID, error) {
+		if !c.IsSet(name) {
+			return nil, nil
+		}
+		id, err := uuid.FromString(c.String(name))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s", name)
+		}
+		return &id, nil
+	}
+
+	var err error
+	filter.OrganisationID, err = parseID("organisation-id")
+	if err != nil {
+		return filter, err
+	}
+	filter.ActorAccountID, err = parseID("actor-account-id")
+	if err != nil {
+		return filter, err
+	}
+	if c.IsSet("command-type") {
+		commandType := c.String("command-type")
+		filter.CommandType = &commandType
+	}
+	filter.OccurredFrom = c.Timestamp("from")
+	filter.OccurredTo = c.Timestamp("to")
+
+	return filter, nil
+}
+
+type exportedAuditEntry struct {
+	ID                    uuid.UUID       `json:"id"`
+	OccurredAt            time.Time       `json:"occurredAt"`
+	ActorAccountID        uuid.NullUUID   `json:"actorAccountId"`
+	ImpersonatorAccountID uuid.NullUUID   `json:"impersonatorAccountId"`
+	OrganisationID        uuid.NullUUID   `json:"organisationId"`
+	CommandType           string          `json:"commandType"`
+	TargetIDs             []uuid.UUID     `json:"targetIds"`
+	Changes               json.RawMessage `json:"changes"`
+	RequestID             *string         `json:"requestId"`
+	IPAddress             *string         `json:"ipAddress"`
+}
+
+func newExportedAuditEntry(entry model.AuditEntry) exportedAuditEntry {
+	return exportedAuditEntry{
+		ID:                    entry.ID,
+		OccurredAt:            entry.OccurredAt,
+		ActorAccountID:        entry.ActorAccountID,
+		ImpersonatorAccountID: entry.ImpersonatorAccountID,
+		OrganisationID:        entry.OrganisationID,
+		CommandType:           entry.CommandType,
+		TargetIDs:             entry.TargetIDs,
+		Changes:               entry.Changes,
+		RequestID:             entry.RequestID,
+		IPAddress:             entry.IPAddress,
+	}
+}
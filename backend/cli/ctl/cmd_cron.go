@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/urfave/cli/v2"
+
+	"myvendor.mytld/myproject/backend/persistence/scheduler"
+)
+
+func newCronCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "cron",
+		Usage: "Inspect and run cron jobs",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List cron jobs with their last and next run",
+				Action: func(c *cli.Context) error {
+					s, err := buildSchedulerFromContext(c)
+					if err != nil {
+						return err
+					}
+
+					jobs, err := s.List(c.Context)
+					if err != nil {
+						return err
+					}
+
+					for _, job := range jobs {
+						lastRunAt, lastStatus, lastError := "-", "-", ""
+						if job.LastRunAt != nil {
+							lastRunAt = job.LastRunAt.Format(time.RFC3339)
+						}
+						if job.LastStatus != nil {
+							lastStatus = string(*job.LastStatus)
+						}
+						if job.LastError != nil {
+							lastError = *job.LastError
+						}
+						fmt.Printf("%s\t%s\t%s\t%s\t%s\t%s\n", job.Name, job.Schedule, job.NextRunAt.Format(time.RFC3339), lastRunAt, lastStatus, lastError) //nolint:forbidigo
+					}
+
+					return nil
+				},
+			},
+			{
+				Name:      "run",
+				Usage:     "Run a cron job now, without changing its next scheduled run",
+				ArgsUsage: "<name>",
+				Action: func(c *cli.Context) error {
+					name := c.Args().First()
+					if name == "" {
+						return errors.New("missing cron job name")
+					}
+
+					s, err := buildSchedulerFromContext(c)
+					if err != nil {
+						return err
+					}
+
+					err = s.Trigger(c.Context, name)
+					if errors.Is(err, scheduler.ErrUnknownJob) {
+						return errors.Errorf("unknown cron job %q", name)
+					} else if err != nil {
+						return errors.Wrap(err, "running cron job")
+					}
+
+					fmt.Printf("Ran cron job %s\n", name) //nolint:forbidigo
+
+					return nil
+				},
+			},
+		},
+	}
+}
+
+func buildSchedulerFromContext(c *cli.Context) (*scheduler.Scheduler, error) {
+	db, err := connectDatabase(c)
+	if err != nil {
+		return nil, err
+	}
+
+	timeSource, err := newCurrentTimeSource(c)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return newScheduler(db, timeSource, config)
+}
@@ -4,11 +4,13 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/apex/log"
 	"github.com/friendsofgo/errors"
 	"github.com/urfave/cli/v2"
 
+	"myvendor.mytld/myproject/backend/domain/model"
 	"myvendor.mytld/myproject/backend/persistence/repository"
 	"myvendor.mytld/myproject/backend/test/fixtures"
 )
@@ -30,6 +32,38 @@ func newFixturesCmd() *cli.Command {
 				},
 				Action: fixturesImportAction,
 			},
+			{
+				Name:  "generate",
+				Usage: "Generate realistic fake organisations and accounts, e.g. for testing pagination, search or performance",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  "organisations",
+						Usage: "Number of organisations to generate",
+						Value: 10,
+					},
+					&cli.IntFlag{
+						Name:  "accounts-per-org",
+						Usage: "Number of accounts to generate per organisation",
+						Value: 10,
+					},
+					&cli.Int64Flag{
+						Name:  "seed",
+						Usage: "Seed for random data, the same seed generates the same organisations and accounts",
+						Value: 1,
+					},
+					&cli.TimestampFlag{
+						Name:   "now",
+						Usage:  "Time that generated timestamps (e.g. created at, last login) lie before, a fixed time keeps the data deterministic",
+						Layout: time.RFC3339,
+						Value:  cli.NewTimestamp(defaultGenerateNow),
+					},
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "Truncate the DB before generating data",
+					},
+				},
+				Action: fixturesGenerateAction,
+			},
 		},
 	}
 }
@@ -82,6 +116,94 @@ func fixturesImportAction(c *cli.Context) error {
 	return nil
 }
 
+// generateBatchSize is the number of organisations (with their accounts) that are inserted at once
+const generateBatchSize = 500
+
+// defaultGenerateNow is the default for --now, so the same seed generates the same data at any time
+//
+//nolint:gochecknoglobals
+var defaultGenerateNow = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func fixturesGenerateAction(c *cli.Context) error {
+	organisationCount := c.Int("organisations")
+	accountsPerOrganisation := c.Int("accounts-per-org")
+	if organisationCount < 0 || accountsPerOrganisation < 0 {
+		return errors.New("--organisations and --accounts-per-org must not be negative")
+	}
+
+	config, err := getConfig(c)
+	if err != nil {
+		return err
+	}
+
+	db, err := connectDatabase(c)
+	if err != nil {
+		return err
+	}
+
+	if c.Bool("force") {
+		err = truncateDB(db)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Generating %d organisations with %d accounts each", organisationCount, accountsPerOrganisation)
+
+	generator := fixtures.NewGenerator(c.Int64("seed"), *c.Timestamp("now"), config)
+
+	// COPY needs the underlying connection, the transaction of a batch is started on the same connection
+	conn, err := db.Conn(c.Context)
+	if err != nil {
+		return errors.Wrap(err, "getting connection")
+	}
+	defer conn.Close()
+
+	for offset := 0; offset < organisationCount; offset += generateBatchSize {
+		batchSize := min(generateBatchSize, organisationCount-offset)
+
+		organisations := make([]model.Organisation, 0, batchSize)
+		accounts := make([]model.Account, 0, batchSize*accountsPerOrganisation)
+		for i := 0; i < batchSize; i++ {
+			organisation, err := generator.Organisation()
+			if err != nil {
+				return errors.Wrap(err, "generating organisation")
+			}
+			organisations = append(organisations, organisation)
+
+			for j := 0; j < accountsPerOrganisation; j++ {
+				account, err := generator.Account(organisation)
+				if err != nil {
+					return errors.Wrap(err, "generating account")
+				}
+				accounts = append(accounts, account)
+			}
+		}
+
+		// A batch is inserted completely or not at all
+		err = repository.Transactional(c.Context, conn, func(tx *sql.Tx) error {
+			err := repository.InsertOrganisations(c.Context, tx, organisations)
+			if err != nil {
+				return errors.Wrap(err, "inserting organisations")
+			}
+			err = repository.CopyAccounts(c.Context, conn, accounts)
+			if err != nil {
+				return errors.Wrap(err, "inserting accounts")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		log.Infof("Inserted %d of %d organisations", offset+batchSize, organisationCount)
+	}
+
+	log.Infof("All generated accounts have the password %q", fixtures.DefaultPassword)
+
+	return nil
+}
+
 func truncateDB(db *sql.DB) error {
 	tableNames, err := getTableNames(db)
 	if err != nil {
@@ -98,8 +220,9 @@ func truncateDB(db *sql.DB) error {
 	return nil
 }
 
+// getTableNames returns the tables to truncate, the audit log is append-only and cannot be truncated
 func getTableNames(db *sql.DB) ([]string, error) {
-	rows, err := db.Query("SELECT tablename FROM pg_catalog.pg_tables WHERE schemaname = 'public' AND tablename != 'goose_db_version'")
+	rows, err := db.Query("SELECT tablename FROM pg_catalog.pg_tables WHERE schemaname = 'public' AND tablename NOT IN ('goose_db_version', 'audit_log')")
 	if err != nil {
 		return nil, errors.Wrap(err, "querying tables")
 	}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/persistence/audit"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/test"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+)
+
+func TestTruncateDB(t *testing.T) {
+	ctx := context.Background()
+	db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+
+	err := audit.Record(ctx, db, test.FixedTime().Now(), audit.Entry{CommandType: "AccountCreate"})
+	require.NoError(t, err)
+
+	err = truncateDB(db)
+	require.NoError(t, err)
+
+	accountCount, err := repository.CountAccounts(ctx, db, repository.AccountsFilter{WithDeleted: true})
+	require.NoError(t, err)
+	assert.Equal(t, 0, accountCount)
+
+	// The audit log is append-only and kept
+	auditEntryCount, err := repository.CountAuditEntries(ctx, db, repository.AuditEntriesFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, auditEntryCount)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"database/sql"
+
+	"github.com/apex/log"
+	"github.com/friendsofgo/errors"
+	"github.com/urfave/cli/v2"
+
+	"myvendor.mytld/myproject/backend/api/graph/persisted"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+func newGraphqlCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "graphql",
+		Usage: "Manage the GraphQL API",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "persisted",
+				Usage: "Manage persisted queries for the allowlist mode of the server",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "import",
+						Usage:     "Import persisted queries from a JSON manifest file (object of SHA-256 hashes to documents)",
+						ArgsUsage: "<manifest file>",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "replace",
+								Usage: "Delete all persisted queries that are not in the manifest",
+							},
+						},
+						Action: graphqlPersistedImportAction,
+					},
+				},
+			},
+		},
+	}
+}
+
+func graphqlPersistedImportAction(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return errors.New("expected path to manifest file as argument")
+	}
+
+	manifest, err := persisted.LoadManifestFile(c.Args().First())
+	if err != nil {
+		return err
+	}
+
+	db, err := connectDatabase(c)
+	if err != nil {
+		return err
+	}
+
+	err = repository.Transactional(c.Context, db, func(tx *sql.Tx) error {
+		if c.Bool("replace") {
+			if err := repository.DeleteAllPersistedQueries(c.Context, tx); err != nil {
+				return errors.Wrap(err, "deleting persisted queries")
+			}
+		}
+
+		for hash, document := range manifest {
+			hash, document := hash, document
+			err := repository.InsertPersistedQuery(c.Context, tx, repository.PersistedQueryChangeSet{
+				Hash:     &hash,
+				Document: &document,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "inserting persisted query %s", hash)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Imported %d persisted queries", len(manifest))
+
+	return nil
+}
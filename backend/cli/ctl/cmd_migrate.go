@@ -1,13 +1,30 @@
 package main
 
 import (
+	"database/sql"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/apex/log"
 	"github.com/friendsofgo/errors"
 	"github.com/pressly/goose/v3"
 	"github.com/urfave/cli/v2"
 
 	"myvendor.mytld/myproject/backend/persistence/migrations"
+	"myvendor.mytld/myproject/backend/persistence/migrationsql"
 )
 
+// migrationsDir is the directory of migrations relative to the backend (the working directory of go run ./cli/ctl)
+const migrationsDir = "persistence/migrations"
+
 func newMigrateCmd() *cli.Command {
 	return &cli.Command{
 		Name:  "migrate",
@@ -28,6 +45,10 @@ func newMigrateCmd() *cli.Command {
 						Name:  "allow-missing",
 						Usage: "Allow migration with missing previous migrations (not recommended for production)",
 					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Print the SQL of pending migrations instead of applying them",
+					},
 				},
 				Action: func(c *cli.Context) error {
 					db, err := connectDatabase(c)
@@ -35,6 +56,10 @@ func newMigrateCmd() *cli.Command {
 						return err
 					}
 
+					if c.Bool("dry-run") {
+						return printPendingMigrations(c, db)
+					}
+
 					var opts []goose.OptionsFunc
 					if c.Bool("allow-missing") {
 						opts = append(opts, goose.WithAllowMissing())
@@ -86,23 +111,96 @@ func newMigrateCmd() *cli.Command {
 			},
 			{
 				Name:      "create",
-				Flags:     []cli.Flag{},
+				Usage:     "Create a Go or SQL migration with the next version",
 				ArgsUsage: "<migration-name>",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "sql",
+						Usage: "Create a SQL migration",
+					},
+					&cli.BoolFlag{
+						Name:  "go",
+						Usage: "Create a Go migration (default)",
+					},
+				},
 				Before: func(c *cli.Context) error {
 					if c.Args().First() == "" {
 						return errors.New("missing migration name")
 					}
+					if c.Bool("sql") && c.Bool("go") {
+						return errors.New("only one of --sql or --go can be set")
+					}
 					return nil
 				},
+				Action: func(c *cli.Context) error {
+					migrationType := "go"
+					if c.Bool("sql") {
+						migrationType = "sql"
+					}
+
+					filename, err := createMigration(migrationsDir, c.Args().First(), migrationType)
+					if err != nil {
+						return errors.Wrap(err, "creating migration")
+					}
+					log.Infof("Created migration %s", filename)
+
+					return nil
+				},
+			},
+			{
+				Name:  "status",
+				Usage: "Show applied and pending migrations",
 				Action: func(c *cli.Context) error {
 					db, err := connectDatabase(c)
 					if err != nil {
 						return err
 					}
 
-					err = goose.Create(db, "persistence/migrations", c.Args().First(), "go")
+					statuses, err := migrationStatus(c, db)
 					if err != nil {
-						return errors.Wrap(err, "creating migration")
+						return err
+					}
+
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					fmt.Fprintln(w, "VERSION\tFILE\tTYPE\tAPPLIED AT")
+					for _, status := range statuses {
+						appliedAt := "pending"
+						if status.State == goose.StateApplied {
+							appliedAt = status.AppliedAt.Local().Format(time.RFC3339)
+						}
+						fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Source.Version, path.Base(status.Source.Path), status.Source.Type, appliedAt)
+					}
+					return w.Flush()
+				},
+			},
+			{
+				Name:  "lint",
+				Usage: "Check migrations for statements that lock tables or break running instances",
+				Flags: []cli.Flag{
+					&cli.Int64Flag{
+						Name:  "since",
+						Usage: "Only check migrations after this version (e.g. the version deployed to production)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					parsed, err := migrationsql.ParseAll(migrations.FS)
+					if err != nil {
+						return errors.Wrap(err, "parsing migrations")
+					}
+
+					var findings []migrationsql.Finding
+					for _, migration := range parsed {
+						if migration.Version <= c.Int64("since") {
+							continue
+						}
+						findings = append(findings, migrationsql.Lint(migration)...)
+					}
+
+					for _, finding := range findings {
+						fmt.Printf("%s: %s: %s\n%s\n\n", finding.Filename, finding.Rule, finding.Message, indent(finding.Statement)) //nolint:forbidigo
+					}
+					if len(findings) > 0 {
+						return errors.Errorf("found %d dangerous statements, add \"-- lint:ignore <rule>\" to a statement if it is safe", len(findings))
 					}
 
 					return nil
@@ -111,3 +209,139 @@ func newMigrateCmd() *cli.Command {
 		},
 	}
 }
+
+func migrationStatus(c *cli.Context, db *sql.DB) ([]*goose.MigrationStatus, error) {
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, migrations.FS)
+	if err != nil {
+		return nil, errors.Wrap(err, "initializing migrations")
+	}
+	statuses, err := provider.Status(c.Context)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting migration status")
+	}
+	return statuses, nil
+}
+
+// printPendingMigrations prints the up statements of pending migrations up to the version flag.
+// Statements of Go migrations are taken from string literals, so SQL that is built in Go code is missing.
+func printPendingMigrations(c *cli.Context, db *sql.DB) error {
+	statuses, err := migrationStatus(c, db)
+	if err != nil {
+		return err
+	}
+	parsed, err := migrationsql.ParseAll(migrations.FS)
+	if err != nil {
+		return errors.Wrap(err, "parsing migrations")
+	}
+	migrationsByVersion := make(map[int64]migrationsql.Migration, len(parsed))
+	for _, migration := range parsed {
+		migrationsByVersion[migration.Version] = migration
+	}
+
+	maxVersion := int64(math.MaxInt64)
+	if c.IsSet("version") {
+		maxVersion = c.Int64("version")
+	}
+
+	for _, status := range statuses {
+		if status.State != goose.StatePending || status.Source.Version > maxVersion {
+			continue
+		}
+		migration := migrationsByVersion[status.Source.Version]
+
+		fmt.Printf("-- %s", path.Base(status.Source.Path)) //nolint:forbidigo
+		if migration.NoTransaction {
+			fmt.Print(" (no transaction)") //nolint:forbidigo
+		}
+		if migration.Go() {
+			fmt.Print(" (Go migration, statements built in code are not shown)") //nolint:forbidigo
+		}
+		fmt.Println() //nolint:forbidigo
+		for _, statement := range migration.Up {
+			fmt.Printf("%s\n\n", statement) //nolint:forbidigo
+		}
+	}
+
+	return nil
+}
+
+func indent(s string) string {
+	return "    " + strings.ReplaceAll(s, "\n", "\n    ")
+}
+
+//nolint:gochecknoglobals
+var (
+	migrationNameSeparatorRegexp = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+	goMigrationTemplate = template.Must(template.New("go").Parse(`package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(up{{.FuncName}}, down{{.FuncName}})
+}
+
+func up{{.FuncName}}(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, ` + "``" + `)
+	return err
+}
+
+func down{{.FuncName}}(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, ` + "``" + `)
+	return err
+}
+`))
+
+	sqlMigrationTemplate = template.Must(template.New("sql").Parse(`-- +goose Up
+
+-- +goose Down
+`))
+)
+
+// createMigration creates a migration file in dir with the next version after the existing migrations (e.g. 015_add_column.sql)
+func createMigration(dir, name, migrationType string) (filename string, err error) {
+	words := migrationNameSeparatorRegexp.Split(strings.Trim(migrationNameSeparatorRegexp.ReplaceAllString(name, " "), " "), -1)
+	if len(words) == 0 || words[0] == "" {
+		return "", errors.Errorf("invalid migration name %q", name)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", errors.Wrap(err, "reading migrations")
+	}
+	var lastVersion int64
+	for _, entry := range entries {
+		if version, err := goose.NumericComponent(entry.Name()); err == nil && version > lastVersion {
+			lastVersion = version
+		}
+	}
+
+	var funcName strings.Builder
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+		funcName.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+
+	tmpl := goMigrationTemplate
+	if migrationType == "sql" {
+		tmpl = sqlMigrationTemplate
+	}
+
+	filename = filepath.Join(dir, fmt.Sprintf("%03d_%s.%s", lastVersion+1, strings.Join(words, "_"), migrationType))
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	err = tmpl.Execute(f, struct{ FuncName string }{FuncName: funcName.String()})
+	if err != nil {
+		return "", errors.Wrap(err, "writing migration")
+	}
+	return filename, nil
+}
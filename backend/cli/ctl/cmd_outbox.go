@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/friendsofgo/errors"
+	"github.com/urfave/cli/v2"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+func newOutboxCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "outbox",
+		Usage: "Inspect and manage domain events in the outbox",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List outbox events",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "status",
+						Usage: "Filter by status (pending, delivered, dead)",
+					},
+					&cli.IntFlag{
+						Name:  "per-page",
+						Value: 100,
+					},
+					&cli.IntFlag{
+						Name:  "page",
+						Value: 0,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					db, err := connectDatabase(c)
+					if err != nil {
+						return err
+					}
+
+					var filter repository.OutboxEventsFilter
+					if c.IsSet("status") {
+						status := model.OutboxEventStatus(c.String("status"))
+						switch status {
+						case model.OutboxEventStatusPending, model.OutboxEventStatusDelivered, model.OutboxEventStatusDead:
+						default:
+							return errors.Errorf("invalid status %q", status)
+						}
+						filter.Status = &status
+					}
+
+					page := c.Int("page")
+					perPage := c.Int("per-page")
+					events, err := repository.FindAllOutboxEvents(c.Context, db, filter,
+						repository.WithLimit(perPage),
+						repository.WithOffset(page*perPage),
+						repository.WithSort("occurredAt", repository.SortOrderDesc),
+					)
+					if err != nil {
+						return errors.Wrap(err, "finding outbox events")
+					}
+
+					for _, e := range events {
+						var lastError string
+						if e.LastError != nil {
+							lastError = *e.LastError
+						}
+						fmt.Printf("%s\t%s\t%s\t%s\t%d\t%s\n", e.ID, e.OccurredAt.Format("2006-01-02T15:04:05Z07:00"), e.EventType, e.Status, e.Attempts, lastError) //nolint:forbidigo
+					}
+
+					return nil
+				},
+			},
+			{
+				Name:  "requeue",
+				Usage: "Requeue dead outbox events for delivery",
+				Action: func(c *cli.Context) error {
+					db, err := connectDatabase(c)
+					if err != nil {
+						return err
+					}
+
+					timeSource, err := newCurrentTimeSource(c)
+					if err != nil {
+						return err
+					}
+
+					n, err := repository.RequeueDeadOutboxEvents(c.Context, db, timeSource.Now())
+					if err != nil {
+						return errors.Wrap(err, "requeueing outbox events")
+					}
+
+					fmt.Printf("Requeued %d events\n", n) //nolint:forbidigo
+
+					return nil
+				},
+			},
+		},
+	}
+}
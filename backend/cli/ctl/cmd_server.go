@@ -21,14 +21,23 @@ import (
 	"github.com/networkteam/apexlogutils/httplog"
 	apexlogutils_middleware "github.com/networkteam/apexlogutils/middleware"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"github.com/robfig/cron"
 	"github.com/urfave/cli/v2"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
 
 	"myvendor.mytld/myproject/backend/api"
+	"myvendor.mytld/myproject/backend/api/graph/persisted"
 	api_handler "myvendor.mytld/myproject/backend/api/handler"
 	http_api "myvendor.mytld/myproject/backend/api/http"
+	"myvendor.mytld/myproject/backend/finder"
+	"myvendor.mytld/myproject/backend/persistence/notification"
+	"myvendor.mytld/myproject/backend/persistence/outbox"
+	security_helper "myvendor.mytld/myproject/backend/security/helper"
+	"myvendor.mytld/myproject/backend/security/signedurl"
+	"myvendor.mytld/myproject/backend/storage"
+	"myvendor.mytld/myproject/backend/storage/local"
+	"myvendor.mytld/myproject/backend/storage/s3"
+	"myvendor.mytld/myproject/backend/webhook"
 )
 
 const shutdownTimeout = 5 * time.Second
@@ -37,7 +46,7 @@ func newServerCmd() *cli.Command {
 	return &cli.Command{
 		Name:  "server",
 		Usage: "Run the backend server",
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.StringFlag{
 				Name:    "address",
 				Usage:   "Listen on this address",
@@ -49,54 +58,112 @@ func newServerCmd() *cli.Command {
 				Usage:   "Allow websocket connections from this origin, if empty only the origin matching the host of the request is allowed",
 				EnvVars: []string{"BACKEND_WEBSOCKET_ALLOW_ORIGIN"},
 			},
-			&cli.BoolFlag{
-				Name:  "playground",
-				Usage: "Enable GraphQL playground",
-				Value: false,
+			&cli.IntFlag{
+				Name:    "graphql-max-depth",
+				Usage:   "Reject GraphQL operations with a deeper nesting of fields (0 disables the limit)",
+				EnvVars: []string{"BACKEND_GRAPHQL_MAX_DEPTH"},
+				Value:   15,
 			},
-			&cli.BoolFlag{
-				Name:    "disable-ansi",
-				Usage:   "Force disable ANSI log output and output log in logfmt format",
-				EnvVars: []string{"BACKEND_DISABLE_ANSI"},
-				Value:   false,
+			&cli.IntFlag{
+				Name:    "graphql-max-complexity",
+				Usage:   "Reject GraphQL operations with a higher computed complexity (0 disables the limit)",
+				EnvVars: []string{"BACKEND_GRAPHQL_MAX_COMPLEXITY"},
+				Value:   10000,
 			},
-			&cli.BoolFlag{
-				Name:    "force-ansi",
-				Usage:   "Force enable ANSI log output",
-				EnvVars: []string{"BACKEND_FORCE_ANSI"},
-				Value:   false,
+			&cli.StringFlag{
+				Name:    "persisted-query-mode",
+				Usage:   "Mode for persisted queries: \"apq\" accepts any query with automatic persisted queries, \"allowlist\" rejects operations that are not persisted for non-admin callers",
+				EnvVars: []string{"BACKEND_PERSISTED_QUERY_MODE"},
+				Value:   persistedQueryModeAPQ,
 			},
-
 			&cli.StringFlag{
-				Name:    "sentry-dsn",
-				Usage:   "Sentry DSN (will be disabled if empty)",
-				EnvVars: []string{"SENTRY_DSN"},
+				Name:    "persisted-query-manifest",
+				Usage:   "Load the persisted query manifest for allowlist mode from this JSON file instead of the database",
+				EnvVars: []string{"BACKEND_PERSISTED_QUERY_MANIFEST"},
 			},
 			&cli.StringFlag{
-				Name:    "sentry-environment",
-				Usage:   "Sentry environment",
-				EnvVars: []string{"SENTRY_ENVIRONMENT"},
-				Value:   "development",
+				Name:    "storage-backend",
+				Usage:   "Storage backend for uploaded files: \"local\" or \"s3\"",
+				EnvVars: []string{"BACKEND_STORAGE_BACKEND"},
+				Value:   storageBackendLocal,
 			},
 			&cli.StringFlag{
-				Name:    "sentry-release",
-				Usage:   "Release version for Sentry",
-				EnvVars: []string{"SENTRY_RELEASE"},
+				Name:    "storage-local-path",
+				Usage:   "Directory for uploaded files with the local storage backend",
+				EnvVars: []string{"BACKEND_STORAGE_LOCAL_PATH"},
+				Value:   "./var/storage",
+			},
+			&cli.StringFlag{
+				Name:    "storage-s3-endpoint",
+				Usage:   "Endpoint URL of the S3-compatible storage",
+				EnvVars: []string{"BACKEND_STORAGE_S3_ENDPOINT"},
+			},
+			&cli.StringFlag{
+				Name:    "storage-s3-region",
+				Usage:   "Region of the S3-compatible storage",
+				EnvVars: []string{"BACKEND_STORAGE_S3_REGION"},
+				Value:   "us-east-1",
+			},
+			&cli.StringFlag{
+				Name:    "storage-s3-bucket",
+				Usage:   "Bucket of the S3-compatible storage",
+				EnvVars: []string{"BACKEND_STORAGE_S3_BUCKET"},
+			},
+			&cli.StringFlag{
+				Name:    "storage-s3-prefix",
+				Usage:   "Prefix for object keys in the bucket",
+				EnvVars: []string{"BACKEND_STORAGE_S3_PREFIX"},
+			},
+			&cli.StringFlag{
+				Name:    "storage-s3-access-key-id",
+				Usage:   "Access key ID of the S3-compatible storage",
+				EnvVars: []string{"BACKEND_STORAGE_S3_ACCESS_KEY_ID"},
+			},
+			&cli.StringFlag{
+				Name:    "storage-s3-secret-access-key",
+				Usage:   "Secret access key of the S3-compatible storage",
+				EnvVars: []string{"BACKEND_STORAGE_S3_SECRET_ACCESS_KEY"},
 			},
-
 			&cli.BoolFlag{
-				Name:    "open-telemetry-enabled",
-				Usage:   "Enable open telemetry",
-				EnvVars: []string{"OPEN_TELEMETRY_ENABLED"},
+				Name:    "storage-s3-path-style",
+				Usage:   "Address the bucket in the path instead of the host name (e.g. for MinIO)",
+				EnvVars: []string{"BACKEND_STORAGE_S3_PATH_STYLE"},
+			},
+			&cli.StringFlag{
+				Name:    "asset-url-secret",
+				Usage:   "Secret for signing asset download URLs, must be the same for all instances (a random secret is used if empty)",
+				EnvVars: []string{"BACKEND_ASSET_URL_SECRET"},
+			},
+			&cli.DurationFlag{
+				Name:    "asset-url-expiry",
+				Usage:   "Validity of signed asset download URLs",
+				EnvVars: []string{"BACKEND_ASSET_URL_EXPIRY"},
+				Value:   api_handler.DefaultAssetDownloadURLExpiry,
+			},
+			&cli.DurationFlag{
+				Name:    "idempotency-key-retention",
+				Usage:   "Retention of responses of mutations with an idempotency key, retries with the key get the stored response",
+				EnvVars: []string{"BACKEND_IDEMPOTENCY_KEY_RETENTION"},
+				Value:   api_handler.DefaultIdempotencyKeyRetention,
+			},
+			&cli.DurationFlag{
+				Name:    "outbox-poll-interval",
+				Usage:   "Interval for delivering pending domain events from the outbox",
+				EnvVars: []string{"BACKEND_OUTBOX_POLL_INTERVAL"},
+				Value:   time.Second,
+			},
+			&cli.BoolFlag{
+				Name:  "playground",
+				Usage: "Enable GraphQL playground",
+				Value: false,
 			},
-
 			&cli.DurationFlag{
 				Name:    "sensitive-operation-constant-time",
 				Usage:   "Constant time duration to wait for sensitive operations (e.g. login / request password reset / perform password reset / registration), to prevent timing attacks",
 				EnvVars: []string{"SENSITIVE_OPERATION_CONSTANT_TIME"},
 				Value:   700 * time.Millisecond,
 			},
-		},
+		}, append(jobWorkerFlags(), processFlags()...)...),
 		Before: func(c *cli.Context) error {
 			setServerLogHandler(c)
 
@@ -129,6 +196,11 @@ func serverAction(c *cli.Context) (err error) {
 		return errors.Wrap(err, "pinging database")
 	}
 
+	replicaDB, err := connectReplicaDatabase(c)
+	if err != nil {
+		return err
+	}
+
 	mailer, err := buildMailer(c)
 	if err != nil {
 		return err
@@ -156,19 +228,56 @@ func serverAction(c *cli.Context) (err error) {
 		err = stderrors.Join(err, otelShutdown(context.Background()))
 	}()
 
-	shutdownCronJobs, err := startCronJobs(c, db)
+	shutdownCronJobs, err := startCronJobs(c, db, timeSource, config)
+	if err != nil {
+		return err
+	}
+
+	persistedQueryAllowlist, err := loadPersistedQueryAllowlist(c, db)
+	if err != nil {
+		return err
+	}
+
+	fileStorage, err := buildStorage(c)
 	if err != nil {
 		return err
 	}
 
+	assetURLSigner, err := buildAssetURLSigner(c)
+	if err != nil {
+		return err
+	}
+
+	// Change notifications are received by every server instance and passed to subscriptions
+	notifications := notification.NewListener(db)
+	go notifications.Run(c.Context)
+
+	// Domain events are delivered by every instance, due events are locked by the instance delivering them
+	dispatcher := outbox.NewDispatcher(db, timeSource, outbox.WithPollInterval(c.Duration("outbox-poll-interval")))
+	webhook.NewService(db, timeSource).Subscribe(dispatcher)
+	go dispatcher.Run(c.Context)
+
+	stopJobWorker := startJobWorker(c, db, timeSource)
+
+	// Queries are routed to the replica as soon as a check found its lag to be acceptable
+	var replica *finder.Replica
+	if replicaDB != nil {
+		replica = finder.NewReplica(replicaDB, c.Duration("postgres-replica-max-lag"))
+		go replica.Run(c.Context)
+	}
+
 	mux := http.NewServeMux()
 
 	deps := api.ResolverDependencies{
-		DB:            db,
-		TimeSource:    timeSource,
-		Config:        config,
-		Mailer:        mailer,
-		MeterProvider: otel.GetMeterProvider(),
+		DB:             db,
+		TimeSource:     timeSource,
+		Config:         config,
+		Mailer:         mailer,
+		MeterProvider:  otel.GetMeterProvider(),
+		Notifications:  notifications,
+		Storage:        fileStorage,
+		AssetURLSigner: assetURLSigner,
+		Replica:        replica,
 	}
 	graphqlHandler := api_handler.NewGraphqlHandler(deps, api_handler.Config{
 		EnableTracing:                  false,
@@ -176,7 +285,12 @@ func serverAction(c *cli.Context) (err error) {
 		EnableOpenTelemetry:            c.Bool("open-telemetry-enabled"),
 		DisableRecover:                 false,
 		WebsocketAllowOrigin:           c.String("websocket-allow-origin"),
+		MaxQueryDepth:                  c.Int("graphql-max-depth"),
+		MaxQueryComplexity:             c.Int("graphql-max-complexity"),
+		PersistedQueryAllowlist:        persistedQueryAllowlist,
 		SensitiveOperationConstantTime: c.Duration("sensitive-operation-constant-time"),
+		AssetDownloadURLExpiry:         c.Duration("asset-url-expiry"),
+		IdempotencyKeyRetention:        c.Duration("idempotency-key-retention"),
 	})
 
 	playgroundEnabled := c.Bool("playground")
@@ -189,6 +303,10 @@ func serverAction(c *cli.Context) (err error) {
 	}
 
 	mux.Handle("/query", http_api.MiddlewareStackWithAuth(deps, graphqlHandler))
+	// Assets are downloaded right after an upload, so their metadata is always read from the primary
+	assetFinder := finder.NewFinder(db, timeSource)
+	mux.Handle(api.AssetDownloadPathPrefix, api_handler.NewAssetDownloadHandler(assetFinder, fileStorage, assetURLSigner, timeSource))
+	mux.Handle(api.AssetVariantPathPrefix, api_handler.NewAssetVariantHandler(assetFinder, fileStorage, assetURLSigner, timeSource))
 	mux.HandleFunc("/healthz", api_handler.NewHealthzHandler(db))
 	mux.Handle("/metrics", promhttp.Handler())
 
@@ -209,11 +327,88 @@ func serverAction(c *cli.Context) (err error) {
 
 	err = serve(c, rootHandler, func(_ *cli.Context) error {
 		shutdownCronJobs()
+		stopJobWorker()
 		return nil
 	})
 	return err
 }
 
+const (
+	storageBackendLocal = "local"
+	storageBackendS3    = "s3"
+)
+
+func buildStorage(c *cli.Context) (storage.Storage, error) {
+	switch backend := c.String("storage-backend"); backend {
+	case storageBackendLocal:
+		return local.NewStorage(c.String("storage-local-path"))
+	case storageBackendS3:
+		return s3.NewStorage(s3.Config{
+			Endpoint:        c.String("storage-s3-endpoint"),
+			Region:          c.String("storage-s3-region"),
+			Bucket:          c.String("storage-s3-bucket"),
+			Prefix:          c.String("storage-s3-prefix"),
+			AccessKeyID:     c.String("storage-s3-access-key-id"),
+			SecretAccessKey: c.String("storage-s3-secret-access-key"),
+			UsePathStyle:    c.Bool("storage-s3-path-style"),
+		})
+	default:
+		return nil, errors.Errorf("invalid storage backend: %s", backend)
+	}
+}
+
+const assetURLSecretLength = 32
+
+func buildAssetURLSigner(c *cli.Context) (*signedurl.Signer, error) {
+	secret := []byte(c.String("asset-url-secret"))
+	if len(secret) == 0 {
+		logger.FromContext(c.Context).
+			Warn("No asset URL secret configured, using a random secret (download URLs are only valid for this instance)")
+
+		var err error
+		secret, err = security_helper.GenerateRandomBytes(assetURLSecretLength)
+		if err != nil {
+			return nil, errors.Wrap(err, "generating asset URL secret")
+		}
+	}
+	return signedurl.NewSigner(secret), nil
+}
+
+const (
+	persistedQueryModeAPQ       = "apq"
+	persistedQueryModeAllowlist = "allowlist"
+)
+
+// loadPersistedQueryAllowlist loads the manifest of persisted queries if the allowlist mode is enabled (nil otherwise)
+func loadPersistedQueryAllowlist(c *cli.Context, db *sql.DB) (persisted.Manifest, error) {
+	log := logger.FromContext(c.Context)
+
+	switch mode := c.String("persisted-query-mode"); mode {
+	case persistedQueryModeAPQ:
+		return nil, nil
+	case persistedQueryModeAllowlist:
+	default:
+		return nil, errors.Errorf("invalid persisted query mode: %s", mode)
+	}
+
+	var (
+		manifest persisted.Manifest
+		err      error
+	)
+	if manifestPath := c.String("persisted-query-manifest"); manifestPath != "" {
+		manifest, err = persisted.LoadManifestFile(manifestPath)
+	} else {
+		manifest, err = persisted.LoadManifestFromDB(c.Context, db)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "loading persisted query manifest")
+	}
+
+	log.Infof("Persisted query allowlist enabled with %d operations", len(manifest))
+
+	return manifest, nil
+}
+
 func serve(c *cli.Context, handler http.Handler, onShutdown func(c *cli.Context) error) (err error) {
 	log := logger.FromContext(c.Context)
 
@@ -281,23 +476,6 @@ func setupCancelOnSignal(c *cli.Context) {
 	}()
 }
 
-//nolint:unparam // Adding jobs needs to return errors
-func startCronJobs(c *cli.Context, _ *sql.DB) (func(), error) {
-	log := logger.FromContext(c.Context)
-
-	cronJobs := cron.New()
-
-	// boilerplate: Register your cronjobs here with cronJobs.AddJob
-
-	cronJobs.Start()
-
-	return func() {
-		log.Debugf("Stopping cron jobs")
-		cronJobs.Stop()
-		log.Debugf("All cron jobs stopped")
-	}, nil
-}
-
 func initializeSentry(c *cli.Context, component string) error {
 	log := logger.FromContext(c.Context)
 
@@ -337,6 +515,47 @@ func initializeSentry(c *cli.Context, component string) error {
 	return nil
 }
 
+// processFlags are the flags for logging, error reporting and telemetry of long-running commands
+func processFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "disable-ansi",
+			Usage:   "Force disable ANSI log output and output log in logfmt format",
+			EnvVars: []string{"BACKEND_DISABLE_ANSI"},
+			Value:   false,
+		},
+		&cli.BoolFlag{
+			Name:    "force-ansi",
+			Usage:   "Force enable ANSI log output",
+			EnvVars: []string{"BACKEND_FORCE_ANSI"},
+			Value:   false,
+		},
+
+		&cli.StringFlag{
+			Name:    "sentry-dsn",
+			Usage:   "Sentry DSN (will be disabled if empty)",
+			EnvVars: []string{"SENTRY_DSN"},
+		},
+		&cli.StringFlag{
+			Name:    "sentry-environment",
+			Usage:   "Sentry environment",
+			EnvVars: []string{"SENTRY_ENVIRONMENT"},
+			Value:   "development",
+		},
+		&cli.StringFlag{
+			Name:    "sentry-release",
+			Usage:   "Release version for Sentry",
+			EnvVars: []string{"SENTRY_RELEASE"},
+		},
+
+		&cli.BoolFlag{
+			Name:    "open-telemetry-enabled",
+			Usage:   "Enable open telemetry",
+			EnvVars: []string{"OPEN_TELEMETRY_ENABLED"},
+		},
+	}
+}
+
 func setServerLogHandler(c *cli.Context) {
 	if !c.Bool("disable-ansi") && (isatty.IsTerminal(os.Stdout.Fd()) || c.Bool("force-ansi")) {
 		logger.SetHandler(apexlogutils.NewComponentTextHandler(os.Stderr))
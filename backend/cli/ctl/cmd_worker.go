@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+
+	logger "github.com/apex/log"
+	"github.com/friendsofgo/errors"
+	"github.com/getsentry/sentry-go"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/urfave/cli/v2"
+
+	api_handler "myvendor.mytld/myproject/backend/api/handler"
+)
+
+func newWorkerCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "worker",
+		Usage: "Run background jobs without serving the API",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:    "address",
+				Usage:   "Listen on this address for health checks and metrics",
+				EnvVars: []string{"BACKEND_WORKER_ADDRESS"},
+				Value:   "0.0.0.0:8081",
+			},
+		}, append(jobWorkerFlags(), processFlags()...)...),
+		Before: func(c *cli.Context) error {
+			setServerLogHandler(c)
+
+			return nil
+		},
+		Action: workerAction,
+	}
+}
+
+func workerAction(c *cli.Context) (err error) {
+	log := logger.FromContext(c.Context)
+
+	defer sentry.Recover()
+	err = initializeSentry(c, "worker")
+	if err != nil {
+		return err
+	}
+
+	db, err := connectDatabase(c)
+	if err != nil {
+		return err
+	}
+
+	err = db.Ping()
+	if err != nil {
+		return errors.Wrap(err, "pinging database")
+	}
+
+	timeSource, err := newCurrentTimeSource(c)
+	if err != nil {
+		return err
+	}
+
+	setupCancelOnSignal(c)
+
+	config, err := getConfig(c)
+	if err != nil {
+		return err
+	}
+
+	otelShutdown, err := setupOTelSDK(c, config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = stderrors.Join(err, otelShutdown(context.Background()))
+	}()
+
+	stopJobWorker := startJobWorker(c, db, timeSource)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", api_handler.NewHealthzHandler(db))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	log.Infof("Running background jobs, serving health checks and metrics at http://%s", c.String("address"))
+
+	return serve(c, mux, func(_ *cli.Context) error {
+		stopJobWorker()
+		return nil
+	})
+}
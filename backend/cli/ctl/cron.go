@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	logger "github.com/apex/log"
+	"github.com/friendsofgo/errors"
+	"github.com/urfave/cli/v2"
+
+	"myvendor.mytld/myproject/backend/domain"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/persistence/scheduler"
+)
+
+// processedRetention is how long delivered outbox events and completed jobs are kept
+const processedRetention = 7 * 24 * time.Hour
+
+func newScheduler(db *sql.DB, timeSource types.TimeSource, config domain.Config) (*scheduler.Scheduler, error) {
+	s := scheduler.New(db, timeSource)
+
+	// boilerplate: Register your cron jobs here with s.Register
+
+	err := s.Register("purge-processed", "@hourly", func(ctx context.Context) error {
+		before := timeSource.Now().Add(-processedRetention)
+
+		events, err := repository.DeleteOutboxEventsDeliveredBefore(ctx, db, before)
+		if err != nil {
+			return errors.Wrap(err, "deleting delivered outbox events")
+		}
+		jobs, err := repository.DeleteJobsCompletedBefore(ctx, db, before)
+		if err != nil {
+			return errors.Wrap(err, "deleting completed jobs")
+		}
+
+		logger.FromContext(ctx).
+			WithField("outboxEvents", events).
+			WithField("jobs", jobs).
+			Info("Purged processed outbox events and jobs")
+		return nil
+	}, scheduler.WithCatchUp(scheduler.CatchUpOnce))
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Register("purge-idempotency-keys", "@hourly", func(ctx context.Context) error {
+		keys, err := repository.DeleteIdempotencyKeysExpiredBefore(ctx, db, timeSource.Now())
+		if err != nil {
+			return errors.Wrap(err, "deleting expired idempotency keys")
+		}
+
+		logger.FromContext(ctx).
+			WithField("idempotencyKeys", keys).
+			Info("Purged expired idempotency keys")
+		return nil
+	}, scheduler.WithCatchUp(scheduler.CatchUpOnce))
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Register("purge-deleted", "@daily", func(ctx context.Context) error {
+		before := timeSource.Now().Add(-config.SoftDeleteRetention)
+
+		// Accounts of purged organisations are deleted by cascade
+		organisations, err := repository.DeleteOrganisationsDeletedBefore(ctx, db, before)
+		if err != nil {
+			return errors.Wrap(err, "deleting organisations")
+		}
+		accounts, err := repository.DeleteAccountsDeletedBefore(ctx, db, before)
+		if err != nil {
+			return errors.Wrap(err, "deleting accounts")
+		}
+
+		logger.FromContext(ctx).
+			WithField("organisations", organisations).
+			WithField("accounts", accounts).
+			Info("Purged deleted organisations and accounts")
+		return nil
+	}, scheduler.WithCatchUp(scheduler.CatchUpOnce))
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// startCronJobs runs the scheduler in the background until the context of c is cancelled.
+// Every instance can run the scheduler, each job run is only executed by one instance.
+func startCronJobs(c *cli.Context, db *sql.DB, timeSource types.TimeSource, config domain.Config) (func(), error) {
+	log := logger.FromContext(c.Context)
+
+	s, err := newScheduler(db, timeSource, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "building scheduler")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := s.Run(c.Context); err != nil {
+			log.WithError(err).Error("Could not run cron jobs")
+		}
+	}()
+
+	return func() {
+		log.Debugf("Stopping cron jobs")
+		<-done
+		log.Debugf("All cron jobs stopped")
+	}, nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"database/sql"
+	"time"
+
+	logger "github.com/apex/log"
+	"github.com/urfave/cli/v2"
+	"go.opentelemetry.io/otel"
+
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/jobqueue"
+	"myvendor.mytld/myproject/backend/webhook"
+)
+
+func jobWorkerFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:    "job-concurrency",
+			Usage:   "Number of background jobs run in parallel (0 disables running jobs, e.g. if a separate worker is used)",
+			EnvVars: []string{"BACKEND_JOB_CONCURRENCY"},
+			Value:   4,
+		},
+		&cli.DurationFlag{
+			Name:    "job-poll-interval",
+			Usage:   "Interval for checking the job queue if it was empty",
+			EnvVars: []string{"BACKEND_JOB_POLL_INTERVAL"},
+			Value:   time.Second,
+		},
+		&cli.DurationFlag{
+			Name:    "job-lock-duration",
+			Usage:   "Maximum run time of a job before it is cancelled and retried",
+			EnvVars: []string{"BACKEND_JOB_LOCK_DURATION"},
+			Value:   5 * time.Minute,
+		},
+	}
+}
+
+func newJobWorker(c *cli.Context, db *sql.DB, timeSource types.TimeSource) *jobqueue.Worker {
+	worker := jobqueue.NewWorker(db, timeSource,
+		jobqueue.WithConcurrency(c.Int("job-concurrency")),
+		jobqueue.WithPollInterval(c.Duration("job-poll-interval")),
+		jobqueue.WithLockDuration(c.Duration("job-lock-duration")),
+		jobqueue.WithMeterProvider(otel.GetMeterProvider()),
+	)
+
+	webhook.NewService(db, timeSource).Register(worker)
+
+	// boilerplate: Register your job handlers here with jobqueue.Register
+
+	return worker
+}
+
+// startJobWorker runs jobs in the background until the context of c is cancelled.
+// The returned function waits until running jobs are finished.
+func startJobWorker(c *cli.Context, db *sql.DB, timeSource types.TimeSource) func() {
+	log := logger.FromContext(c.Context)
+
+	if c.Int("job-concurrency") <= 0 {
+		log.Info("Job concurrency is 0: not running background jobs")
+		return func() {}
+	}
+
+	worker := newJobWorker(c, db, timeSource)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := worker.Run(c.Context); err != nil {
+			log.WithError(err).Error("Could not run job worker")
+		}
+	}()
+
+	return func() {
+		log.Debugf("Waiting for running jobs")
+		<-done
+		log.Debugf("All jobs stopped")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/apex/log"
 	cli_handler "github.com/apex/log/handlers/cli"
@@ -45,6 +46,17 @@ func main() {
 				Value:   "dbname=myproject-dev sslmode=disable",
 				EnvVars: []string{"BACKEND_POSTGRES_DSN"},
 			},
+			&cli.StringFlag{
+				Name:    "postgres-replica-dsn",
+				Usage:   "PostgreSQL connection DSN of a read replica for GraphQL queries (optional)",
+				EnvVars: []string{"BACKEND_POSTGRES_REPLICA_DSN"},
+			},
+			&cli.DurationFlag{
+				Name:    "postgres-replica-max-lag",
+				Usage:   "Maximum replication lag of the read replica, queries are routed to the primary if it is exceeded",
+				Value:   5 * time.Second,
+				EnvVars: []string{"BACKEND_POSTGRES_REPLICA_MAX_LAG"},
+			},
 
 			&cli.IntFlag{
 				Name:    "hash-cost",
@@ -53,6 +65,43 @@ func main() {
 				EnvVars: []string{"BACKEND_HASH_COST"},
 			},
 
+			&cli.Int64Flag{
+				Name:    "upload-max-size",
+				Usage:   "Maximum size of uploaded files in bytes",
+				Value:   defaultConfig.UploadMaxSize,
+				EnvVars: []string{"BACKEND_UPLOAD_MAX_SIZE"},
+			},
+			&cli.IntFlag{
+				Name:    "image-max-width",
+				Usage:   "Maximum width of uploaded images in pixels",
+				Value:   defaultConfig.ImageMaxWidth,
+				EnvVars: []string{"BACKEND_IMAGE_MAX_WIDTH"},
+			},
+			&cli.IntFlag{
+				Name:    "image-max-height",
+				Usage:   "Maximum height of uploaded images in pixels",
+				Value:   defaultConfig.ImageMaxHeight,
+				EnvVars: []string{"BACKEND_IMAGE_MAX_HEIGHT"},
+			},
+			&cli.IntFlag{
+				Name:    "support-request-limit",
+				Usage:   "Maximum number of support requests per account within the limit interval",
+				Value:   defaultConfig.SupportRequestLimit,
+				EnvVars: []string{"BACKEND_SUPPORT_REQUEST_LIMIT"},
+			},
+			&cli.DurationFlag{
+				Name:    "support-request-limit-interval",
+				Usage:   "Interval for limiting support requests per account",
+				Value:   defaultConfig.SupportRequestLimitInterval,
+				EnvVars: []string{"BACKEND_SUPPORT_REQUEST_LIMIT_INTERVAL"},
+			},
+			&cli.DurationFlag{
+				Name:    "soft-delete-retention",
+				Usage:   "Retention of deleted accounts and organisations before they are purged, they can be restored until then",
+				Value:   defaultConfig.SoftDeleteRetention,
+				EnvVars: []string{"BACKEND_SOFT_DELETE_RETENTION"},
+			},
+
 			&cli.StringFlag{
 				Name:    "app-base-url",
 				Usage:   "Application base URL",
@@ -111,9 +160,14 @@ func main() {
 		},
 		Commands: []*cli.Command{
 			newServerCmd(),
+			newWorkerCmd(),
 			newMigrateCmd(),
 			newAccountCmd(),
 			newFixturesCmd(),
+			newOutboxCmd(),
+			newAuditCmd(),
+			newCronCmd(),
+			newGraphqlCmd(),
 			newTestCmd(),
 		},
 	}
@@ -160,7 +214,18 @@ func loadDotenv() {
 }
 
 func connectDatabase(c *cli.Context) (*sql.DB, error) {
-	postgresDSN := c.String("postgres-dsn")
+	return openDatabase(c, c.String("postgres-dsn"))
+}
+
+// connectReplicaDatabase connects to the read replica, it returns nil if no replica is configured
+func connectReplicaDatabase(c *cli.Context) (*sql.DB, error) {
+	if !c.IsSet("postgres-replica-dsn") {
+		return nil, nil
+	}
+	return openDatabase(c, c.String("postgres-replica-dsn"))
+}
+
+func openDatabase(c *cli.Context, postgresDSN string) (*sql.DB, error) {
 	log.
 		WithField("component", "cli").
 		WithField("postgresDSN", postgresDSN).
@@ -209,6 +274,12 @@ func getConfig(c *cli.Context) (domain.Config, error) {
 	config := domain.DefaultConfig()
 	config.AppBaseURL = c.String("app-base-url")
 	config.HashCost = c.Int("hash-cost")
+	config.UploadMaxSize = c.Int64("upload-max-size")
+	config.ImageMaxWidth = c.Int("image-max-width")
+	config.ImageMaxHeight = c.Int("image-max-height")
+	config.SupportRequestLimit = c.Int("support-request-limit")
+	config.SupportRequestLimitInterval = c.Duration("support-request-limit-interval")
+	config.SoftDeleteRetention = c.Duration("soft-delete-retention")
 	// Add more config options here
 	return config, nil
 }
@@ -3,6 +3,7 @@ package command
 import (
 	"strings"
 
+	"github.com/apex/log"
 	"github.com/friendsofgo/errors"
 	"github.com/gofrs/uuid"
 
@@ -88,3 +89,13 @@ func (c AccountCreateCmd) NewAccount(config domain.Config) (model.Account, error
 	}
 	return account, nil
 }
+
+// Fields excludes the password from logging
+func (c AccountCreateCmd) Fields() log.Fields {
+	return log.Fields{
+		"accountID":      c.AccountID,
+		"organisationID": optionalID(c.OrganisationID),
+		"emailAddress":   c.EmailAddress,
+		"role":           c.Role,
+	}
+}
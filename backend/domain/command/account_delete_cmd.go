@@ -1,6 +1,7 @@
 package command
 
 import (
+	"github.com/apex/log"
 	"github.com/gofrs/uuid"
 )
 
@@ -15,3 +16,11 @@ func NewAccountDeleteCmd(accountID uuid.UUID, organisationID uuid.NullUUID) Acco
 		OrganisationID: organisationID,
 	}
 }
+
+// Fields are logged when the command is handled
+func (c AccountDeleteCmd) Fields() log.Fields {
+	return log.Fields{
+		"accountID":      c.AccountID,
+		"organisationID": optionalID(c.OrganisationID),
+	}
+}
@@ -0,0 +1,26 @@
+package command
+
+import (
+	"github.com/apex/log"
+	"github.com/gofrs/uuid"
+)
+
+type AccountRestoreCmd struct {
+	AccountID      uuid.UUID
+	OrganisationID uuid.NullUUID
+}
+
+func NewAccountRestoreCmd(accountID uuid.UUID, organisationID uuid.NullUUID) AccountRestoreCmd {
+	return AccountRestoreCmd{
+		AccountID:      accountID,
+		OrganisationID: organisationID,
+	}
+}
+
+// Fields are logged when the command is handled
+func (c AccountRestoreCmd) Fields() log.Fields {
+	return log.Fields{
+		"accountID":      c.AccountID,
+		"organisationID": optionalID(c.OrganisationID),
+	}
+}
@@ -3,6 +3,7 @@ package command
 import (
 	"strings"
 
+	"github.com/apex/log"
 	"github.com/gofrs/uuid"
 
 	"myvendor.mytld/myproject/backend/domain"
@@ -17,6 +18,8 @@ type AccountUpdateCmd struct {
 	Role                  types.Role
 	CurrentOrganisationID uuid.NullUUID
 	NewOrganisationID     uuid.NullUUID
+	// ExpectedVersion rejects the update if the account was changed in the meantime, the update is unconditional if nil
+	ExpectedVersion *int
 	// Will be nil if not changed
 	PasswordHash []byte
 	Secret       []byte
@@ -72,5 +75,20 @@ func (c AccountUpdateCmd) Validate(_ domain.Config) error {
 			Code:  types.ErrorCodeRequired,
 		}
 	}
+	if err := validateExpectedVersion(c.ExpectedVersion); err != nil {
+		return err
+	}
 	return nil
 }
+
+// Fields excludes the password and secrets from logging
+func (c AccountUpdateCmd) Fields() log.Fields {
+	return log.Fields{
+		"accountID":          c.AccountID,
+		"prevOrganisationID": optionalID(c.CurrentOrganisationID),
+		"organisationID":     optionalID(c.NewOrganisationID),
+		"emailAddress":       c.EmailAddress,
+		"role":               c.Role,
+		"passwordChanged":    c.PasswordHash != nil,
+	}
+}
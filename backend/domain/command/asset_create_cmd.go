@@ -0,0 +1,88 @@
+package command
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/imaging"
+)
+
+type AssetCreateCmd struct {
+	AssetID        uuid.UUID
+	OrganisationID uuid.NullUUID
+	Filename       string
+	// ContentType is detected from the content, the type declared by the client is not trusted
+	ContentType string
+	Size        int64
+	// Image information, only set for images that could be decoded
+	Image   *imaging.Info
+	content io.Reader
+}
+
+func NewAssetCreateCmd(filename string, size int64, content io.ReadSeeker) (cmd AssetCreateCmd, err error) {
+	assetID, err := uuid.NewV7()
+	if err != nil {
+		return cmd, errors.Wrap(err, "generating id")
+	}
+
+	contentType, err := detectContentType(content)
+	if err != nil {
+		return cmd, err
+	}
+
+	var image *imaging.Info
+	if imaging.IsImage(contentType) {
+		image, err = detectImageInfo(content)
+		if err != nil {
+			return cmd, err
+		}
+	}
+
+	return AssetCreateCmd{
+		AssetID:     assetID,
+		Filename:    filepath.Base(strings.TrimSpace(filename)),
+		ContentType: contentType,
+		Size:        size,
+		Image:       image,
+		content:     content,
+	}, nil
+}
+
+// Content of the file to store
+func (c AssetCreateCmd) Content() io.Reader {
+	return c.content
+}
+
+func (c AssetCreateCmd) Validate(config domain.Config) error {
+	if isBlank(c.Filename) || c.Filename == "." || c.Filename == string(filepath.Separator) {
+		return types.FieldError{
+			Field: "file",
+			Code:  types.ErrorCodeRequired,
+		}
+	}
+	if err := validateUpload("file", c.Size, c.ContentType, config); err != nil {
+		return err
+	}
+	if imaging.IsImage(c.ContentType) {
+		return validateImage("file", c.Image, config)
+	}
+	return nil
+}
+
+// Fields excludes the content from logging
+func (c AssetCreateCmd) Fields() log.Fields {
+	return log.Fields{
+		"assetID":        c.AssetID,
+		"organisationID": optionalID(c.OrganisationID),
+		"filename":       c.Filename,
+		"contentType":    c.ContentType,
+		"size":           c.Size,
+	}
+}
@@ -0,0 +1,150 @@
+package command_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/domain"
+	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/types"
+)
+
+// pngHeader builds a PNG signature and IHDR chunk, enough for detecting the content type and dimensions
+func pngHeader(width, height uint32) []byte {
+	ihdr := []byte("IHDR")
+	ihdr = binary.BigEndian.AppendUint32(ihdr, width)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, height)
+	// Bit depth 8, color type RGBA, default compression, filter and interlace
+	ihdr = append(ihdr, 8, 6, 0, 0, 0)
+
+	header := []byte("\x89PNG\r\n\x1a\n")
+	header = binary.BigEndian.AppendUint32(header, uint32(len(ihdr)-4))
+	header = append(header, ihdr...)
+	return binary.BigEndian.AppendUint32(header, crc32.ChecksumIEEE(ihdr))
+}
+
+func TestAssetCreateCmd_Validate(t *testing.T) {
+	config := domain.DefaultConfig()
+	config.UploadMaxSize = 100
+
+	tests := []struct {
+		name                string
+		filename            string
+		content             []byte
+		size                int64
+		expectedContentType string
+		expectedErr         error
+	}{
+		{
+			name:                "text file",
+			filename:            "hello.txt",
+			content:             []byte("Hello"),
+			expectedContentType: "text/plain",
+		},
+		{
+			name:                "png image",
+			filename:            "image.png",
+			content:             pngHeader(800, 600),
+			expectedContentType: "image/png",
+		},
+		{
+			name:                "png image exceeding width",
+			filename:            "wide.png",
+			content:             pngHeader(8193, 600),
+			expectedContentType: "image/png",
+			expectedErr: types.FieldError{
+				Field:     "file",
+				Code:      types.ErrorCodeImageWidthMustBeAtMost,
+				Arguments: []string{"8192"},
+			},
+		},
+		{
+			name:                "png image exceeding height",
+			filename:            "tall.png",
+			content:             pngHeader(800, 10000),
+			expectedContentType: "image/png",
+			expectedErr: types.FieldError{
+				Field:     "file",
+				Code:      types.ErrorCodeImageHeightMustBeAtMost,
+				Arguments: []string{"8192"},
+			},
+		},
+		{
+			name:                "corrupt png image",
+			filename:            "corrupt.png",
+			content:             []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"),
+			expectedContentType: "image/png",
+			expectedErr: types.FieldError{
+				Field: "file",
+				Code:  types.ErrorCodeInvalid,
+			},
+		},
+		{
+			name:                "html is not allowed",
+			filename:            "page.html",
+			content:             []byte("<!DOCTYPE html><html></html>"),
+			expectedContentType: "text/html",
+			expectedErr: types.FieldError{
+				Field:     "file",
+				Code:      types.ErrorCodeContentTypeNotAllowed,
+				Arguments: []string{"text/html"},
+			},
+		},
+		{
+			name:                "exceeds upload limit",
+			filename:            "large.txt",
+			content:             bytes.Repeat([]byte("a"), 101),
+			expectedContentType: "text/plain",
+			expectedErr: types.FieldError{
+				Field:     "file",
+				Code:      types.ErrorCodeUploadLimitExceeded,
+				Arguments: []string{"100"},
+			},
+		},
+		{
+			name:                "blank filename",
+			filename:            " ",
+			content:             []byte("Hello"),
+			expectedContentType: "text/plain",
+			expectedErr: types.FieldError{
+				Field: "file",
+				Code:  types.ErrorCodeRequired,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := bytes.NewReader(tt.content)
+			cmd, err := command.NewAssetCreateCmd(tt.filename, int64(len(tt.content)), content)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedContentType, cmd.ContentType)
+
+			// Content must be readable from the start after detecting the content type
+			read, err := io.ReadAll(cmd.Content())
+			require.NoError(t, err)
+			assert.Equal(t, tt.content, read)
+
+			err = cmd.Validate(config)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewAssetCreateCmd_StripsPath(t *testing.T) {
+	cmd, err := command.NewAssetCreateCmd("../../etc/passwd", 5, strings.NewReader("Hello"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "passwd", cmd.Filename)
+}
@@ -0,0 +1,26 @@
+package command
+
+import (
+	"github.com/apex/log"
+	"github.com/gofrs/uuid"
+)
+
+type AssetDeleteCmd struct {
+	AssetID        uuid.UUID
+	OrganisationID uuid.NullUUID
+}
+
+func NewAssetDeleteCmd(assetID uuid.UUID, organisationID uuid.NullUUID) AssetDeleteCmd {
+	return AssetDeleteCmd{
+		AssetID:        assetID,
+		OrganisationID: organisationID,
+	}
+}
+
+// Fields are logged when the command is handled
+func (c AssetDeleteCmd) Fields() log.Fields {
+	return log.Fields{
+		"assetID":        c.AssetID,
+		"organisationID": optionalID(c.OrganisationID),
+	}
+}
@@ -0,0 +1,20 @@
+package command
+
+import (
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain"
+)
+
+// Validatable is implemented by commands that check their values before they are handled
+type Validatable interface {
+	Validate(config domain.Config) error
+}
+
+// optionalID formats an optional ID for logging, an empty string is logged for null
+func optionalID(id uuid.NullUUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return id.UUID.String()
+}
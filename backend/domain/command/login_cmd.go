@@ -1,6 +1,7 @@
 package command
 
 import (
+	"github.com/apex/log"
 	"github.com/gofrs/uuid"
 )
 
@@ -23,3 +24,14 @@ func NewLoginCmd(email, password string) LoginCmd {
 		Password:     password,
 	}
 }
+
+// Fields excludes the password from logging
+func (c LoginCmd) Fields() log.Fields {
+	fields := log.Fields{
+		"emailAddress": c.EmailAddress,
+	}
+	if c.Account != nil {
+		fields["accountID"] = c.Account.GetAccountID()
+	}
+	return fields
+}
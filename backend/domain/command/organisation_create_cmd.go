@@ -1,9 +1,11 @@
 package command
 
 import (
+	"github.com/apex/log"
 	"github.com/friendsofgo/errors"
 	"github.com/gofrs/uuid"
 
+	"myvendor.mytld/myproject/backend/domain"
 	"myvendor.mytld/myproject/backend/domain/types"
 )
 
@@ -23,7 +25,7 @@ func NewOrganisationCreateCmd() (cmd OrganisationCreateCmd, err error) {
 	}, nil
 }
 
-func (c OrganisationCreateCmd) Validate() error {
+func (c OrganisationCreateCmd) Validate(_ domain.Config) error {
 	if isBlank(c.Name) {
 		return types.FieldError{
 			Field: "name",
@@ -33,3 +35,11 @@ func (c OrganisationCreateCmd) Validate() error {
 
 	return nil
 }
+
+// Fields are logged when the command is handled
+func (c OrganisationCreateCmd) Fields() log.Fields {
+	return log.Fields{
+		"organisationID":   c.OrganisationID,
+		"organisationName": c.Name,
+	}
+}
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"github.com/apex/log"
 	"github.com/gofrs/uuid"
 )
 
@@ -13,3 +14,10 @@ func NewOrganisationDeleteCmd(organisationID uuid.UUID) OrganisationDeleteCmd {
 		OrganisationID: organisationID,
 	}
 }
+
+// Fields are logged when the command is handled
+func (c OrganisationDeleteCmd) Fields() log.Fields {
+	return log.Fields{
+		"organisationID": c.OrganisationID,
+	}
+}
@@ -0,0 +1,23 @@
+package command
+
+import (
+	"github.com/apex/log"
+	"github.com/gofrs/uuid"
+)
+
+type OrganisationRestoreCmd struct {
+	OrganisationID uuid.UUID
+}
+
+func NewOrganisationRestoreCmd(organisationID uuid.UUID) OrganisationRestoreCmd {
+	return OrganisationRestoreCmd{
+		OrganisationID: organisationID,
+	}
+}
+
+// Fields are logged when the command is handled
+func (c OrganisationRestoreCmd) Fields() log.Fields {
+	return log.Fields{
+		"organisationID": c.OrganisationID,
+	}
+}
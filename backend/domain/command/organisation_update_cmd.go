@@ -1,23 +1,38 @@
 package command
 
 import (
+	"github.com/apex/log"
 	"github.com/gofrs/uuid"
 
+	"myvendor.mytld/myproject/backend/domain"
 	"myvendor.mytld/myproject/backend/domain/types"
 )
 
 type OrganisationUpdateCmd struct {
 	OrganisationID uuid.UUID
 	Name           string
+	// ExpectedVersion rejects the update if the organisation was changed in the meantime, the update is unconditional if nil
+	ExpectedVersion *int
 }
 
-func (c OrganisationUpdateCmd) Validate() error {
+func (c OrganisationUpdateCmd) Validate(_ domain.Config) error {
 	if isBlank(c.Name) {
 		return types.FieldError{
 			Field: "name",
 			Code:  types.ErrorCodeRequired,
 		}
 	}
+	if err := validateExpectedVersion(c.ExpectedVersion); err != nil {
+		return err
+	}
 
 	return nil
 }
+
+// Fields are logged when the command is handled
+func (c OrganisationUpdateCmd) Fields() log.Fields {
+	return log.Fields{
+		"organisationID":   c.OrganisationID,
+		"organisationName": c.Name,
+	}
+}
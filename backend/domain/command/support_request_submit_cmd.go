@@ -0,0 +1,124 @@
+package command
+
+import (
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apex/log"
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain"
+	"myvendor.mytld/myproject/backend/domain/types"
+)
+
+const (
+	supportRequestSubjectMaxLength = 200
+	supportRequestMessageMaxLength = 10000
+)
+
+type SupportRequestSubmitCmd struct {
+	SupportRequestID uuid.UUID
+	// AccountID of the sender, sender details are looked up from the account
+	AccountID uuid.UUID
+	Subject   string
+	Message   string
+	// Attachment is optional
+	Attachment *SupportRequestAttachment
+}
+
+type SupportRequestAttachment struct {
+	Filename string
+	// ContentType is detected from the content, the type declared by the client is not trusted
+	ContentType string
+	Size        int64
+	content     io.Reader
+}
+
+// Content of the attached file
+func (a SupportRequestAttachment) Content() io.Reader {
+	return a.content
+}
+
+func NewSupportRequestSubmitCmd(accountID uuid.UUID, subject, message string) (cmd SupportRequestSubmitCmd, err error) {
+	supportRequestID, err := uuid.NewV7()
+	if err != nil {
+		return cmd, errors.Wrap(err, "generating id")
+	}
+
+	return SupportRequestSubmitCmd{
+		SupportRequestID: supportRequestID,
+		AccountID:        accountID,
+		Subject:          strings.TrimSpace(subject),
+		Message:          strings.TrimSpace(message),
+	}, nil
+}
+
+// Attach sets the attachment of the support request
+func (c *SupportRequestSubmitCmd) Attach(filename string, size int64, content io.ReadSeeker) error {
+	contentType, err := detectContentType(content)
+	if err != nil {
+		return err
+	}
+
+	c.Attachment = &SupportRequestAttachment{
+		Filename:    filepath.Base(strings.TrimSpace(filename)),
+		ContentType: contentType,
+		Size:        size,
+		content:     content,
+	}
+	return nil
+}
+
+func (c SupportRequestSubmitCmd) Validate(config domain.Config) error {
+	if isBlank(c.Subject) {
+		return types.FieldError{
+			Field: "subject",
+			Code:  types.ErrorCodeRequired,
+		}
+	}
+	if utf8.RuneCountInString(c.Subject) > supportRequestSubjectMaxLength {
+		return types.FieldError{
+			Field:     "subject",
+			Code:      types.ErrorCodeMustBeAtMost,
+			Arguments: []string{strconv.Itoa(supportRequestSubjectMaxLength)},
+		}
+	}
+	if isBlank(c.Message) {
+		return types.FieldError{
+			Field: "message",
+			Code:  types.ErrorCodeRequired,
+		}
+	}
+	if utf8.RuneCountInString(c.Message) > supportRequestMessageMaxLength {
+		return types.FieldError{
+			Field:     "message",
+			Code:      types.ErrorCodeMustBeAtMost,
+			Arguments: []string{strconv.Itoa(supportRequestMessageMaxLength)},
+		}
+	}
+
+	if c.Attachment != nil {
+		if isBlank(c.Attachment.Filename) || c.Attachment.Filename == "." || c.Attachment.Filename == string(filepath.Separator) {
+			return types.FieldError{
+				Field: "attachment",
+				Code:  types.ErrorCodeRequired,
+			}
+		}
+		return validateUpload("attachment", c.Attachment.Size, c.Attachment.ContentType, config)
+	}
+
+	return nil
+}
+
+// Fields excludes the message and attachment from logging
+func (c SupportRequestSubmitCmd) Fields() log.Fields {
+	return log.Fields{
+		"supportRequestID": c.SupportRequestID,
+		"accountID":        c.AccountID,
+		"subject":          c.Subject,
+	}
+}
@@ -0,0 +1,90 @@
+package command_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/domain"
+	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/types"
+)
+
+func TestSupportRequestSubmitCmd_Validate(t *testing.T) {
+	config := domain.DefaultConfig()
+	config.UploadMaxSize = 100
+
+	accountID := uuid.Must(uuid.FromString("3ad082c7-cbda-49e1-a707-c53e1962be65"))
+
+	tests := []struct {
+		name        string
+		subject     string
+		message     string
+		attachment  string
+		expectedErr error
+	}{
+		{
+			name:    "without attachment",
+			subject: "Question",
+			message: "Hello",
+		},
+		{
+			name:       "with text attachment",
+			subject:    "Question",
+			message:    "Hello",
+			attachment: "Log output",
+		},
+		{
+			name:        "blank subject",
+			subject:     " ",
+			message:     "Hello",
+			expectedErr: types.FieldError{Field: "subject", Code: types.ErrorCodeRequired},
+		},
+		{
+			name:        "too long subject",
+			subject:     strings.Repeat("a", 201),
+			message:     "Hello",
+			expectedErr: types.FieldError{Field: "subject", Code: types.ErrorCodeMustBeAtMost, Arguments: []string{"200"}},
+		},
+		{
+			name:        "blank message",
+			subject:     "Question",
+			message:     "",
+			expectedErr: types.FieldError{Field: "message", Code: types.ErrorCodeRequired},
+		},
+		{
+			name:        "attachment exceeds upload limit",
+			subject:     "Question",
+			message:     "Hello",
+			attachment:  strings.Repeat("a", 101),
+			expectedErr: types.FieldError{Field: "attachment", Code: types.ErrorCodeUploadLimitExceeded, Arguments: []string{"100"}},
+		},
+		{
+			name:        "attachment with disallowed content type",
+			subject:     "Question",
+			message:     "Hello",
+			attachment:  "<!DOCTYPE html><html></html>",
+			expectedErr: types.FieldError{Field: "attachment", Code: types.ErrorCodeContentTypeNotAllowed, Arguments: []string{"text/html"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := command.NewSupportRequestSubmitCmd(accountID, tt.subject, tt.message)
+			require.NoError(t, err)
+
+			if tt.attachment != "" {
+				err = cmd.Attach("attachment.txt", int64(len(tt.attachment)), strings.NewReader(tt.attachment))
+				require.NoError(t, err)
+			}
+
+			err = cmd.Validate(config)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/friendsofgo/errors"
+
+	"myvendor.mytld/myproject/backend/domain"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/imaging"
+)
+
+// sniffLength is the number of bytes that are considered for detecting the content type
+const sniffLength = 512
+
+//nolint:gochecknoglobals
+var allowedUploadContentTypes = map[string]struct{}{
+	"image/jpeg":      {},
+	"image/png":       {},
+	"image/gif":       {},
+	"application/pdf": {},
+	"text/plain":      {},
+}
+
+func detectContentType(content io.ReadSeeker) (string, error) {
+	buf := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, buf)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", errors.Wrap(err, "reading content")
+	}
+	_, err = content.Seek(0, io.SeekStart)
+	if err != nil {
+		return "", errors.Wrap(err, "seeking content")
+	}
+
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(buf[:n]))
+	if err != nil {
+		return "", errors.Wrap(err, "parsing detected content type")
+	}
+	return mediaType, nil
+}
+
+// detectImageInfo reads the dimensions of an image, nil is returned if the content cannot be decoded as an image
+func detectImageInfo(content io.ReadSeeker) (*imaging.Info, error) {
+	info, decodeErr := imaging.DecodeInfo(content)
+	_, err := content.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, errors.Wrap(err, "seeking content")
+	}
+	if decodeErr != nil {
+		return nil, nil //nolint:nilerr // Invalid images are rejected by validation
+	}
+	return &info, nil
+}
+
+// validateUpload checks an uploaded file against the configured size limit and the allowed content types
+func validateUpload(field string, size int64, contentType string, config domain.Config) error {
+	if size > config.UploadMaxSize {
+		return types.FieldError{
+			Field:     field,
+			Code:      types.ErrorCodeUploadLimitExceeded,
+			Arguments: []string{strconv.FormatInt(config.UploadMaxSize, 10)},
+		}
+	}
+	if _, ok := allowedUploadContentTypes[contentType]; !ok {
+		return types.FieldError{
+			Field:     field,
+			Code:      types.ErrorCodeContentTypeNotAllowed,
+			Arguments: []string{contentType},
+		}
+	}
+	return nil
+}
+
+// validateImage checks that an image could be decoded and does not exceed the configured dimensions
+func validateImage(field string, info *imaging.Info, config domain.Config) error {
+	if info == nil {
+		return types.FieldError{
+			Field: field,
+			Code:  types.ErrorCodeInvalid,
+		}
+	}
+	if info.Width > config.ImageMaxWidth {
+		return types.FieldError{
+			Field:     field,
+			Code:      types.ErrorCodeImageWidthMustBeAtMost,
+			Arguments: []string{strconv.Itoa(config.ImageMaxWidth)},
+		}
+	}
+	if info.Height > config.ImageMaxHeight {
+		return types.FieldError{
+			Field:     field,
+			Code:      types.ErrorCodeImageHeightMustBeAtMost,
+			Arguments: []string{strconv.Itoa(config.ImageMaxHeight)},
+		}
+	}
+	return nil
+}
@@ -1,7 +1,22 @@
 package command
 
-import "strings"
+import (
+	"strings"
+
+	"myvendor.mytld/myproject/backend/domain/types"
+)
 
 func isBlank(s string) bool {
 	return strings.Trim(s, " ") == ""
 }
+
+// validateExpectedVersion checks an optional version for a conditional update, versions start at 1
+func validateExpectedVersion(expectedVersion *int) error {
+	if expectedVersion != nil && *expectedVersion < 1 {
+		return types.FieldError{
+			Field: "expectedVersion",
+			Code:  types.ErrorCodeInvalid,
+		}
+	}
+	return nil
+}
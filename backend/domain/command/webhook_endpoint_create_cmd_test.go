@@ -0,0 +1,92 @@
+package command_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/domain"
+	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/event"
+	"myvendor.mytld/myproject/backend/domain/types"
+)
+
+func TestWebhookEndpointCreateCmd_Validate(t *testing.T) {
+	organisationID := uuid.Must(uuid.FromString("6330de58-2761-411e-a243-bec6d0c53876"))
+
+	tests := []struct {
+		name        string
+		url         string
+		eventTypes  []string
+		expectedErr error
+	}{
+		{
+			name:       "valid",
+			url:        "https://example.com/hooks",
+			eventTypes: []string{event.TypeAccountCreated, event.TypeAccountDeleted},
+		},
+		{
+			name:        "blank url",
+			url:         " ",
+			eventTypes:  []string{event.TypeAccountCreated},
+			expectedErr: types.FieldError{Field: "url", Code: types.ErrorCodeRequired},
+		},
+		{
+			name:        "relative url",
+			url:         "/hooks",
+			eventTypes:  []string{event.TypeAccountCreated},
+			expectedErr: types.FieldError{Field: "url", Code: types.ErrorCodeInvalid},
+		},
+		{
+			name:        "unsupported scheme",
+			url:         "ftp://example.com/hooks",
+			eventTypes:  []string{event.TypeAccountCreated},
+			expectedErr: types.FieldError{Field: "url", Code: types.ErrorCodeInvalid},
+		},
+		{
+			name:        "localhost",
+			url:         "http://localhost:8080/hooks",
+			eventTypes:  []string{event.TypeAccountCreated},
+			expectedErr: types.FieldError{Field: "url", Code: types.ErrorCodeInvalid},
+		},
+		{
+			name:        "metadata address",
+			url:         "http://169.254.169.254/latest/meta-data/",
+			eventTypes:  []string{event.TypeAccountCreated},
+			expectedErr: types.FieldError{Field: "url", Code: types.ErrorCodeInvalid},
+		},
+		{
+			name:        "private address",
+			url:         "https://[fd00::1]/hooks",
+			eventTypes:  []string{event.TypeAccountCreated},
+			expectedErr: types.FieldError{Field: "url", Code: types.ErrorCodeInvalid},
+		},
+		{
+			name:        "no event types",
+			url:         "https://example.com/hooks",
+			expectedErr: types.FieldError{Field: "eventTypes", Code: types.ErrorCodeRequired},
+		},
+		{
+			name:        "unknown event type",
+			url:         "https://example.com/hooks",
+			eventTypes:  []string{event.TypeAccountCreated, "AccountExploded"},
+			expectedErr: types.FieldError{Field: "eventTypes", Code: types.ErrorCodeInvalid},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := command.NewWebhookEndpointCreateCmd(organisationID, tt.url, tt.eventTypes)
+			require.NoError(t, err)
+			assert.Regexp(t, `^whsec_[0-9a-f]{64}$`, cmd.Secret)
+
+			err = cmd.Validate(domain.Config{})
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package command
+
+import (
+	"github.com/apex/log"
+	"github.com/gofrs/uuid"
+)
+
+type WebhookEndpointDeleteCmd struct {
+	WebhookEndpointID uuid.UUID
+	OrganisationID    uuid.UUID
+}
+
+func NewWebhookEndpointDeleteCmd(webhookEndpointID, organisationID uuid.UUID) WebhookEndpointDeleteCmd {
+	return WebhookEndpointDeleteCmd{
+		WebhookEndpointID: webhookEndpointID,
+		OrganisationID:    organisationID,
+	}
+}
+
+// Fields are logged when the command is handled
+func (c WebhookEndpointDeleteCmd) Fields() log.Fields {
+	return log.Fields{
+		"webhookEndpointID": c.WebhookEndpointID,
+		"organisationID":    c.OrganisationID,
+	}
+}
@@ -0,0 +1,38 @@
+package command
+
+import (
+	"github.com/apex/log"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain"
+)
+
+type WebhookEndpointUpdateCmd struct {
+	WebhookEndpointID uuid.UUID
+	OrganisationID    uuid.UUID
+	URL               string
+	EventTypes        []string
+}
+
+func NewWebhookEndpointUpdateCmd(webhookEndpointID, organisationID uuid.UUID, url string, eventTypes []string) WebhookEndpointUpdateCmd {
+	return WebhookEndpointUpdateCmd{
+		WebhookEndpointID: webhookEndpointID,
+		OrganisationID:    organisationID,
+		URL:               url,
+		EventTypes:        eventTypes,
+	}
+}
+
+func (c WebhookEndpointUpdateCmd) Validate(_ domain.Config) error {
+	return validateWebhookEndpoint(c.URL, c.EventTypes)
+}
+
+// Fields are logged when the command is handled
+func (c WebhookEndpointUpdateCmd) Fields() log.Fields {
+	return log.Fields{
+		"webhookEndpointID": c.WebhookEndpointID,
+		"organisationID":    c.OrganisationID,
+		"url":               c.URL,
+		"eventTypes":        c.EventTypes,
+	}
+}
@@ -0,0 +1,27 @@
+package command
+
+import (
+	"github.com/apex/log"
+	"github.com/gofrs/uuid"
+)
+
+// WebhookRedeliverCmd sends a delivery again with the original payload, e.g. after it failed
+type WebhookRedeliverCmd struct {
+	WebhookDeliveryID uuid.UUID
+	OrganisationID    uuid.UUID
+}
+
+func NewWebhookRedeliverCmd(webhookDeliveryID, organisationID uuid.UUID) WebhookRedeliverCmd {
+	return WebhookRedeliverCmd{
+		WebhookDeliveryID: webhookDeliveryID,
+		OrganisationID:    organisationID,
+	}
+}
+
+// Fields are logged when the command is handled
+func (c WebhookRedeliverCmd) Fields() log.Fields {
+	return log.Fields{
+		"webhookDeliveryID": c.WebhookDeliveryID,
+		"organisationID":    c.OrganisationID,
+	}
+}
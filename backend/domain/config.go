@@ -8,6 +8,17 @@ import (
 // Bcrypt hash cost (defaults to 10), but 12 is more secure
 const defaultHashCost = 12
 
+const defaultUploadMaxSize = 10 << 20 // 10 MiB
+
+const defaultImageMaxDimension = 8192
+
+const (
+	defaultSupportRequestLimit         = 5
+	defaultSupportRequestLimitInterval = time.Hour
+)
+
+const defaultSoftDeleteRetention = 30 * 24 * time.Hour
+
 // Config holds the base configuration used by various parts of the application
 type Config struct {
 	AppName string
@@ -17,6 +28,16 @@ type Config struct {
 	HashCost int
 	// Location for date / time calculations, defaults to Europe/Berlin
 	Location *time.Location
+	// Maximum size of uploaded files in bytes
+	UploadMaxSize int64
+	// Maximum dimensions of uploaded images in pixels
+	ImageMaxWidth  int
+	ImageMaxHeight int
+	// Maximum number of support requests an account can submit within SupportRequestLimitInterval
+	SupportRequestLimit         int
+	SupportRequestLimitInterval time.Duration
+	// Deleted accounts and organisations can be restored within this duration, they are purged afterwards
+	SoftDeleteRetention time.Duration
 }
 
 func DefaultConfig() Config {
@@ -25,9 +46,18 @@ func DefaultConfig() Config {
 		panic(err)
 	}
 	return Config{
-		AppName:  "myproject",
-		HashCost: defaultHashCost,
-		Location: location,
+		AppName:       "myproject",
+		HashCost:      defaultHashCost,
+		Location:      location,
+		UploadMaxSize: defaultUploadMaxSize,
+
+		ImageMaxWidth:  defaultImageMaxDimension,
+		ImageMaxHeight: defaultImageMaxDimension,
+
+		SupportRequestLimit:         defaultSupportRequestLimit,
+		SupportRequestLimitInterval: defaultSupportRequestLimitInterval,
+
+		SoftDeleteRetention: defaultSoftDeleteRetention,
 	}
 }
 func (c Config) BuildURL(path string) string {
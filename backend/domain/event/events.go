@@ -0,0 +1,158 @@
+package event
+
+import (
+	"encoding/json"
+	std_errors "errors"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain/types"
+)
+
+var ErrUnknownEventType = std_errors.New("unknown event type")
+
+// Event is something that happened in the domain.
+// Events are appended to the outbox in the transaction of the change and delivered to subscribers afterwards.
+type Event interface {
+	EventType() string
+}
+
+const (
+	TypeAccountCreated       = "AccountCreated"
+	TypeAccountUpdated       = "AccountUpdated"
+	TypeAccountDeleted       = "AccountDeleted"
+	TypeAccountRestored      = "AccountRestored"
+	TypeOrganisationCreated  = "OrganisationCreated"
+	TypeOrganisationUpdated  = "OrganisationUpdated"
+	TypeOrganisationDeleted  = "OrganisationDeleted"
+	TypeOrganisationRestored = "OrganisationRestored"
+)
+
+type AccountCreated struct {
+	AccountID      uuid.UUID     `json:"accountId"`
+	OrganisationID uuid.NullUUID `json:"organisationId"`
+	EmailAddress   string        `json:"emailAddress"`
+	Role           types.Role    `json:"role"`
+}
+
+func (AccountCreated) EventType() string {
+	return TypeAccountCreated
+}
+
+type AccountUpdated struct {
+	AccountID      uuid.UUID     `json:"accountId"`
+	OrganisationID uuid.NullUUID `json:"organisationId"`
+	EmailAddress   string        `json:"emailAddress"`
+	Role           types.Role    `json:"role"`
+}
+
+func (AccountUpdated) EventType() string {
+	return TypeAccountUpdated
+}
+
+// AccountDeleted is also recorded for accounts that are deleted together with their organisation
+type AccountDeleted struct {
+	AccountID      uuid.UUID     `json:"accountId"`
+	OrganisationID uuid.NullUUID `json:"organisationId"`
+}
+
+func (AccountDeleted) EventType() string {
+	return TypeAccountDeleted
+}
+
+// AccountRestored is also recorded for accounts that are restored together with their organisation
+type AccountRestored struct {
+	AccountID      uuid.UUID     `json:"accountId"`
+	OrganisationID uuid.NullUUID `json:"organisationId"`
+}
+
+func (AccountRestored) EventType() string {
+	return TypeAccountRestored
+}
+
+type OrganisationCreated struct {
+	OrganisationID uuid.UUID `json:"organisationId"`
+	Name           string    `json:"name"`
+}
+
+func (OrganisationCreated) EventType() string {
+	return TypeOrganisationCreated
+}
+
+type OrganisationUpdated struct {
+	OrganisationID uuid.UUID `json:"organisationId"`
+	Name           string    `json:"name"`
+}
+
+func (OrganisationUpdated) EventType() string {
+	return TypeOrganisationUpdated
+}
+
+type OrganisationDeleted struct {
+	OrganisationID uuid.UUID `json:"organisationId"`
+	Name           string    `json:"name"`
+}
+
+func (OrganisationDeleted) EventType() string {
+	return TypeOrganisationDeleted
+}
+
+type OrganisationRestored struct {
+	OrganisationID uuid.UUID `json:"organisationId"`
+	Name           string    `json:"name"`
+}
+
+func (OrganisationRestored) EventType() string {
+	return TypeOrganisationRestored
+}
+
+// Types returns all known event types
+func Types() []string {
+	return []string{
+		TypeAccountCreated,
+		TypeAccountUpdated,
+		TypeAccountDeleted,
+		TypeAccountRestored,
+		TypeOrganisationCreated,
+		TypeOrganisationUpdated,
+		TypeOrganisationDeleted,
+		TypeOrganisationRestored,
+	}
+}
+
+// IsKnownType checks if eventType is one of the known event types
+func IsKnownType(eventType string) bool {
+	_, ok := decoders[eventType]
+	return ok
+}
+
+//nolint:gochecknoglobals
+var decoders = map[string]func(payload []byte) (Event, error){
+	TypeAccountCreated:       decodeAs[AccountCreated],
+	TypeAccountUpdated:       decodeAs[AccountUpdated],
+	TypeAccountDeleted:       decodeAs[AccountDeleted],
+	TypeAccountRestored:      decodeAs[AccountRestored],
+	TypeOrganisationCreated:  decodeAs[OrganisationCreated],
+	TypeOrganisationUpdated:  decodeAs[OrganisationUpdated],
+	TypeOrganisationDeleted:  decodeAs[OrganisationDeleted],
+	TypeOrganisationRestored: decodeAs[OrganisationRestored],
+}
+
+// Decode decodes the JSON payload of an event by its type
+func Decode(eventType string, payload []byte) (Event, error) {
+	decode, ok := decoders[eventType]
+	if !ok {
+		return nil, errors.Wrap(ErrUnknownEventType, eventType)
+	}
+	return decode(payload)
+}
+
+func decodeAs[E Event](payload []byte) (Event, error) {
+	var e E
+	err := json.Unmarshal(payload, &e)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding %s", e.EventType())
+	}
+	return e, nil
+}
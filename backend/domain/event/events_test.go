@@ -0,0 +1,49 @@
+package event_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/domain/event"
+	"myvendor.mytld/myproject/backend/domain/types"
+)
+
+func TestDecode(t *testing.T) {
+	events := []event.Event{
+		event.AccountCreated{
+			AccountID:      uuid.Must(uuid.FromString("3ad082c7-cbda-49e1-a707-c53e1962be65")),
+			OrganisationID: uuid.NullUUID{Valid: true, UUID: uuid.Must(uuid.FromString("6330de58-2761-411e-a243-bec6d0c53876"))},
+			EmailAddress:   "admin+acmeinc@example.com",
+			Role:           types.RoleOrganisationAdministrator,
+		},
+		event.AccountDeleted{
+			AccountID: uuid.Must(uuid.FromString("d7037ad0-d4bb-4dcc-8759-d82fbb3354e8")),
+		},
+		event.AccountRestored{
+			AccountID:      uuid.Must(uuid.FromString("3ad082c7-cbda-49e1-a707-c53e1962be65")),
+			OrganisationID: uuid.NullUUID{Valid: true, UUID: uuid.Must(uuid.FromString("6330de58-2761-411e-a243-bec6d0c53876"))},
+		},
+		event.OrganisationDeleted{
+			OrganisationID: uuid.Must(uuid.FromString("6330de58-2761-411e-a243-bec6d0c53876")),
+			Name:           "Acme Inc.",
+		},
+	}
+
+	for _, e := range events {
+		t.Run(e.EventType(), func(t *testing.T) {
+			payload, err := json.Marshal(e)
+			require.NoError(t, err)
+
+			decoded, err := event.Decode(e.EventType(), payload)
+			require.NoError(t, err)
+			assert.Equal(t, e, decoded)
+		})
+	}
+
+	_, err := event.Decode("SomethingHappened", []byte(`{}`))
+	require.ErrorIs(t, err, event.ErrUnknownEventType)
+}
@@ -15,7 +15,7 @@ const accountSecretLength = 32
 type Account struct {
 	construct.Table `table_name:"accounts"`
 
-	ID             uuid.UUID     `read_col:"accounts.account_id" write_col:"account_id"`
+	ID             uuid.UUID     `read_col:"accounts.account_id,sortable" write_col:"account_id"`
 	EmailAddress   string        `read_col:"accounts.email_address,sortable" write_col:"email_address"`
 	Secret         []byte        `read_col:"accounts.secret" write_col:"secret"`
 	PasswordHash   []byte        `read_col:"accounts.password_hash" write_col:"password_hash"`
@@ -25,6 +25,10 @@ type Account struct {
 
 	CreatedAt time.Time `read_col:"accounts.created_at,sortable"`
 	UpdatedAt time.Time `read_col:"accounts.updated_at,sortable"`
+	// Version is incremented on every update, it is used to detect concurrent changes
+	Version int `read_col:"accounts.version"`
+	// DeletedAt is set for soft deleted records, they are purged after a retention
+	DeletedAt *time.Time `read_col:"accounts.deleted_at" write_col:"deleted_at"`
 
 	// Organisation that the account is assigned to (if not system administrator), is side-loaded
 	Organisation *Organisation
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/construct/v2"
+)
+
+// Asset is the metadata of an uploaded file, the content is kept in a storage under StorageKey
+type Asset struct {
+	construct.Table `table_name:"assets"`
+
+	ID uuid.UUID `read_col:"assets.asset_id,sortable" write_col:"asset_id"`
+	// OrganisationID of the owner organisation, not set for assets of system administrators
+	OrganisationID uuid.NullUUID `read_col:"assets.organisation_id" write_col:"organisation_id"`
+	StorageKey     string        `read_col:"assets.storage_key" write_col:"storage_key"`
+	Filename       string        `read_col:"assets.filename,sortable" write_col:"filename"`
+	ContentType    string        `read_col:"assets.content_type,sortable" write_col:"content_type"`
+	// Size in bytes
+	Size int64 `read_col:"assets.size,sortable" write_col:"size"`
+	// Checksum is the hex encoded SHA-256 hash of the content
+	Checksum  string        `read_col:"assets.checksum" write_col:"checksum"`
+	CreatedBy uuid.NullUUID `read_col:"assets.created_by" write_col:"created_by"`
+	// Width and height in pixels, only set for images
+	Width  *int `read_col:"assets.width" write_col:"width"`
+	Height *int `read_col:"assets.height" write_col:"height"`
+
+	CreatedAt time.Time `read_col:"assets.created_at,sortable"`
+}
+
+// IsImage checks if dimensions are known, which is the case for all uploaded images
+func (a Asset) IsImage() bool {
+	return a.Width != nil && a.Height != nil
+}
+
+// VariantStorageKey is the storage key of a cached image variant in the given content type
+func (a Asset) VariantStorageKey(variantName string, contentType string) string {
+	return "variants/" + a.ID.String() + "/" + variantName + "." + strings.TrimPrefix(contentType, "image/")
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/construct/v2"
+)
+
+// AuditEntry records an administrative command, entries are never changed or deleted
+type AuditEntry struct {
+	construct.Table `table_name:"audit_log"`
+
+	// ID is time ordered, so it can be used for a stable order of entries
+	ID         uuid.UUID `read_col:"audit_log.audit_entry_id,sortable" write_col:"audit_entry_id"`
+	OccurredAt time.Time `read_col:"audit_log.occurred_at,sortable" write_col:"occurred_at"`
+
+	// ActorAccountID is the authenticated account, it is not set for commands of the CLI
+	ActorAccountID uuid.NullUUID `read_col:"audit_log.actor_account_id" write_col:"actor_account_id"`
+	// ImpersonatorAccountID is set if another account acted on behalf of the actor
+	ImpersonatorAccountID uuid.NullUUID `read_col:"audit_log.impersonator_account_id" write_col:"impersonator_account_id"`
+	OrganisationID        uuid.NullUUID `read_col:"audit_log.organisation_id" write_col:"organisation_id"`
+
+	CommandType string `read_col:"audit_log.command_type,sortable" write_col:"command_type"`
+	// TargetIDs are the records affected by the command
+	TargetIDs []uuid.UUID `read_col:"audit_log.target_ids" write_col:"target_ids"`
+	// Changes maps changed fields to their values before and after the command
+	Changes json.RawMessage `read_col:"audit_log.changes" write_col:"changes"`
+
+	RequestID *string `read_col:"audit_log.request_id" write_col:"request_id"`
+	IPAddress *string `read_col:"audit_log.ip_address" write_col:"ip_address"`
+}
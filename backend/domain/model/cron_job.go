@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"github.com/networkteam/construct/v2"
+)
+
+type CronRunStatus string
+
+const (
+	CronRunStatusSucceeded CronRunStatus = "succeeded"
+	CronRunStatusFailed    CronRunStatus = "failed"
+)
+
+// CronJob is the state of a scheduled job that is shared by all instances
+type CronJob struct {
+	construct.Table `table_name:"cron_jobs"`
+
+	Name      string    `read_col:"cron_jobs.name,sortable" write_col:"name"`
+	Schedule  string    `read_col:"cron_jobs.schedule" write_col:"schedule"`
+	NextRunAt time.Time `read_col:"cron_jobs.next_run_at,sortable" write_col:"next_run_at"`
+
+	LastRunAt      *time.Time     `read_col:"cron_jobs.last_run_at,sortable" write_col:"last_run_at"`
+	LastFinishedAt *time.Time     `read_col:"cron_jobs.last_finished_at" write_col:"last_finished_at"`
+	LastStatus     *CronRunStatus `read_col:"cron_jobs.last_status" write_col:"last_status"`
+	LastError      *string        `read_col:"cron_jobs.last_error" write_col:"last_error"`
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/construct/v2"
+)
+
+// IdempotencyKey stores the result of a request by a client provided key, so a retry of the request gets the same result
+type IdempotencyKey struct {
+	construct.Table `table_name:"idempotency_keys"`
+
+	AccountID uuid.UUID `read_col:"idempotency_keys.account_id" write_col:"account_id"`
+	Key       string    `read_col:"idempotency_keys.key" write_col:"key"`
+	// RequestHash identifies the request, a key must not be used for a different request
+	RequestHash string `read_col:"idempotency_keys.request_hash" write_col:"request_hash"`
+	// Response is the serialized response, it is only set after the request is completed
+	Response json.RawMessage `read_col:"idempotency_keys.response" write_col:"response"`
+
+	CreatedAt   time.Time  `read_col:"idempotency_keys.created_at,sortable" write_col:"created_at"`
+	CompletedAt *time.Time `read_col:"idempotency_keys.completed_at" write_col:"completed_at"`
+	// ExpiresAt is the end of the retention, after that the key can be used again
+	ExpiresAt time.Time `read_col:"idempotency_keys.expires_at,sortable" write_col:"expires_at"`
+}
+
+// Completed tells if the response of the request is stored
+func (k IdempotencyKey) Completed() bool {
+	return k.CompletedAt != nil
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/construct/v2"
+)
+
+type JobStatus string
+
+const (
+	// JobStatusPending jobs are run when RunAt is reached
+	JobStatusPending JobStatus = "pending"
+	// JobStatusRunning jobs are claimed by a worker until LockedUntil, after that they can be claimed again
+	JobStatusRunning JobStatus = "running"
+	// JobStatusCompleted jobs were handled successfully
+	JobStatusCompleted JobStatus = "completed"
+	// JobStatusFailed jobs failed MaxAttempts times and are not retried
+	JobStatusFailed JobStatus = "failed"
+)
+
+// Job is a unit of background work that is stored in the job queue
+type Job struct {
+	construct.Table `table_name:"jobs"`
+
+	ID      uuid.UUID       `read_col:"jobs.job_id" write_col:"job_id"`
+	JobType string          `read_col:"jobs.job_type,sortable" write_col:"job_type"`
+	Payload json.RawMessage `read_col:"jobs.payload" write_col:"payload"`
+	// UniqueKey prevents enqueueing another job of the same type while one with the same key is pending or running
+	UniqueKey *string `read_col:"jobs.unique_key" write_col:"unique_key"`
+
+	Status      JobStatus  `read_col:"jobs.status,sortable" write_col:"status"`
+	Attempts    int        `read_col:"jobs.attempts" write_col:"attempts"`
+	MaxAttempts int        `read_col:"jobs.max_attempts" write_col:"max_attempts"`
+	RunAt       time.Time  `read_col:"jobs.run_at,sortable" write_col:"run_at"`
+	LockedUntil *time.Time `read_col:"jobs.locked_until" write_col:"locked_until"`
+	LastError   *string    `read_col:"jobs.last_error" write_col:"last_error"`
+
+	CreatedAt   time.Time  `read_col:"jobs.created_at,sortable" write_col:"created_at"`
+	CompletedAt *time.Time `read_col:"jobs.completed_at" write_col:"completed_at"`
+}
@@ -10,9 +10,13 @@ import (
 type Organisation struct {
 	construct.Table `table_name:"organisations"`
 
-	ID   uuid.UUID `read_col:"organisations.organisation_id" write_col:"organisation_id"`
+	ID   uuid.UUID `read_col:"organisations.organisation_id,sortable" write_col:"organisation_id"`
 	Name string    `read_col:"organisations.name,sortable" write_col:"name"`
 
 	CreatedAt time.Time `read_col:"organisations.created_at,sortable"`
 	UpdatedAt time.Time `read_col:"organisations.updated_at,sortable"`
+	// Version is incremented on every update, it is used to detect concurrent changes
+	Version int `read_col:"organisations.version"`
+	// DeletedAt is set for soft deleted records, they are purged after a retention
+	DeletedAt *time.Time `read_col:"organisations.deleted_at" write_col:"deleted_at"`
 }
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/construct/v2"
+)
+
+type OutboxEventStatus string
+
+const (
+	// OutboxEventStatusPending events are delivered when NextAttemptAt is reached
+	OutboxEventStatusPending OutboxEventStatus = "pending"
+	// OutboxEventStatusDelivered events were handled by all subscribers
+	OutboxEventStatusDelivered OutboxEventStatus = "delivered"
+	// OutboxEventStatusDead events failed too often and are not retried until they are requeued
+	OutboxEventStatusDead OutboxEventStatus = "dead"
+)
+
+// OutboxEvent is a domain event that was recorded in the transaction of a change and is delivered to subscribers asynchronously
+type OutboxEvent struct {
+	construct.Table `table_name:"outbox_events"`
+
+	ID        uuid.UUID       `read_col:"outbox_events.event_id" write_col:"event_id"`
+	EventType string          `read_col:"outbox_events.event_type,sortable" write_col:"event_type"`
+	Payload   json.RawMessage `read_col:"outbox_events.payload" write_col:"payload"`
+
+	Status        OutboxEventStatus `read_col:"outbox_events.status,sortable" write_col:"status"`
+	Attempts      int               `read_col:"outbox_events.attempts" write_col:"attempts"`
+	NextAttemptAt time.Time         `read_col:"outbox_events.next_attempt_at,sortable" write_col:"next_attempt_at"`
+	LastError     *string           `read_col:"outbox_events.last_error" write_col:"last_error"`
+
+	OccurredAt  time.Time  `read_col:"outbox_events.occurred_at,sortable" write_col:"occurred_at"`
+	DeliveredAt *time.Time `read_col:"outbox_events.delivered_at" write_col:"delivered_at"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+
+	"github.com/networkteam/construct/v2"
+)
+
+// PersistedQuery is an allowlisted GraphQL document that is identified by its SHA-256 hash
+type PersistedQuery struct {
+	construct.Table `table_name:"persisted_queries"`
+
+	Hash     string `read_col:"persisted_queries.hash,sortable" write_col:"hash"`
+	Document string `read_col:"persisted_queries.document" write_col:"document"`
+
+	CreatedAt time.Time `read_col:"persisted_queries.created_at,sortable"`
+}
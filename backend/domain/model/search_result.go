@@ -0,0 +1,14 @@
+package model
+
+// SearchResult is an account or organisation found by a full-text search, only one of the records is set
+type SearchResult struct {
+	Account      *Account
+	Organisation *Organisation
+
+	// Rank is the relevance for the search term, higher is better
+	Rank float64
+	// Text is the searched text of the record (e.g. the email address of an account)
+	Text string
+	// Highlight is the HTML escaped text with matched terms wrapped in <b> tags
+	Highlight string
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/construct/v2"
+)
+
+// SupportRequest records a submitted support request for rate limiting, the request itself is sent by mail
+type SupportRequest struct {
+	construct.Table `table_name:"support_requests"`
+
+	ID        uuid.UUID `read_col:"support_requests.support_request_id" write_col:"support_request_id"`
+	AccountID uuid.UUID `read_col:"support_requests.account_id" write_col:"account_id"`
+	Subject   string    `read_col:"support_requests.subject" write_col:"subject"`
+
+	CreatedAt time.Time `read_col:"support_requests.created_at,sortable" write_col:"created_at"`
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/construct/v2"
+)
+
+// WebhookEndpoint receives events of an organisation by HTTP
+type WebhookEndpoint struct {
+	construct.Table `table_name:"webhook_endpoints"`
+
+	ID             uuid.UUID `read_col:"webhook_endpoints.webhook_endpoint_id,sortable" write_col:"webhook_endpoint_id"`
+	OrganisationID uuid.UUID `read_col:"webhook_endpoints.organisation_id" write_col:"organisation_id"`
+	URL            string    `read_col:"webhook_endpoints.url,sortable" write_col:"url"`
+	// Secret is the key for signing requests, so receivers can verify the origin
+	Secret string `read_col:"webhook_endpoints.secret" write_col:"secret"`
+	// EventTypes the endpoint is subscribed to
+	EventTypes []string `read_col:"webhook_endpoints.event_types" write_col:"event_types"`
+
+	CreatedAt time.Time `read_col:"webhook_endpoints.created_at,sortable"`
+	UpdatedAt time.Time `read_col:"webhook_endpoints.updated_at,sortable"`
+}
+
+// SubscribesTo checks if the endpoint is subscribed to an event type
+func (e WebhookEndpoint) SubscribesTo(eventType string) bool {
+	for _, t := range e.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryStatusPending deliveries are sent or retried by a job
+	WebhookDeliveryStatusPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryStatusSucceeded deliveries got a 2xx response
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	// WebhookDeliveryStatusFailed deliveries were not accepted after all attempts, they can be redelivered
+	WebhookDeliveryStatusFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is the log entry of sending an event to a webhook endpoint
+type WebhookDelivery struct {
+	construct.Table `table_name:"webhook_deliveries"`
+
+	ID                uuid.UUID `read_col:"webhook_deliveries.webhook_delivery_id,sortable" write_col:"webhook_delivery_id"`
+	WebhookEndpointID uuid.UUID `read_col:"webhook_deliveries.webhook_endpoint_id" write_col:"webhook_endpoint_id"`
+	OrganisationID    uuid.UUID `read_col:"webhook_deliveries.organisation_id" write_col:"organisation_id"`
+	EventID           uuid.UUID `read_col:"webhook_deliveries.event_id" write_col:"event_id"`
+	EventType         string    `read_col:"webhook_deliveries.event_type,sortable" write_col:"event_type"`
+	// Payload is the request body, it is sent unchanged on every attempt
+	Payload json.RawMessage `read_col:"webhook_deliveries.payload" write_col:"payload"`
+
+	Status   WebhookDeliveryStatus `read_col:"webhook_deliveries.status,sortable" write_col:"status"`
+	Attempts int                   `read_col:"webhook_deliveries.attempts" write_col:"attempts"`
+	// ResponseStatus is the HTTP status code of the last attempt, not set if no response was received
+	ResponseStatus *int    `read_col:"webhook_deliveries.response_status" write_col:"response_status"`
+	LastError      *string `read_col:"webhook_deliveries.last_error" write_col:"last_error"`
+
+	CreatedAt     time.Time  `read_col:"webhook_deliveries.created_at,sortable" write_col:"created_at"`
+	LastAttemptAt *time.Time `read_col:"webhook_deliveries.last_attempt_at,sortable" write_col:"last_attempt_at"`
+	DeliveredAt   *time.Time `read_col:"webhook_deliveries.delivered_at" write_col:"delivered_at"`
+}
@@ -21,6 +21,8 @@ type AccountsQuery struct {
 	IDs            []uuid.UUID
 	SearchTerm     string
 	OrganisationID *uuid.UUID
+	// WithDeleted includes soft deleted accounts (system administrators only)
+	WithDeleted bool
 }
 
 func (f *AccountsQuery) SetOrganisationID(organisationID *uuid.UUID) {
@@ -31,3 +33,11 @@ type AccountQueryOpts struct {
 	IncludeOrganisation   bool
 	OrganisationQueryOpts *OrganisationQueryOpts
 }
+
+type AccountChangedSubscription struct {
+	OrganisationID *uuid.UUID
+}
+
+func (f *AccountChangedSubscription) SetOrganisationID(organisationID *uuid.UUID) {
+	f.OrganisationID = organisationID
+}
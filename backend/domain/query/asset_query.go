@@ -0,0 +1,7 @@
+package query
+
+import "github.com/gofrs/uuid"
+
+type AssetQuery struct {
+	AssetID uuid.UUID
+}
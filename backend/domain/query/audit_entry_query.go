@@ -0,0 +1,21 @@
+package query
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type AuditEntriesQuery struct {
+	OrganisationID *uuid.UUID
+	ActorAccountID *uuid.UUID
+	CommandType    *string
+	TargetID       *uuid.UUID
+	// OccurredFrom is inclusive, OccurredTo exclusive
+	OccurredFrom *time.Time
+	OccurredTo   *time.Time
+}
+
+func (f *AuditEntriesQuery) SetOrganisationID(organisationID *uuid.UUID) {
+	f.OrganisationID = organisationID
+}
@@ -14,6 +14,8 @@ type OrganisationsQuery struct {
 	Opts       *OrganisationQueryOpts
 	IDs        []uuid.UUID
 	SearchTerm string
+	// WithDeleted includes soft deleted organisations (system administrators only)
+	WithDeleted bool
 }
 
 func (f *OrganisationsQuery) SetOrganisationID(organisationID *uuid.UUID) {
@@ -23,3 +25,11 @@ func (f *OrganisationsQuery) SetOrganisationID(organisationID *uuid.UUID) {
 		f.IDs = nil
 	}
 }
+
+type OrganisationChangedSubscription struct {
+	OrganisationID *uuid.UUID
+}
+
+func (f *OrganisationChangedSubscription) SetOrganisationID(organisationID *uuid.UUID) {
+	f.OrganisationID = organisationID
+}
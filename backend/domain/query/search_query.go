@@ -0,0 +1,7 @@
+package query
+
+// SearchQuery searches accounts and organisations with full-text search
+type SearchQuery struct {
+	SearchTerm string
+	Limit      int
+}
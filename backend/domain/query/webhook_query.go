@@ -0,0 +1,30 @@
+package query
+
+import (
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+)
+
+type WebhookEndpointQuery struct {
+	WebhookEndpointID uuid.UUID
+}
+
+type WebhookEndpointsQuery struct {
+	OrganisationID *uuid.UUID
+}
+
+func (f *WebhookEndpointsQuery) SetOrganisationID(organisationID *uuid.UUID) {
+	f.OrganisationID = organisationID
+}
+
+type WebhookDeliveryQuery struct {
+	WebhookDeliveryID uuid.UUID
+}
+
+// WebhookDeliveriesQuery lists the deliveries of an endpoint, the organisation of the endpoint is needed for authorization
+type WebhookDeliveriesQuery struct {
+	OrganisationID    uuid.UUID
+	WebhookEndpointID uuid.UUID
+	Status            *model.WebhookDeliveryStatus
+}
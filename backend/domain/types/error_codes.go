@@ -13,7 +13,10 @@ const ErrorCodeExpired = "expired"
 const ErrorCodeNotConfirmed = "notConfirmed"
 const ErrorCodeAlreadyConfirmed = "alreadyConfirmed"
 const ErrorCodeUploadLimitExceeded = "uploadLimitExceeded"
+const ErrorCodeContentTypeNotAllowed = "contentTypeNotAllowed"
+const ErrorCodeRateLimitExceeded = "rateLimitExceeded"
 const ErrorCodeImageWidthMustBeAtMost = "imageWidthMustBeAtMost"
 const ErrorCodeImageHeightMustBeAtMost = "imageHeightMustBeAtMost"
 const ErrorCodeInsufficientPoints = "insufficientPoints"
 const ErrorCodeNotActivated = "notActivated"
+const ErrorCodeConflict = "conflict"
@@ -2,8 +2,10 @@ package finder
 
 import (
 	"context"
+	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
 
 	"myvendor.mytld/myproject/backend/domain/model"
 	domain_query "myvendor.mytld/myproject/backend/domain/query"
@@ -13,7 +15,20 @@ import (
 )
 
 func (f *Finder) QueryAccount(ctx context.Context, query domain_query.AccountQuery) (model.Account, error) {
-	record, err := repository.FindAccountByID(ctx, f.executor, query.AccountID, query.Opts)
+	record, err := repository.FindAccountByID(ctx, f.executorFor(ctx), query.AccountID, query.Opts)
+	if err != nil {
+		return record, err
+	}
+	err = authorization.NewAuthorizer(authentication.GetAuthContext(ctx)).AllowsAccountView(record)
+	if err != nil {
+		return record, err
+	}
+	return record, nil
+}
+
+// QueryDeletedAccount finds a soft deleted account (e.g. for restoring it)
+func (f *Finder) QueryDeletedAccount(ctx context.Context, query domain_query.AccountQuery) (model.Account, error) {
+	record, err := repository.FindDeletedAccountByID(ctx, f.executorFor(ctx), query.AccountID, query.Opts)
 	if err != nil {
 		return record, err
 	}
@@ -26,11 +41,11 @@ func (f *Finder) QueryAccount(ctx context.Context, query domain_query.AccountQue
 
 func (f *Finder) QueryAccountNotAuthorized(ctx context.Context, query domain_query.AccountQueryNotAuthorized) (model.Account, error) {
 	if query.AccountID != nil {
-		return repository.FindAccountByID(ctx, f.executor, *query.AccountID, query.Opts)
+		return repository.FindAccountByID(ctx, f.executorFor(ctx), *query.AccountID, query.Opts)
 	}
 
 	if query.EmailAddress != nil {
-		return repository.FindAccountByEmailAddress(ctx, f.executor, *query.EmailAddress, query.Opts)
+		return repository.FindAccountByEmailAddress(ctx, f.executorFor(ctx), *query.EmailAddress, query.Opts)
 	}
 
 	return model.Account{}, errors.Wrap(ErrInvalidQuery, "AccountID or EmailAddress must be set")
@@ -43,11 +58,13 @@ func (f *Finder) QueryAccounts(ctx context.Context, query domain_query.AccountsQ
 		return nil, err
 	}
 
-	return repository.FindAllAccounts(ctx, f.executor, repository.AccountsFilter{
+	return repository.FindAllAccounts(ctx, f.executorFor(ctx), repository.AccountsFilter{
 		Opts:           query.Opts,
 		OrganisationID: query.OrganisationID,
 		IDs:            query.IDs,
 		SearchTerm:     query.SearchTerm,
+		OrderByRank:    paging.SortField == nil,
+		WithDeleted:    query.WithDeleted,
 	}, paging.options()...)
 }
 
@@ -58,9 +75,98 @@ func (f *Finder) CountAccounts(ctx context.Context, query domain_query.AccountsQ
 		return 0, err
 	}
 
-	return repository.CountAccounts(ctx, f.executor, repository.AccountsFilter{
+	return repository.CountAccounts(ctx, f.executorFor(ctx), repository.AccountsFilter{
 		OrganisationID: query.OrganisationID,
 		IDs:            query.IDs,
 		SearchTerm:     query.SearchTerm,
+		WithDeleted:    query.WithDeleted,
 	})
 }
+
+//nolint:gochecknoglobals
+var accountCursorValues = cursorValueFuncs[model.Account]{
+	"id":           func(model.Account) string { return "" },
+	"emailaddress": func(record model.Account) string { return record.EmailAddress },
+	"role":         func(record model.Account) string { return string(record.Role) },
+	"createdat":    func(record model.Account) string { return record.CreatedAt.Format(time.RFC3339Nano) },
+	"updatedat":    func(record model.Account) string { return record.UpdatedAt.Format(time.RFC3339Nano) },
+}
+
+func (f *Finder) QueryAccountsConnection(ctx context.Context, query domain_query.AccountsQuery, paging CursorPaging) (Connection[model.Account], error) {
+	authorizer := authorization.NewAuthorizer(authentication.GetAuthContext(ctx))
+	err := authorizer.AllowsAndFilterAllAccountsQuery(&query)
+	if err != nil {
+		return Connection[model.Account]{}, err
+	}
+
+	return queryConnection(
+		paging,
+		accountCursorValues,
+		func(record model.Account) uuid.UUID { return record.ID },
+		func(pagingOpts ...repository.PagingOption) ([]model.Account, error) {
+			return repository.FindAllAccounts(ctx, f.executorFor(ctx), repository.AccountsFilter{
+				Opts:           query.Opts,
+				OrganisationID: query.OrganisationID,
+				IDs:            query.IDs,
+				SearchTerm:     query.SearchTerm,
+				WithDeleted:    query.WithDeleted,
+			}, pagingOpts...)
+		},
+	)
+}
+
+// QueryAccountsByOrganisationIDs fetches the accounts of multiple organisations with a single query for batched loading.
+// Accounts and errors are returned in the order of organisationIDs. The per page limit of the paging is applied to
+// each organisation, the page is ignored.
+// Viewing the organisation is checked for every organisation and accounts that cannot be viewed are skipped.
+func (f *Finder) QueryAccountsByOrganisationIDs(ctx context.Context, organisationIDs []uuid.UUID, opts *domain_query.AccountQueryOpts, paging Paging) ([][]model.Account, []error) {
+	records := make([][]model.Account, len(organisationIDs))
+	errs := make([]error, len(organisationIDs))
+
+	sortPaging := Paging{
+		SortField: paging.SortField,
+		SortOrder: paging.SortOrder,
+	}
+	filter := repository.AccountsFilter{
+		Opts:            opts,
+		OrganisationIDs: organisationIDs,
+	}
+	var (
+		result []model.Account
+		err    error
+	)
+	if paging.PerPage != nil {
+		result, err = repository.FindAccountsPerOrganisation(ctx, f.executorFor(ctx), filter, *paging.PerPage, sortPaging.options()...)
+	} else {
+		result, err = repository.FindAllAccounts(ctx, f.executorFor(ctx), filter, sortPaging.options()...)
+	}
+	if err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+		return records, errs
+	}
+
+	authorizer := authorization.NewAuthorizer(authentication.GetAuthContext(ctx))
+
+	recordsByOrganisationID := make(map[uuid.UUID][]model.Account, len(organisationIDs))
+	for _, record := range result {
+		if authorizer.AllowsAccountView(record) != nil {
+			continue
+		}
+		recordsByOrganisationID[record.OrganisationID.UUID] = append(recordsByOrganisationID[record.OrganisationID.UUID], record)
+	}
+
+	for i, organisationID := range organisationIDs {
+		if err := authorizer.AllowsOrganisationView(model.Organisation{ID: organisationID}); err != nil {
+			errs[i] = err
+			continue
+		}
+		records[i] = recordsByOrganisationID[organisationID]
+		if records[i] == nil {
+			records[i] = []model.Account{}
+		}
+	}
+
+	return records, errs
+}
@@ -0,0 +1,28 @@
+package finder
+
+import (
+	"context"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+	domain_query "myvendor.mytld/myproject/backend/domain/query"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/security/authentication"
+	"myvendor.mytld/myproject/backend/security/authorization"
+)
+
+func (f *Finder) QueryAsset(ctx context.Context, query domain_query.AssetQuery) (model.Asset, error) {
+	record, err := repository.FindAssetByID(ctx, f.executorFor(ctx), query.AssetID)
+	if err != nil {
+		return record, err
+	}
+	err = authorization.NewAuthorizer(authentication.GetAuthContext(ctx)).AllowsAssetView(record)
+	if err != nil {
+		return record, err
+	}
+	return record, nil
+}
+
+// QueryAssetNotAuthorized fetches an asset without authorization (e.g. for downloads that are authorized by a signed URL)
+func (f *Finder) QueryAssetNotAuthorized(ctx context.Context, query domain_query.AssetQuery) (model.Asset, error) {
+	return repository.FindAssetByID(ctx, f.executorFor(ctx), query.AssetID)
+}
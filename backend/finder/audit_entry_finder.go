@@ -0,0 +1,40 @@
+package finder
+
+import (
+	"context"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+	domain_query "myvendor.mytld/myproject/backend/domain/query"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/security/authentication"
+	"myvendor.mytld/myproject/backend/security/authorization"
+)
+
+func (f *Finder) QueryAuditEntries(ctx context.Context, query domain_query.AuditEntriesQuery, paging Paging) ([]model.AuditEntry, error) {
+	authorizer := authorization.NewAuthorizer(authentication.GetAuthContext(ctx))
+	err := authorizer.AllowsAndFilterAllAuditEntriesQuery(&query)
+	if err != nil {
+		return nil, err
+	}
+	return repository.FindAllAuditEntries(ctx, f.executorFor(ctx), auditEntriesFilter(query), paging.options()...)
+}
+
+func (f *Finder) CountAuditEntries(ctx context.Context, query domain_query.AuditEntriesQuery) (int, error) {
+	authorizer := authorization.NewAuthorizer(authentication.GetAuthContext(ctx))
+	err := authorizer.AllowsAndFilterAllAuditEntriesQuery(&query)
+	if err != nil {
+		return 0, err
+	}
+	return repository.CountAuditEntries(ctx, f.executorFor(ctx), auditEntriesFilter(query))
+}
+
+func auditEntriesFilter(query domain_query.AuditEntriesQuery) repository.AuditEntriesFilter {
+	return repository.AuditEntriesFilter{
+		OrganisationID: query.OrganisationID,
+		ActorAccountID: query.ActorAccountID,
+		CommandType:    query.CommandType,
+		TargetID:       query.TargetID,
+		OccurredFrom:   query.OccurredFrom,
+		OccurredTo:     query.OccurredTo,
+	}
+}
@@ -0,0 +1,127 @@
+package finder
+
+import (
+	"slices"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+// Connection is a window of records for Relay style cursor based pagination
+type Connection[T any] struct {
+	Edges    []Edge[T]
+	PageInfo PageInfo
+}
+
+type Edge[T any] struct {
+	Cursor string
+	Node   T
+}
+
+type PageInfo struct {
+	HasNextPage     bool
+	HasPreviousPage bool
+	StartCursor     *string
+	EndCursor       *string
+}
+
+// cursorValueFuncs maps the sort fields that are supported for cursor based pagination to a function returning the sort value of a record
+type cursorValueFuncs[T any] map[string]func(record T) string
+
+// queryConnection fetches a connection window with keyset pagination by calling find with the paging options.
+// It fetches one record more than requested to tell if there are more records in the paging direction.
+func queryConnection[T any](
+	paging CursorPaging,
+	valueFuncs cursorValueFuncs[T],
+	idFunc func(record T) uuid.UUID,
+	find func(pagingOpts ...repository.PagingOption) ([]T, error),
+) (conn Connection[T], err error) {
+	if paging.First != nil && paging.Last != nil {
+		return conn, errors.Wrap(ErrInvalidQuery, "first and last must not be combined")
+	}
+
+	sortField := paging.sortField()
+	valueFunc, ok := valueFuncs[sortField]
+	if !ok {
+		return conn, errors.Wrap(repository.ErrInvalidSortField, sortField)
+	}
+
+	backward := paging.backward()
+
+	limit, cursorValue := paging.First, paging.After
+	sortOrder := paging.sortOrder()
+	if backward {
+		limit, cursorValue = paging.Last, paging.Before
+		// Fetch in reverse order and reverse the result afterwards
+		if sortOrder == repository.SortOrderDesc {
+			sortOrder = repository.SortOrderAsc
+		} else {
+			sortOrder = repository.SortOrderDesc
+		}
+	}
+	if limit != nil && *limit < 0 {
+		return conn, errors.Wrap(ErrInvalidQuery, "first or last must not be negative")
+	}
+
+	var after *repository.KeysetCursor
+	if cursorValue != nil {
+		c, err := decodeCursor(*cursorValue)
+		if err != nil {
+			return conn, err
+		}
+		if c.SortField != sortField {
+			return conn, errors.Wrap(ErrInvalidCursor, "cursor was created for another sort field")
+		}
+		after = c.keysetCursor()
+	}
+
+	pagingOpts := []repository.PagingOption{
+		repository.WithKeyset(sortField, sortOrder, after),
+	}
+	if limit != nil {
+		pagingOpts = append(pagingOpts, repository.WithLimit(*limit+1))
+	}
+
+	records, err := find(pagingOpts...)
+	if err != nil {
+		return conn, err
+	}
+
+	hasMore := limit != nil && len(records) > *limit
+	if hasMore {
+		records = records[:*limit]
+	}
+	if backward {
+		slices.Reverse(records)
+	}
+
+	conn.Edges = make([]Edge[T], len(records))
+	for i, record := range records {
+		conn.Edges[i] = Edge[T]{
+			Cursor: encodeCursor(cursor{
+				SortField: sortField,
+				SortValue: valueFunc(record),
+				ID:        idFunc(record),
+			}),
+			Node: record,
+		}
+	}
+
+	if len(conn.Edges) > 0 {
+		conn.PageInfo.StartCursor = &conn.Edges[0].Cursor
+		conn.PageInfo.EndCursor = &conn.Edges[len(conn.Edges)-1].Cursor
+	}
+
+	// There is at least the record of the cursor itself in the opposite direction
+	if backward {
+		conn.PageInfo.HasPreviousPage = hasMore
+		conn.PageInfo.HasNextPage = paging.Before != nil
+	} else {
+		conn.PageInfo.HasNextPage = hasMore
+		conn.PageInfo.HasPreviousPage = paging.After != nil
+	}
+
+	return conn, nil
+}
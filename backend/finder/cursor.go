@@ -0,0 +1,45 @@
+package finder
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+// cursor is the position of a record in a connection, it is encoded as an opaque string for clients
+type cursor struct {
+	// SortField is stored to detect cursors that are used with a different sort order
+	SortField string    `json:"f"`
+	SortValue string    `json:"v,omitempty"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c) //nolint:errchkjson // Marshalling strings and a UUID cannot fail
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	var c cursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err = json.Unmarshal(data, &c); err != nil {
+		return c, ErrInvalidCursor
+	}
+	if c.ID == uuid.Nil {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+func (c cursor) keysetCursor() *repository.KeysetCursor {
+	return &repository.KeysetCursor{
+		SortValue: c.SortValue,
+		ID:        c.ID,
+	}
+}
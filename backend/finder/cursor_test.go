@@ -0,0 +1,28 @@
+package finder
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	c := cursor{
+		SortField: "createdat",
+		SortValue: "2024-01-02T03:04:05.123456Z",
+		ID:        uuid.Must(uuid.NewV4()),
+	}
+
+	decoded, err := decodeCursor(encodeCursor(c))
+	require.NoError(t, err)
+	assert.Equal(t, c, decoded)
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	for _, s := range []string{"", "not base64!", "bm90IGpzb24", "e30"} {
+		_, err := decodeCursor(s)
+		assert.ErrorIs(t, err, ErrInvalidCursor, s)
+	}
+}
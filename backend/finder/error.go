@@ -3,3 +3,5 @@ package finder
 import "errors"
 
 var ErrInvalidQuery = errors.New("invalid query")
+
+var ErrInvalidCursor = errors.New("invalid cursor")
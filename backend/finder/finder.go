@@ -15,29 +15,53 @@ import (
 // Finder is a higher level executor for queries that includes authorization.
 type Finder struct {
 	executor   qrbsql.Executor
+	replica    *Replica
 	timeSource types.TimeSource
 }
 
+type Option func(f *Finder)
+
+// WithReplica routes queries to a read replica if it is available, a nil replica is ignored
+func WithReplica(replica *Replica) Option {
+	return func(f *Finder) {
+		f.replica = replica
+	}
+}
+
 // NewFinder creates a new Finder.
-func NewFinder(db *sql.DB, timeSource types.TimeSource) *Finder {
-	return &Finder{
+func NewFinder(db *sql.DB, timeSource types.TimeSource, opts ...Option) *Finder {
+	f := &Finder{
 		executor:   db,
 		timeSource: timeSource,
 	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// executorFor returns the executor for queries with the context, which is the replica if it is available and the primary is not required
+func (f *Finder) executorFor(ctx context.Context) qrbsql.Executor {
+	if f.replica != nil && !usePrimary(ctx) && f.replica.Available() {
+		return f.replica.db
+	}
+	return f.executor
 }
 
 var errTransactionalNoSQLDB = std_errors.New("finder: executor for Transactional must be a *sql.DB")
 
+// Transactional runs the callback with a finder using a read-only transaction on the primary.
+// The transaction is retried on serialization failures, which can happen for read-only transactions with SERIALIZABLE isolation.
 func (f *Finder) Transactional(ctx context.Context, isolationLevel sql.IsolationLevel, callback func(txFinder *Finder) error) error {
 	db, ok := f.executor.(*sql.DB)
 	if !ok {
 		return errors.WithStack(errTransactionalNoSQLDB)
 	}
 
-	return repository.TransactionalWithOpts(ctx, db, &sql.TxOptions{
+	return repository.TransactionalWithRetry(ctx, db, &sql.TxOptions{
 		ReadOnly:  true,
 		Isolation: isolationLevel,
-	}, func(tx *sql.Tx) error {
+	}, repository.RetryOpts{}, func(tx *sql.Tx) error {
 		txFinder := &Finder{
 			executor:   tx,
 			timeSource: f.timeSource,
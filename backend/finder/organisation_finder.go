@@ -2,6 +2,9 @@ package finder
 
 import (
 	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
 
 	"myvendor.mytld/myproject/backend/domain/model"
 	domain_query "myvendor.mytld/myproject/backend/domain/query"
@@ -17,7 +20,7 @@ func (f *Finder) QueryOrganisation(ctx context.Context, query domain_query.Organ
 		return model.Organisation{}, err
 	}
 
-	record, err := repository.FindOrganisationByID(ctx, f.executor, query.OrganisationID, query.Opts)
+	record, err := repository.FindOrganisationByID(ctx, f.executorFor(ctx), query.OrganisationID, query.Opts)
 	if err != nil {
 		return record, err
 	}
@@ -30,10 +33,12 @@ func (f *Finder) QueryOrganisations(ctx context.Context, query domain_query.Orga
 	if err != nil {
 		return nil, err
 	}
-	return repository.FindAllOrganisations(ctx, f.executor, repository.OrganisationsFilter{
-		Opts:       query.Opts,
-		IDs:        query.IDs,
-		SearchTerm: query.SearchTerm,
+	return repository.FindAllOrganisations(ctx, f.executorFor(ctx), repository.OrganisationsFilter{
+		Opts:        query.Opts,
+		IDs:         query.IDs,
+		SearchTerm:  query.SearchTerm,
+		OrderByRank: paging.SortField == nil,
+		WithDeleted: query.WithDeleted,
 	}, paging.options()...)
 }
 
@@ -44,8 +49,80 @@ func (f *Finder) CountOrganisations(ctx context.Context, query domain_query.Orga
 		return 0, err
 	}
 
-	return repository.CountOrganisations(ctx, f.executor, repository.OrganisationsFilter{
-		IDs:        query.IDs,
-		SearchTerm: query.SearchTerm,
+	return repository.CountOrganisations(ctx, f.executorFor(ctx), repository.OrganisationsFilter{
+		IDs:         query.IDs,
+		SearchTerm:  query.SearchTerm,
+		WithDeleted: query.WithDeleted,
+	})
+}
+
+//nolint:gochecknoglobals
+var organisationCursorValues = cursorValueFuncs[model.Organisation]{
+	"id":        func(model.Organisation) string { return "" },
+	"name":      func(record model.Organisation) string { return record.Name },
+	"createdat": func(record model.Organisation) string { return record.CreatedAt.Format(time.RFC3339Nano) },
+	"updatedat": func(record model.Organisation) string { return record.UpdatedAt.Format(time.RFC3339Nano) },
+}
+
+func (f *Finder) QueryOrganisationsConnection(ctx context.Context, query domain_query.OrganisationsQuery, paging CursorPaging) (Connection[model.Organisation], error) {
+	authorizer := authorization.NewAuthorizer(authentication.GetAuthContext(ctx))
+	err := authorizer.AllowsAndFilterAllOrganisationsQuery(&query)
+	if err != nil {
+		return Connection[model.Organisation]{}, err
+	}
+
+	return queryConnection(
+		paging,
+		organisationCursorValues,
+		func(record model.Organisation) uuid.UUID { return record.ID },
+		func(pagingOpts ...repository.PagingOption) ([]model.Organisation, error) {
+			return repository.FindAllOrganisations(ctx, f.executorFor(ctx), repository.OrganisationsFilter{
+				Opts:        query.Opts,
+				IDs:         query.IDs,
+				SearchTerm:  query.SearchTerm,
+				WithDeleted: query.WithDeleted,
+			}, pagingOpts...)
+		},
+	)
+}
+
+// QueryOrganisationsByIDs fetches organisations for multiple ids with a single query for batched loading.
+// Records and errors are returned in the order of ids, authorization is checked for every record.
+func (f *Finder) QueryOrganisationsByIDs(ctx context.Context, ids []uuid.UUID, opts *domain_query.OrganisationQueryOpts) ([]model.Organisation, []error) {
+	records := make([]model.Organisation, len(ids))
+	errs := make([]error, len(ids))
+
+	result, err := repository.FindAllOrganisations(ctx, f.executorFor(ctx), repository.OrganisationsFilter{
+		Opts: opts,
+		IDs:  ids,
+		// Deleted accounts (only listed for system administrators) reference their deleted organisation
+		WithDeleted: true,
 	})
+	if err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+		return records, errs
+	}
+
+	recordsByID := make(map[uuid.UUID]model.Organisation, len(result))
+	for _, record := range result {
+		recordsByID[record.ID] = record
+	}
+
+	authorizer := authorization.NewAuthorizer(authentication.GetAuthContext(ctx))
+	for i, id := range ids {
+		record, ok := recordsByID[id]
+		if !ok {
+			errs[i] = repository.ErrNotFound
+			continue
+		}
+		if err := authorizer.AllowsOrganisationView(record); err != nil {
+			errs[i] = err
+			continue
+		}
+		records[i] = record
+	}
+
+	return records, errs
 }
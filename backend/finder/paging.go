@@ -1,6 +1,10 @@
 package finder
 
-import "myvendor.mytld/myproject/backend/persistence/repository"
+import (
+	"strings"
+
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
 
 type Paging struct {
 	Page      int
@@ -29,3 +33,34 @@ func (p Paging) options() []repository.PagingOption {
 	}
 	return opts
 }
+
+// CursorPaging selects a window of a connection for Relay style cursor based pagination.
+// Either First (with an optional After cursor) or Last (with an optional Before cursor) should be set.
+type CursorPaging struct {
+	First     *int
+	After     *string
+	Last      *int
+	Before    *string
+	SortField *string
+	SortOrder *string
+}
+
+const defaultCursorSortField = "id"
+
+func (p CursorPaging) backward() bool {
+	return p.Last != nil || (p.First == nil && p.Before != nil)
+}
+
+func (p CursorPaging) sortField() string {
+	if p.SortField != nil {
+		return strings.ToLower(*p.SortField)
+	}
+	return defaultCursorSortField
+}
+
+func (p CursorPaging) sortOrder() string {
+	if p.SortOrder != nil && *p.SortOrder == repository.SortOrderDesc {
+		return repository.SortOrderDesc
+	}
+	return repository.SortOrderAsc
+}
@@ -0,0 +1,92 @@
+package finder
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"time"
+
+	logger "github.com/apex/log"
+
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+// replicaCheckInterval is the interval for checking the replication lag of a replica
+const replicaCheckInterval = time.Second
+
+// Replica is a read replica (hot standby) of the primary database.
+// Queries are routed to it while its replication lag is known and below the maximum, otherwise they fall back to the primary.
+type Replica struct {
+	db     *sql.DB
+	maxLag time.Duration
+
+	// available is only set by checks, so a replica is not used before its lag is known
+	available atomic.Bool
+}
+
+// NewReplica creates a replica, Run must be called to check its replication lag
+func NewReplica(db *sql.DB, maxLag time.Duration) *Replica {
+	return &Replica{
+		db:     db,
+		maxLag: maxLag,
+	}
+}
+
+// Run checks the replication lag periodically until the context is cancelled
+func (r *Replica) Run(ctx context.Context) {
+	ticker := time.NewTicker(replicaCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		r.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check updates the availability of the replica by its replication lag
+func (r *Replica) Check(ctx context.Context) {
+	log := logger.FromContext(ctx).
+		WithField("component", "finder.replica")
+
+	lag, known, err := repository.FindReplicationLag(ctx, r.db)
+	available := err == nil && known && lag <= r.maxLag
+
+	if wasAvailable := r.available.Swap(available); wasAvailable && !available {
+		log := log.WithField("maxLag", r.maxLag)
+		if err != nil {
+			log = log.WithError(err)
+		} else if known {
+			log = log.WithField("lag", lag)
+		}
+		log.Warn("Replica unavailable, routing queries to primary")
+	} else if !wasAvailable && available {
+		log.
+			WithField("lag", lag).
+			Info("Replica available, routing queries to replica")
+	}
+}
+
+// Available returns whether queries can be routed to the replica
+func (r *Replica) Available() bool {
+	return r.available.Load()
+}
+
+type ctxKey int
+
+const primaryCtxKey ctxKey = iota
+
+// WithPrimary routes all queries of finders using the context to the primary.
+// It must be used to read the results of commands (read your writes), since they could not be replicated yet.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryCtxKey, true)
+}
+
+func usePrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryCtxKey).(bool)
+	return primary
+}
@@ -0,0 +1,61 @@
+package finder
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"myvendor.mytld/myproject/backend/test"
+)
+
+func TestFinder_ExecutorFor(t *testing.T) {
+	primaryDB := &sql.DB{}
+	replicaDB := &sql.DB{}
+
+	availableReplica := NewReplica(replicaDB, time.Second)
+	availableReplica.available.Store(true)
+
+	tt := []struct {
+		name     string
+		replica  *Replica
+		primary  bool
+		expected *sql.DB
+	}{
+		{
+			name:     "without replica",
+			expected: primaryDB,
+		},
+		{
+			name:     "with unchecked replica",
+			replica:  NewReplica(replicaDB, time.Second),
+			expected: primaryDB,
+		},
+		{
+			name:     "with available replica",
+			replica:  availableReplica,
+			expected: replicaDB,
+		},
+		{
+			name:     "with available replica and primary required",
+			replica:  availableReplica,
+			primary:  true,
+			expected: primaryDB,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f := NewFinder(primaryDB, test.FixedTime(), WithReplica(tc.replica))
+
+			ctx := context.Background()
+			if tc.primary {
+				ctx = WithPrimary(ctx)
+			}
+
+			assert.Same(t, tc.expected, f.executorFor(ctx))
+		})
+	}
+}
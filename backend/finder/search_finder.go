@@ -0,0 +1,58 @@
+package finder
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+	domain_query "myvendor.mytld/myproject/backend/domain/query"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/security/authentication"
+	"myvendor.mytld/myproject/backend/security/authorization"
+)
+
+// QuerySearch searches accounts and organisations with the same authorization as listing them.
+// Results of both are merged by their rank, so the most relevant results are returned up to the limit.
+func (f *Finder) QuerySearch(ctx context.Context, query domain_query.SearchQuery) ([]model.SearchResult, error) {
+	if strings.TrimSpace(query.SearchTerm) == "" {
+		return []model.SearchResult{}, nil
+	}
+
+	authorizer := authorization.NewAuthorizer(authentication.GetAuthContext(ctx))
+
+	accountsQuery := domain_query.AccountsQuery{SearchTerm: query.SearchTerm}
+	err := authorizer.AllowsAndFilterAllAccountsQuery(&accountsQuery)
+	if err != nil {
+		return nil, err
+	}
+	organisationsQuery := domain_query.OrganisationsQuery{SearchTerm: query.SearchTerm}
+	err = authorizer.AllowsAndFilterAllOrganisationsQuery(&organisationsQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := repository.SearchAccounts(ctx, f.executorFor(ctx), repository.AccountsFilter{
+		OrganisationID: accountsQuery.OrganisationID,
+		SearchTerm:     accountsQuery.SearchTerm,
+	}, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+	organisations, err := repository.SearchOrganisations(ctx, f.executorFor(ctx), repository.OrganisationsFilter{
+		IDs:        organisationsQuery.IDs,
+		SearchTerm: organisationsQuery.SearchTerm,
+	}, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := append(accounts, organisations...)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Rank > results[j].Rank
+	})
+	if len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+	return results, nil
+}
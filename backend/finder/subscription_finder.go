@@ -0,0 +1,83 @@
+package finder
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+	domain_query "myvendor.mytld/myproject/backend/domain/query"
+	"myvendor.mytld/myproject/backend/persistence/notification"
+	"myvendor.mytld/myproject/backend/security/authentication"
+	"myvendor.mytld/myproject/backend/security/authorization"
+)
+
+// SubscribeAccountChanged subscribes to account changes from the listener.
+// Every event is authorized for the subscriber, so events of accounts that are not visible are skipped.
+// An account that was moved to another organisation is visible in the previous and the new organisation.
+func (f *Finder) SubscribeAccountChanged(ctx context.Context, listener *notification.Listener, query domain_query.AccountChangedSubscription) (<-chan notification.AccountChanged, error) {
+	authorizer := authorization.NewAuthorizer(authentication.GetAuthContext(ctx))
+	err := authorizer.AllowsAndFilterAccountChangedSubscription(&query)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterEvents(ctx, notification.Subscribe[notification.AccountChanged](ctx, listener), func(event notification.AccountChanged) bool {
+		organisationIDs := []uuid.NullUUID{event.OrganisationID}
+		if event.PreviousOrganisationID.Valid {
+			organisationIDs = append(organisationIDs, event.PreviousOrganisationID)
+		}
+
+		for _, organisationID := range organisationIDs {
+			if query.OrganisationID != nil && (!organisationID.Valid || organisationID.UUID != *query.OrganisationID) {
+				continue
+			}
+			if authorizer.AllowsAccountView(model.Account{
+				ID:             event.AccountID,
+				OrganisationID: organisationID,
+			}) == nil {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+// SubscribeOrganisationChanged subscribes to organisation changes from the listener.
+// Every event is authorized for the subscriber, so events of organisations that are not visible are skipped.
+func (f *Finder) SubscribeOrganisationChanged(ctx context.Context, listener *notification.Listener, query domain_query.OrganisationChangedSubscription) (<-chan notification.OrganisationChanged, error) {
+	authorizer := authorization.NewAuthorizer(authentication.GetAuthContext(ctx))
+	err := authorizer.AllowsAndFilterOrganisationChangedSubscription(&query)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterEvents(ctx, notification.Subscribe[notification.OrganisationChanged](ctx, listener), func(event notification.OrganisationChanged) bool {
+		if query.OrganisationID != nil && event.OrganisationID != *query.OrganisationID {
+			return false
+		}
+		return authorizer.AllowsOrganisationView(model.Organisation{
+			ID: event.OrganisationID,
+		}) == nil
+	}), nil
+}
+
+func filterEvents[E any](ctx context.Context, events <-chan E, allow func(event E) bool) <-chan E {
+	filtered := make(chan E)
+	go func() {
+		defer close(filtered)
+
+		for event := range events {
+			if !allow(event) {
+				continue
+			}
+
+			select {
+			case filtered <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return filtered
+}
@@ -0,0 +1,59 @@
+package finder
+
+import (
+	"context"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+	domain_query "myvendor.mytld/myproject/backend/domain/query"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/security/authentication"
+	"myvendor.mytld/myproject/backend/security/authorization"
+)
+
+func (f *Finder) QueryWebhookEndpoint(ctx context.Context, query domain_query.WebhookEndpointQuery) (model.WebhookEndpoint, error) {
+	record, err := repository.FindWebhookEndpointByID(ctx, f.executorFor(ctx), query.WebhookEndpointID)
+	if err != nil {
+		return record, err
+	}
+	err = authorization.NewAuthorizer(authentication.GetAuthContext(ctx)).AllowsWebhookEndpointView(record)
+	if err != nil {
+		return record, err
+	}
+	return record, nil
+}
+
+func (f *Finder) QueryWebhookEndpoints(ctx context.Context, query domain_query.WebhookEndpointsQuery, paging Paging) ([]model.WebhookEndpoint, error) {
+	authorizer := authorization.NewAuthorizer(authentication.GetAuthContext(ctx))
+	err := authorizer.AllowsAndFilterAllWebhookEndpointsQuery(&query)
+	if err != nil {
+		return nil, err
+	}
+	return repository.FindAllWebhookEndpoints(ctx, f.executorFor(ctx), repository.WebhookEndpointsFilter{
+		OrganisationID: query.OrganisationID,
+	}, paging.options()...)
+}
+
+func (f *Finder) QueryWebhookDelivery(ctx context.Context, query domain_query.WebhookDeliveryQuery) (model.WebhookDelivery, error) {
+	record, err := repository.FindWebhookDeliveryByID(ctx, f.executorFor(ctx), query.WebhookDeliveryID)
+	if err != nil {
+		return record, err
+	}
+	err = authorization.NewAuthorizer(authentication.GetAuthContext(ctx)).AllowsWebhookDeliveryView(record)
+	if err != nil {
+		return record, err
+	}
+	return record, nil
+}
+
+func (f *Finder) QueryWebhookDeliveries(ctx context.Context, query domain_query.WebhookDeliveriesQuery, paging Paging) ([]model.WebhookDelivery, error) {
+	authorizer := authorization.NewAuthorizer(authentication.GetAuthContext(ctx))
+	err := authorizer.AllowsWebhookDeliveriesQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	return repository.FindAllWebhookDeliveries(ctx, f.executorFor(ctx), repository.WebhookDeliveriesFilter{
+		OrganisationID:    &query.OrganisationID,
+		WebhookEndpointID: &query.WebhookEndpointID,
+		Status:            query.Status,
+	}, paging.options()...)
+}
@@ -4,63 +4,72 @@ import (
 	"context"
 	"database/sql"
 
-	logger "github.com/apex/log"
 	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
 
 	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/event"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/audit"
+	"myvendor.mytld/myproject/backend/persistence/notification"
+	"myvendor.mytld/myproject/backend/persistence/outbox"
 	"myvendor.mytld/myproject/backend/persistence/repository"
-	"myvendor.mytld/myproject/backend/security/authentication"
-	"myvendor.mytld/myproject/backend/security/authorization"
 )
 
 func (h *Handler) AccountCreate(ctx context.Context, cmd command.AccountCreateCmd) error {
-	log := logger.FromContext(ctx).
-		WithField("component", "handler").
-		WithField("handler", "AccountCreate")
-
-	log.
-		WithField("cmd", cmd).
-		Debug("Handling account create command")
-
-	if err := cmd.Validate(h.config); err != nil {
-		return err
-	}
-
-	authCtx := authentication.GetAuthContext(ctx)
-	if err := authorization.NewAuthorizer(authCtx).AllowsAccountCreateCmd(cmd); err != nil {
-		return err
-	}
-
-	err := repository.Transactional(ctx, h.db, func(tx *sql.Tx) error {
+	return dispatchTx(ctx, h, cmd, func(ctx context.Context, tx *sql.Tx, cmd command.AccountCreateCmd) (*audit.Entry, error) {
 		account, err := cmd.NewAccount(h.config)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		// Accounts cannot be assigned to a deleted organisation
+		if account.OrganisationID.Valid {
+			_, err = repository.FindOrganisationByID(ctx, tx, account.OrganisationID.UUID, nil)
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, types.FieldError{
+					Field: "organisationId",
+					Code:  types.ErrorCodeNotExists,
+				}
+			} else if err != nil {
+				return nil, errors.Wrap(err, "finding organisation")
+			}
+		}
+
 		err = repository.InsertAccount(ctx, tx, repository.AccountToChangeSet(account))
 		if err != nil {
 			if constraintErr := repository.AccountConstraintErr(err); constraintErr != nil {
-				return constraintErr
+				return nil, constraintErr
 			}
-			return errors.Wrap(err, "inserting account")
+			return nil, errors.Wrap(err, "inserting account")
 		}
 
-		return nil
-	})
-	if err != nil {
-		return errors.Wrap(err, "running transaction")
-	}
-
-	var organisationID string
-	if cmd.OrganisationID.Valid {
-		organisationID = cmd.OrganisationID.UUID.String()
-	}
+		err = notification.Notify(ctx, tx, notification.AccountChanged{
+			Action:         notification.ChangeActionCreated,
+			AccountID:      account.ID,
+			OrganisationID: account.OrganisationID,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "notifying account change")
+		}
 
-	log.
-		WithField("accountID", cmd.AccountID).
-		WithField("organisationID", organisationID).
-		WithField("emailAddress", cmd.EmailAddress).
-		WithField("role", cmd.Role).
-		Info("Created account")
+		err = outbox.Append(ctx, tx, h.timeSource.Now(), event.AccountCreated{
+			AccountID:      account.ID,
+			OrganisationID: account.OrganisationID,
+			EmailAddress:   account.EmailAddress,
+			Role:           account.Role,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "appending account event")
+		}
 
-	return nil
+		return &audit.Entry{
+			OrganisationID: account.OrganisationID,
+			TargetIDs:      []uuid.UUID{account.ID},
+			Changes: audit.Changes{}.
+				Set("emailAddress", nil, account.EmailAddress).
+				Set("role", nil, account.Role).
+				Set("organisationId", nil, account.OrganisationID).
+				SetRedacted("password"),
+		}, nil
+	})
 }
@@ -4,69 +4,61 @@ import (
 	"context"
 	"database/sql"
 
-	logger "github.com/apex/log"
 	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
 
 	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/event"
 	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/audit"
+	"myvendor.mytld/myproject/backend/persistence/notification"
+	"myvendor.mytld/myproject/backend/persistence/outbox"
 	"myvendor.mytld/myproject/backend/persistence/repository"
-	"myvendor.mytld/myproject/backend/security/authentication"
-	"myvendor.mytld/myproject/backend/security/authorization"
 )
 
 func (h *Handler) AccountDelete(ctx context.Context, cmd command.AccountDeleteCmd) error {
-	log := logger.FromContext(ctx).
-		WithField("component", "handler").
-		WithField("handler", "AccountDelete")
-
-	log.
-		WithField("cmd", cmd).
-		Debug("Handling account delete command")
-
-	authCtx := authentication.GetAuthContext(ctx)
-	if err := authorization.NewAuthorizer(authCtx).AllowsAccountDeleteCmd(cmd); err != nil {
-		return err
-	}
-
-	var (
-		organisationID string
-		emailAddress   string
-		role           types.Role
-	)
-	err := repository.Transactional(ctx, h.db, func(tx *sql.Tx) error {
+	return dispatchTx(ctx, h, cmd, func(ctx context.Context, tx *sql.Tx, cmd command.AccountDeleteCmd) (*audit.Entry, error) {
 		record, err := repository.FindAccountByID(ctx, tx, cmd.AccountID, nil)
 		if errors.Is(err, repository.ErrNotFound) {
-			return types.FieldError{
+			return nil, types.FieldError{
 				Field: "accountId",
 				Code:  types.ErrorCodeNotExists,
 			}
 		} else if err != nil {
-			return errors.Wrap(err, "fetching account")
+			return nil, errors.Wrap(err, "fetching account")
 		}
 
-		// For logging
-		emailAddress = record.EmailAddress
-		role = record.Role
-		if record.OrganisationID.Valid {
-			organisationID = record.OrganisationID.UUID.String()
+		// Accounts are soft deleted and purged after the retention, they can be restored until then
+		deletedAt := h.timeSource.Now()
+		deletedAtPtr := &deletedAt
+		err = repository.UpdateAccount(ctx, tx, cmd.AccountID, repository.AccountChangeSet{
+			DeletedAt: &deletedAtPtr,
+		}, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "deleting account")
 		}
 
-		err = repository.DeleteAccount(ctx, tx, cmd.AccountID)
+		err = notification.Notify(ctx, tx, notification.AccountChanged{
+			Action:         notification.ChangeActionDeleted,
+			AccountID:      record.ID,
+			OrganisationID: record.OrganisationID,
+		})
 		if err != nil {
-			return errors.Wrap(err, "deleting account")
+			return nil, errors.Wrap(err, "notifying account change")
 		}
-		return nil
-	})
-	if err != nil {
-		return err
-	}
 
-	log.
-		WithField("accountID", cmd.AccountID).
-		WithField("organisationID", organisationID).
-		WithField("emailAddress", emailAddress).
-		WithField("role", role).
-		Info("Deleted account")
+		err = outbox.Append(ctx, tx, h.timeSource.Now(), event.AccountDeleted{
+			AccountID:      record.ID,
+			OrganisationID: record.OrganisationID,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "appending account event")
+		}
 
-	return nil
+		return &audit.Entry{
+			OrganisationID: record.OrganisationID,
+			TargetIDs:      []uuid.UUID{record.ID},
+			Changes:        audit.Changes{}.Set("deletedAt", nil, deletedAt),
+		}, nil
+	})
 }
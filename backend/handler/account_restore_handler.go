@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/event"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/audit"
+	"myvendor.mytld/myproject/backend/persistence/notification"
+	"myvendor.mytld/myproject/backend/persistence/outbox"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+func (h *Handler) AccountRestore(ctx context.Context, cmd command.AccountRestoreCmd) error {
+	return dispatchTx(ctx, h, cmd, func(ctx context.Context, tx *sql.Tx, cmd command.AccountRestoreCmd) (*audit.Entry, error) {
+		record, err := repository.FindDeletedAccountByID(ctx, tx, cmd.AccountID, nil)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, types.FieldError{
+				Field: "accountId",
+				Code:  types.ErrorCodeNotExists,
+			}
+		} else if err != nil {
+			return nil, errors.Wrap(err, "finding account")
+		}
+
+		// The organisation has to be restored first, that restores the account as well
+		if record.OrganisationID.Valid {
+			_, err = repository.FindOrganisationByID(ctx, tx, record.OrganisationID.UUID, nil)
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, types.FieldError{
+					Field: "organisationId",
+					Code:  types.ErrorCodeNotExists,
+				}
+			} else if err != nil {
+				return nil, errors.Wrap(err, "finding organisation")
+			}
+		}
+
+		var deletedAt *time.Time
+		err = repository.UpdateAccount(ctx, tx, cmd.AccountID, repository.AccountChangeSet{
+			DeletedAt: &deletedAt,
+		}, nil)
+		if err != nil {
+			// Another account could have been created with the email address in the meantime
+			if constraintErr := repository.AccountConstraintErr(err); constraintErr != nil {
+				return nil, constraintErr
+			}
+			return nil, errors.Wrap(err, "restoring account")
+		}
+
+		err = notification.Notify(ctx, tx, notification.AccountChanged{
+			Action:         notification.ChangeActionRestored,
+			AccountID:      record.ID,
+			OrganisationID: record.OrganisationID,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "notifying account change")
+		}
+
+		err = outbox.Append(ctx, tx, h.timeSource.Now(), event.AccountRestored{
+			AccountID:      record.ID,
+			OrganisationID: record.OrganisationID,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "appending account event")
+		}
+
+		return &audit.Entry{
+			OrganisationID: record.OrganisationID,
+			TargetIDs:      []uuid.UUID{record.ID},
+			Changes:        audit.Changes{}.Set("deletedAt", record.DeletedAt, nil),
+		}, nil
+	})
+}
@@ -4,54 +4,41 @@ import (
 	"context"
 	"database/sql"
 
-	logger "github.com/apex/log"
 	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
 
 	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/event"
 	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/audit"
+	"myvendor.mytld/myproject/backend/persistence/notification"
+	"myvendor.mytld/myproject/backend/persistence/outbox"
 	"myvendor.mytld/myproject/backend/persistence/repository"
-	"myvendor.mytld/myproject/backend/security/authentication"
-	"myvendor.mytld/myproject/backend/security/authorization"
 )
 
 func (h *Handler) AccountUpdate(ctx context.Context, cmd command.AccountUpdateCmd) error {
-	log := logger.FromContext(ctx).
-		WithField("component", "handler").
-		WithField("handler", "AccountUpdate")
-
-	log.
-		WithField("cmd", cmd).
-		Debug("Handling account update command")
-
-	if err := cmd.Validate(h.config); err != nil {
-		return err
-	}
-
-	authCtx := authentication.GetAuthContext(ctx)
-	if err := authorization.NewAuthorizer(authCtx).AllowsAccountUpdateCmd(cmd); err != nil {
-		return err
-	}
-
-	var (
-		prevUsername       string
-		prevOrganisationID string
-		prevRole           string
-	)
-	err := repository.Transactional(ctx, h.db, func(tx *sql.Tx) error {
+	return dispatchTx(ctx, h, cmd, func(ctx context.Context, tx *sql.Tx, cmd command.AccountUpdateCmd) (*audit.Entry, error) {
 		prevRecord, err := repository.FindAccountByID(ctx, tx, cmd.AccountID, nil)
 		if errors.Is(err, repository.ErrNotFound) {
-			return types.FieldError{
+			return nil, types.FieldError{
 				Field: "accountId",
 				Code:  types.ErrorCodeNotExists,
 			}
 		} else if err != nil {
-			return errors.Wrap(err, "finding account")
+			return nil, errors.Wrap(err, "finding account")
 		}
 
-		// For logging
-		prevUsername = prevRecord.EmailAddress
-		if prevRecord.OrganisationID.Valid {
-			prevOrganisationID = prevRecord.OrganisationID.UUID.String()
+		// Accounts cannot be assigned to a deleted organisation
+		if cmd.NewOrganisationID.Valid {
+			_, err = repository.FindOrganisationByID(ctx, tx, cmd.NewOrganisationID.UUID, nil)
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, types.FieldError{
+					Field: "organisationId",
+					Code:  types.ErrorCodeNotExists,
+				}
+			} else if err != nil {
+				return nil, errors.Wrap(err, "finding organisation")
+			}
 		}
 
 		changeSet := repository.AccountChangeSet{
@@ -63,35 +50,49 @@ func (h *Handler) AccountUpdate(ctx context.Context, cmd command.AccountUpdateCm
 			PasswordHash: cmd.PasswordHash,
 		}
 
-		err = repository.UpdateAccount(ctx, tx, prevRecord.ID, changeSet)
+		err = repository.UpdateAccount(ctx, tx, prevRecord.ID, changeSet, cmd.ExpectedVersion)
 		if err != nil {
 			if constraintErr := repository.AccountConstraintErr(err); constraintErr != nil {
-				return constraintErr
+				return nil, constraintErr
 			}
-			return errors.Wrap(err, "updating account")
+			return nil, errors.Wrap(err, "updating account")
 		}
 
-		return nil
-	})
-	if err != nil {
-		return errors.Wrap(err, "running transaction")
-	}
-
-	// For logging
-	var organisationID string
-	if cmd.NewOrganisationID.Valid {
-		organisationID = cmd.NewOrganisationID.UUID.String()
-	}
+		accountChanged := notification.AccountChanged{
+			Action:         notification.ChangeActionUpdated,
+			AccountID:      prevRecord.ID,
+			OrganisationID: cmd.NewOrganisationID,
+		}
+		// Subscribers of the previous organisation are notified that the account left
+		if prevRecord.OrganisationID != cmd.NewOrganisationID {
+			accountChanged.PreviousOrganisationID = prevRecord.OrganisationID
+		}
+		err = notification.Notify(ctx, tx, accountChanged)
+		if err != nil {
+			return nil, errors.Wrap(err, "notifying account change")
+		}
 
-	log.
-		WithField("accountID", cmd.AccountID).
-		WithField("prevOrganisationID", prevOrganisationID).
-		WithField("organisationID", organisationID).
-		WithField("prevUsername", prevUsername).
-		WithField("emailAddress", cmd.EmailAddress).
-		WithField("prevRole", prevRole).
-		WithField("role", cmd.Role).
-		Info("Updated account")
+		err = outbox.Append(ctx, tx, h.timeSource.Now(), event.AccountUpdated{
+			AccountID:      prevRecord.ID,
+			OrganisationID: cmd.NewOrganisationID,
+			EmailAddress:   cmd.EmailAddress,
+			Role:           cmd.Role,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "appending account event")
+		}
 
-	return nil
+		changes := audit.Changes{}.
+			Set("emailAddress", prevRecord.EmailAddress, cmd.EmailAddress).
+			Set("role", prevRecord.Role, cmd.Role).
+			Set("organisationId", prevRecord.OrganisationID, cmd.NewOrganisationID)
+		if cmd.PasswordHash != nil {
+			changes.SetRedacted("password")
+		}
+		return &audit.Entry{
+			OrganisationID: prevRecord.OrganisationID,
+			TargetIDs:      []uuid.UUID{prevRecord.ID},
+			Changes:        changes,
+		}, nil
+	})
 }
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"io"
+
+	logger "github.com/apex/log"
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/imaging"
+	"myvendor.mytld/myproject/backend/persistence/audit"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/security/authentication"
+)
+
+func (h *Handler) AssetCreate(ctx context.Context, cmd command.AssetCreateCmd) error {
+	storageKey := assetStorageKey(cmd)
+	var stored bool
+
+	// The content is read and stored once, so the transaction is not retried
+	err := dispatchTxNoRetry(ctx, h, cmd, func(ctx context.Context, tx *sql.Tx, cmd command.AssetCreateCmd) (*audit.Entry, error) {
+		source := cmd.Content()
+		size := cmd.Size
+		var width, height *int
+		if cmd.Image != nil {
+			data, info, err := sanitizeImage(cmd)
+			if err != nil {
+				return nil, err
+			}
+			source = bytes.NewReader(data)
+			size = int64(len(data))
+			width, height = &info.Width, &info.Height
+		}
+
+		// The checksum is calculated while the content is streamed to the storage
+		hash := sha256.New()
+		content := &countingReader{r: io.TeeReader(source, hash)}
+		err := h.storage.Put(ctx, storageKey, content, size, cmd.ContentType)
+		if err != nil {
+			return nil, errors.Wrap(err, "storing content")
+		}
+		stored = true
+		if content.n != size {
+			return nil, errors.Errorf("content size %d does not match declared size %d", content.n, size)
+		}
+		checksum := hex.EncodeToString(hash.Sum(nil))
+
+		// The CLI acts without an account
+		authCtx := authentication.GetAuthContext(ctx)
+		createdBy := uuid.NullUUID{UUID: authCtx.AccountID, Valid: authCtx.AccountID != uuid.Nil}
+
+		changeSet := repository.AssetChangeSet{
+			ID:             &cmd.AssetID,
+			OrganisationID: &cmd.OrganisationID,
+			StorageKey:     &storageKey,
+			Filename:       &cmd.Filename,
+			ContentType:    &cmd.ContentType,
+			Size:           &size,
+			Checksum:       &checksum,
+			CreatedBy:      &createdBy,
+			Width:          &width,
+			Height:         &height,
+		}
+
+		err = repository.InsertAsset(ctx, tx, changeSet)
+		if err != nil {
+			return nil, errors.Wrap(err, "inserting asset")
+		}
+
+		return &audit.Entry{
+			OrganisationID: cmd.OrganisationID,
+			TargetIDs:      []uuid.UUID{cmd.AssetID},
+			Changes: audit.Changes{}.
+				Set("filename", nil, cmd.Filename).
+				Set("contentType", nil, cmd.ContentType).
+				Set("size", nil, size),
+		}, nil
+	})
+	if err != nil && stored {
+		// Do not keep content without metadata
+		h.deleteStoredAsset(ctx, storageKey)
+	}
+	return err
+}
+
+func assetStorageKey(cmd command.AssetCreateCmd) string {
+	owner := "system"
+	if cmd.OrganisationID.Valid {
+		owner = cmd.OrganisationID.UUID.String()
+	}
+	return "assets/" + owner + "/" + cmd.AssetID.String()
+}
+
+func (h *Handler) deleteStoredAsset(ctx context.Context, storageKey string) {
+	err := h.storage.Delete(ctx, storageKey)
+	if err != nil {
+		logger.FromContext(ctx).
+			WithField("storageKey", storageKey).
+			WithError(err).
+			Warn("Could not delete stored asset content")
+	}
+}
+
+// sanitizeImage strips metadata from an image, which is read into memory (its size is bounded by the upload limit).
+// The dimensions are read again, since applying the orientation can swap width and height.
+func sanitizeImage(cmd command.AssetCreateCmd) ([]byte, imaging.Info, error) {
+	data, err := io.ReadAll(io.LimitReader(cmd.Content(), cmd.Size+1))
+	if err != nil {
+		return nil, imaging.Info{}, errors.Wrap(err, "reading image")
+	}
+	if int64(len(data)) != cmd.Size {
+		return nil, imaging.Info{}, errors.Errorf("content size %d does not match declared size %d", len(data), cmd.Size)
+	}
+
+	data, err = imaging.StripMetadata(data, cmd.ContentType)
+	if err != nil {
+		return nil, imaging.Info{}, errors.Wrap(err, "stripping image metadata")
+	}
+
+	info, err := imaging.DecodeInfo(bytes.NewReader(data))
+	if err != nil {
+		return nil, imaging.Info{}, errors.Wrap(err, "decoding sanitized image")
+	}
+	return data, info, nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/imaging"
+	"myvendor.mytld/myproject/backend/persistence/audit"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+func (h *Handler) AssetDelete(ctx context.Context, cmd command.AssetDeleteCmd) error {
+	var record model.Asset
+	err := dispatchTx(ctx, h, cmd, func(ctx context.Context, tx *sql.Tx, cmd command.AssetDeleteCmd) (*audit.Entry, error) {
+		var err error
+		record, err = repository.FindAssetByID(ctx, tx, cmd.AssetID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, types.FieldError{
+				Field: "id",
+				Code:  types.ErrorCodeNotExists,
+			}
+		} else if err != nil {
+			return nil, errors.Wrap(err, "fetching asset")
+		}
+		// The organisation of the command is checked by the authorizer, so it must match the record
+		if record.OrganisationID != cmd.OrganisationID {
+			return nil, types.FieldError{
+				Field: "id",
+				Code:  types.ErrorCodeNotExists,
+			}
+		}
+
+		err = repository.DeleteAsset(ctx, tx, cmd.AssetID)
+		if err != nil {
+			return nil, errors.Wrap(err, "deleting asset")
+		}
+
+		return &audit.Entry{
+			OrganisationID: record.OrganisationID,
+			TargetIDs:      []uuid.UUID{record.ID},
+			Changes: audit.Changes{}.
+				Set("filename", record.Filename, nil).
+				Set("contentType", record.ContentType, nil).
+				Set("size", record.Size, nil),
+		}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Content is deleted after the metadata is gone, a failure only leaves an orphaned object
+	h.deleteStoredAsset(ctx, record.StorageKey)
+	if record.IsImage() {
+		for _, variant := range imaging.Variants() {
+			for _, contentType := range []string{"image/jpeg", "image/png"} {
+				h.deleteStoredAsset(ctx, record.VariantStorageKey(variant.Name, contentType))
+			}
+		}
+	}
+
+	return nil
+}
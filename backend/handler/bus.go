@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"strings"
+
+	"myvendor.mytld/myproject/backend/persistence/audit"
+)
+
+// envelope carries a command through the middlewares of the bus
+type envelope struct {
+	// commandType is the name of the command without the Cmd suffix (e.g. AccountUpdate)
+	commandType string
+	cmd         any
+	// transactional commands are handled in a transaction started by the transaction middleware
+	transactional bool
+	// noRetry runs the transaction only once, for commands with side effects outside the transaction (e.g. sending a mail)
+	noRetry bool
+	// tx is set by the transaction middleware
+	tx *sql.Tx
+	// auditEntry is set by transactional commands to record the change in the audit log
+	auditEntry *audit.Entry
+}
+
+type handlerFunc func(ctx context.Context, env *envelope) error
+
+// middleware adds behaviour to the handling of all commands (e.g. validation or authorization)
+type middleware func(next handlerFunc) handlerFunc
+
+// bus dispatches commands through a chain of middlewares
+type bus struct {
+	middlewares []middleware
+}
+
+func (b bus) dispatch(ctx context.Context, env *envelope, h handlerFunc) error {
+	for i := len(b.middlewares) - 1; i >= 0; i-- {
+		h = b.middlewares[i](h)
+	}
+	return h(ctx, env)
+}
+
+// dispatch handles the command by fn after passing all middlewares
+func dispatch[C any](ctx context.Context, h *Handler, cmd C, fn func(ctx context.Context, cmd C) error) error {
+	env := &envelope{
+		commandType: commandType(cmd),
+		cmd:         cmd,
+	}
+	return h.bus.dispatch(ctx, env, func(ctx context.Context, _ *envelope) error {
+		return fn(ctx, cmd)
+	})
+}
+
+// dispatchTx handles the command by fn in a transaction after passing all middlewares.
+// The audit entry returned by fn is recorded in the same transaction, nil skips the audit log.
+func dispatchTx[C any](ctx context.Context, h *Handler, cmd C, fn func(ctx context.Context, tx *sql.Tx, cmd C) (*audit.Entry, error)) error {
+	env := &envelope{
+		commandType:   commandType(cmd),
+		cmd:           cmd,
+		transactional: true,
+	}
+	return dispatchEnvelopeTx(ctx, h, env, cmd, fn)
+}
+
+// dispatchTxNoRetry is like dispatchTx, but the transaction is not retried on serialization failures and deadlocks.
+// It must be used if fn has side effects that must not be repeated (e.g. sending a mail).
+func dispatchTxNoRetry[C any](ctx context.Context, h *Handler, cmd C, fn func(ctx context.Context, tx *sql.Tx, cmd C) (*audit.Entry, error)) error {
+	env := &envelope{
+		commandType:   commandType(cmd),
+		cmd:           cmd,
+		transactional: true,
+		noRetry:       true,
+	}
+	return dispatchEnvelopeTx(ctx, h, env, cmd, fn)
+}
+
+func dispatchEnvelopeTx[C any](ctx context.Context, h *Handler, env *envelope, cmd C, fn func(ctx context.Context, tx *sql.Tx, cmd C) (*audit.Entry, error)) error {
+	return h.bus.dispatch(ctx, env, func(ctx context.Context, env *envelope) error {
+		entry, err := fn(ctx, env.tx, cmd)
+		if err != nil {
+			return err
+		}
+		env.auditEntry = entry
+		return nil
+	})
+}
+
+func commandType(cmd any) string {
+	return strings.TrimSuffix(reflect.TypeOf(cmd).Name(), "Cmd")
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+
+	logger "github.com/apex/log"
+	"github.com/friendsofgo/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"myvendor.mytld/myproject/backend/domain"
+	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/audit"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/security/authentication"
+	"myvendor.mytld/myproject/backend/security/authorization"
+)
+
+// defaultMiddlewares are applied to all commands, the first middleware is the outermost.
+// Commands are validated before they are authorized, since checks of the authorizer can rely on valid values.
+func defaultMiddlewares(db *sql.DB, config domain.Config, timeSource types.TimeSource, tracer trace.Tracer, meterProvider metric.MeterProvider, instrumentation instrumentation) []middleware {
+	return []middleware{
+		tracingMiddleware(tracer),
+		metricsMiddleware(instrumentation, timeSource),
+		loggingMiddleware(timeSource),
+		validationMiddleware(config),
+		authorizationMiddleware,
+		transactionMiddleware(db, meterProvider),
+		auditMiddleware(timeSource),
+	}
+}
+
+func tracingMiddleware(tracer trace.Tracer) middleware {
+	return func(next handlerFunc) handlerFunc {
+		return func(ctx context.Context, env *envelope) error {
+			ctx, span := tracer.Start(ctx, "command "+env.commandType,
+				trace.WithAttributes(attribute.String("command.type", env.commandType)),
+			)
+			defer span.End()
+
+			err := next(ctx, env)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return err
+		}
+	}
+}
+
+func metricsMiddleware(instrumentation instrumentation, timeSource types.TimeSource) middleware {
+	return func(next handlerFunc) handlerFunc {
+		return func(ctx context.Context, env *envelope) error {
+			startedAt := timeSource.Now()
+
+			err := next(ctx, env)
+
+			status := "succeeded"
+			if err != nil {
+				status = "failed"
+			}
+			instrumentation.commandDuration.Record(ctx, timeSource.Now().Sub(startedAt).Seconds(),
+				metric.WithAttributes(
+					attribute.String("command.type", env.commandType),
+					attribute.String("command.status", status),
+				),
+			)
+			return err
+		}
+	}
+}
+
+// loggingMiddleware adds a logger for the command to the context, handlers get it by logger.FromContext.
+// Commands implement logger.Fielder to log their fields (e.g. IDs and names, but no secrets), other commands are only logged for debugging.
+func loggingMiddleware(timeSource types.TimeSource) middleware {
+	return func(next handlerFunc) handlerFunc {
+		return func(ctx context.Context, env *envelope) error {
+			log := logger.FromContext(ctx).
+				WithField("component", "handler").
+				WithField("handler", env.commandType)
+
+			if cmd, ok := env.cmd.(logger.Fielder); ok {
+				log = log.WithFields(cmd)
+				log.Debug("Handling command")
+			} else {
+				log.
+					WithField("cmd", env.cmd).
+					Debug("Handling command")
+			}
+
+			startedAt := timeSource.Now()
+			err := next(logger.NewContext(ctx, log), env)
+			if err != nil {
+				return err
+			}
+
+			if env.auditEntry != nil {
+				log = log.WithField("targetIDs", env.auditEntry.TargetIDs)
+			}
+			log.
+				WithDuration(timeSource.Now().Sub(startedAt)).
+				Info("Handled command")
+
+			return nil
+		}
+	}
+}
+
+// authorizationMiddleware checks all commands against the current auth context (see authorization.Authorizer.AllowsCmd)
+func authorizationMiddleware(next handlerFunc) handlerFunc {
+	return func(ctx context.Context, env *envelope) error {
+		authCtx := authentication.GetAuthContext(ctx)
+		if err := authorization.NewAuthorizer(authCtx).AllowsCmd(env.cmd); err != nil {
+			return err
+		}
+		return next(ctx, env)
+	}
+}
+
+func validationMiddleware(config domain.Config) middleware {
+	return func(next handlerFunc) handlerFunc {
+		return func(ctx context.Context, env *envelope) error {
+			if cmd, ok := env.cmd.(command.Validatable); ok {
+				if err := cmd.Validate(config); err != nil {
+					return err
+				}
+			}
+			return next(ctx, env)
+		}
+	}
+}
+
+// transactionMiddleware runs transactional commands in a transaction that is retried on serialization failures and deadlocks,
+// so the following middlewares and the handler must not have side effects outside the transaction (unless retries are disabled by noRetry)
+func transactionMiddleware(db *sql.DB, meterProvider metric.MeterProvider) middleware {
+	return func(next handlerFunc) handlerFunc {
+		return func(ctx context.Context, env *envelope) error {
+			if !env.transactional {
+				return next(ctx, env)
+			}
+
+			retryOpts := repository.RetryOpts{MeterProvider: meterProvider}
+			if env.noRetry {
+				retryOpts.MaxAttempts = 1
+			}
+			err := repository.TransactionalWithRetry(ctx, db, nil, retryOpts, func(tx *sql.Tx) error {
+				env.tx = tx
+				return next(ctx, env)
+			})
+			if err != nil {
+				return errors.Wrap(err, "running transaction")
+			}
+			return nil
+		}
+	}
+}
+
+// auditMiddleware records the audit entry of a command in its transaction, so it is only stored if the command is committed
+func auditMiddleware(timeSource types.TimeSource) middleware {
+	return func(next handlerFunc) handlerFunc {
+		return func(ctx context.Context, env *envelope) error {
+			err := next(ctx, env)
+			if err != nil || env.auditEntry == nil {
+				return err
+			}
+
+			entry := *env.auditEntry
+			if entry.CommandType == "" {
+				entry.CommandType = env.commandType
+			}
+			err = audit.Record(ctx, env.tx, timeSource.Now(), entry)
+			if err != nil {
+				return errors.Wrap(err, "recording audit entry")
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	logger "github.com/apex/log"
+	"github.com/apex/log/handlers/memory"
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"myvendor.mytld/myproject/backend/domain"
+	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/security/authentication"
+	"myvendor.mytld/myproject/backend/security/authorization"
+	"myvendor.mytld/myproject/backend/test"
+	test_telemetry "myvendor.mytld/myproject/backend/test/telemetry"
+)
+
+func TestDispatch(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	sysAdminAuthCtx := authentication.AuthContext{Authenticated: true, Role: types.RoleSystemAdministrator}
+
+	requireNotAuthorized := func(t *testing.T, err error) {
+		var authorizationErr authorization.Error
+		require.ErrorAs(t, err, &authorizationErr)
+	}
+	requireInvalid := func(t *testing.T, err error) {
+		var fieldErr types.FieldError
+		require.ErrorAs(t, err, &fieldErr)
+		assert.Equal(t, "name", fieldErr.Field)
+	}
+
+	tt := []struct {
+		name          string
+		authCtx       authentication.AuthContext
+		orgName       string
+		handleErr     error
+		expectErr     func(t *testing.T, err error)
+		expectHandled bool
+	}{
+		{
+			name:          "handled",
+			authCtx:       sysAdminAuthCtx,
+			orgName:       "Acme",
+			expectHandled: true,
+		},
+		{
+			name:      "not authorized",
+			orgName:   "Acme",
+			expectErr: requireNotAuthorized,
+		},
+		{
+			name:      "invalid",
+			authCtx:   sysAdminAuthCtx,
+			expectErr: requireInvalid,
+		},
+		{
+			name: "invalid and not authorized",
+			// Commands are validated before they are authorized
+			expectErr: requireInvalid,
+		},
+		{
+			name:          "failed",
+			authCtx:       sysAdminAuthCtx,
+			orgName:       "Acme",
+			handleErr:     errFailed,
+			expectErr:     func(t *testing.T, err error) { require.ErrorIs(t, err, errFailed) },
+			expectHandled: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			reader, meterProvider := test_telemetry.SetupTestMeter(t)
+			spanRecorder := tracetest.NewSpanRecorder()
+			tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+
+			h := NewHandler(nil, domain.DefaultConfig(), Deps{
+				TimeSource:     test.FixedTime(),
+				MeterProvider:  meterProvider,
+				TracerProvider: tracerProvider,
+			})
+
+			cmd := command.OrganisationCreateCmd{
+				OrganisationID: uuid.Must(uuid.FromString("6330de58-2761-411e-a243-bec6d0c53876")),
+				Name:           tc.orgName,
+			}
+
+			logHandler := memory.New()
+			ctx := logger.NewContext(context.Background(), &logger.Logger{Handler: logHandler, Level: logger.InfoLevel})
+			ctx = authentication.WithAuthContext(ctx, tc.authCtx)
+			var handled bool
+			err := dispatch(ctx, h, cmd, func(ctx context.Context, cmd command.OrganisationCreateCmd) error {
+				handled = true
+
+				// The logger of the command is passed to the handler
+				logger.FromContext(ctx).Info("Handling test")
+
+				return tc.handleErr
+			})
+			if tc.expectErr != nil {
+				tc.expectErr(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectHandled, handled)
+
+			for _, entry := range logHandler.Entries {
+				assert.Equal(t, "OrganisationCreate", entry.Fields["handler"], "handler field of %q", entry.Message)
+				// Fields of the command are logged
+				assert.Equal(t, "Acme", entry.Fields["organisationName"], "organisationName field of %q", entry.Message)
+			}
+			if tc.expectErr == nil {
+				require.Len(t, logHandler.Entries, 2)
+				assert.Equal(t, "Handled command", logHandler.Entries[1].Message)
+			}
+
+			test_telemetry.AssertMeterHistogramCount(t, reader, instrumentationName, "commands.duration", 1)
+
+			spans := spanRecorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "command OrganisationCreate", spans[0].Name())
+			if tc.expectErr != nil {
+				assert.Equal(t, codes.Error, spans[0].Status().Code)
+			} else {
+				assert.Equal(t, codes.Unset, spans[0].Status().Code)
+			}
+		})
+	}
+}
@@ -3,34 +3,52 @@ package handler
 import (
 	"database/sql"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 
 	"myvendor.mytld/myproject/backend/domain"
 	"myvendor.mytld/myproject/backend/domain/types"
 	"myvendor.mytld/myproject/backend/mail"
+	"myvendor.mytld/myproject/backend/storage"
 )
 
 type Handler struct {
 	db         *sql.DB
 	timeSource types.TimeSource
 	mailer     *mail.Mailer
+	storage    storage.Storage
 	config     domain.Config
 
 	instrumentation instrumentation
+	bus             bus
 }
 
 type Deps struct {
 	TimeSource    types.TimeSource
 	Mailer        *mail.Mailer
+	Storage       storage.Storage
 	MeterProvider metric.MeterProvider
+	// TracerProvider for spans of handled commands, the global provider is used if nil
+	TracerProvider trace.TracerProvider
 }
 
 func NewHandler(db *sql.DB, config domain.Config, deps Deps) *Handler {
-	return &Handler{
+	tracerProvider := deps.TracerProvider
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+
+	h := &Handler{
 		db:              db,
 		config:          config,
 		timeSource:      deps.TimeSource,
 		mailer:          deps.Mailer,
+		storage:         deps.Storage,
 		instrumentation: initInstrumentation(deps.MeterProvider),
 	}
+	h.bus = bus{
+		middlewares: defaultMiddlewares(db, config, deps.TimeSource, tracerProvider.Tracer(instrumentationName), deps.MeterProvider, h.instrumentation),
+	}
+	return h
 }
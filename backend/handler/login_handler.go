@@ -16,57 +16,46 @@ import (
 
 var ErrLoginInvalidCredentials = std_errors.New("invalid credentials")
 
-func (h *Handler) Login(ctx context.Context, cmd command.LoginCmd) (err error) {
-	log := logger.
-		FromContext(ctx).
-		WithField("handler", "login")
+func (h *Handler) Login(ctx context.Context, cmd command.LoginCmd) error {
+	return dispatch(ctx, h, cmd, func(ctx context.Context, cmd command.LoginCmd) error {
+		log := logger.FromContext(ctx)
 
-	log.
-		WithField("emailAddress", cmd.EmailAddress).
-		Debug("Handling login")
-
-	account := cmd.Account
-	if cmd.Account == nil {
-		// Use an empty user to have constant password compare times
-		account = model.Account{
-			PasswordHash: security_helper.DefaultHashForComparison(h.config.HashCost),
-		}
-	}
-
-	err = security_helper.CompareHashAndPassword(account.GetPasswordHash(), []byte(cmd.Password))
-	if err != nil || cmd.Account == nil {
-		// Log warning to find potential attacks
+		account := cmd.Account
 		if cmd.Account == nil {
-			log.
-				WithField("emailAddress", cmd.EmailAddress).
-				WithField("errorCode", types.ErrorCodeNotExists).
-				Warn("Login failed, account not found")
-		} else {
-			log.
-				WithField("emailAddress", cmd.EmailAddress).
-				WithField("errorCode", "invalidPassword").
-				WithError(err).
-				Warn("Login failed, invalid password")
+			// Use an empty user to have constant password compare times
+			account = model.Account{
+				PasswordHash: security_helper.DefaultHashForComparison(h.config.HashCost),
+			}
 		}
 
-		h.instrumentation.loginFailedCounter.Add(ctx, 1)
-
-		return ErrLoginInvalidCredentials
-	}
-
-	now := h.timeSource.Now()
-	ptrNow := &now
-	err = repository.UpdateAccount(ctx, h.db, account.GetAccountID(), repository.AccountChangeSet{LastLogin: &ptrNow})
-	if err != nil {
-		return fog_errors.Wrap(err, "updating account last login")
-	}
+		err := security_helper.CompareHashAndPassword(account.GetPasswordHash(), []byte(cmd.Password))
+		if err != nil || cmd.Account == nil {
+			// Log warning to find potential attacks
+			if cmd.Account == nil {
+				log.
+					WithField("errorCode", types.ErrorCodeNotExists).
+					Warn("Login failed, account not found")
+			} else {
+				log.
+					WithField("errorCode", "invalidPassword").
+					WithError(err).
+					Warn("Login failed, invalid password")
+			}
+
+			h.instrumentation.loginFailedCounter.Add(ctx, 1)
+
+			return ErrLoginInvalidCredentials
+		}
 
-	h.instrumentation.loginSuccessCounter.Add(ctx, 1)
+		now := h.timeSource.Now()
+		ptrNow := &now
+		err = repository.UpdateAccount(ctx, h.db, account.GetAccountID(), repository.AccountChangeSet{LastLogin: &ptrNow}, nil)
+		if err != nil {
+			return fog_errors.Wrap(err, "updating account last login")
+		}
 
-	log.
-		WithField("emailAddress", cmd.EmailAddress).
-		WithField("accountID", account.GetAccountID()).
-		Info("Login success")
+		h.instrumentation.loginSuccessCounter.Add(ctx, 1)
 
-	return nil
+		return nil
+	})
 }
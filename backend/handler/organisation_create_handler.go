@@ -4,34 +4,19 @@ import (
 	"context"
 	"database/sql"
 
-	logger "github.com/apex/log"
 	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
 
 	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/event"
+	"myvendor.mytld/myproject/backend/persistence/audit"
+	"myvendor.mytld/myproject/backend/persistence/notification"
+	"myvendor.mytld/myproject/backend/persistence/outbox"
 	"myvendor.mytld/myproject/backend/persistence/repository"
-	"myvendor.mytld/myproject/backend/security/authentication"
-	"myvendor.mytld/myproject/backend/security/authorization"
 )
 
 func (h *Handler) OrganisationCreate(ctx context.Context, cmd command.OrganisationCreateCmd) error {
-	log := logger.FromContext(ctx).
-		WithField("component", "handler").
-		WithField("handler", "OrganisationCreate")
-
-	log.
-		WithField("cmd", cmd).
-		Debug("Handling organisation create command")
-
-	if err := cmd.Validate(); err != nil {
-		return err
-	}
-
-	authCtx := authentication.GetAuthContext(ctx)
-	if err := authorization.NewAuthorizer(authCtx).AllowsOrganisationCreateCmd(cmd); err != nil {
-		return err
-	}
-
-	err := repository.Transactional(ctx, h.db, func(tx *sql.Tx) error {
+	return dispatchTx(ctx, h, cmd, func(ctx context.Context, tx *sql.Tx, cmd command.OrganisationCreateCmd) (*audit.Entry, error) {
 		changeSet := repository.OrganisationChangeSet{
 			ID:   &cmd.OrganisationID,
 			Name: &cmd.Name,
@@ -40,21 +25,31 @@ func (h *Handler) OrganisationCreate(ctx context.Context, cmd command.Organisati
 		err := repository.InsertOrganisation(ctx, tx, changeSet)
 		if err != nil {
 			if constraintErr := repository.OrganisationConstraintErr(err); constraintErr != nil {
-				return constraintErr
+				return nil, constraintErr
 			}
-			return errors.Wrap(err, "insert organisation")
+			return nil, errors.Wrap(err, "insert organisation")
 		}
 
-		return nil
-	})
-	if err != nil {
-		return errors.Wrap(err, "running transaction")
-	}
+		err = notification.Notify(ctx, tx, notification.OrganisationChanged{
+			Action:         notification.ChangeActionCreated,
+			OrganisationID: cmd.OrganisationID,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "notifying organisation change")
+		}
 
-	log.
-		WithField("organisationID", cmd.OrganisationID).
-		WithField("organisationName", cmd.Name).
-		Info("Created organisation")
+		err = outbox.Append(ctx, tx, h.timeSource.Now(), event.OrganisationCreated{
+			OrganisationID: cmd.OrganisationID,
+			Name:           cmd.Name,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "appending organisation event")
+		}
 
-	return nil
+		return &audit.Entry{
+			OrganisationID: uuid.NullUUID{UUID: cmd.OrganisationID, Valid: true},
+			TargetIDs:      []uuid.UUID{cmd.OrganisationID},
+			Changes:        audit.Changes{}.Set("name", nil, cmd.Name),
+		}, nil
+	})
 }
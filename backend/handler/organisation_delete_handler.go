@@ -4,57 +4,93 @@ import (
 	"context"
 	"database/sql"
 
-	logger "github.com/apex/log"
 	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
 
 	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/event"
 	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/audit"
+	"myvendor.mytld/myproject/backend/persistence/notification"
+	"myvendor.mytld/myproject/backend/persistence/outbox"
 	"myvendor.mytld/myproject/backend/persistence/repository"
-	"myvendor.mytld/myproject/backend/security/authentication"
-	"myvendor.mytld/myproject/backend/security/authorization"
 )
 
 func (h *Handler) OrganisationDelete(ctx context.Context, cmd command.OrganisationDeleteCmd) error {
-	log := logger.FromContext(ctx).
-		WithField("component", "handler").
-		WithField("handler", "OrganisationDelete")
-
-	log.
-		WithField("cmd", cmd).
-		Debug("Handling organisation delete command")
-
-	authCtx := authentication.GetAuthContext(ctx)
-	if err := authorization.NewAuthorizer(authCtx).AllowsOrganisationDeleteCmd(cmd); err != nil {
-		return err
-	}
-
-	var organisationName string
-	err := repository.Transactional(ctx, h.db, func(tx *sql.Tx) error {
+	return dispatchTx(ctx, h, cmd, func(ctx context.Context, tx *sql.Tx, cmd command.OrganisationDeleteCmd) (*audit.Entry, error) {
 		prevRecord, err := repository.FindOrganisationByID(ctx, tx, cmd.OrganisationID, nil)
 		if errors.Is(err, repository.ErrNotFound) {
-			return types.FieldError{
+			return nil, types.FieldError{
 				Field: "organisationId",
 				Code:  types.ErrorCodeNotExists,
 			}
 		} else if err != nil {
-			return errors.Wrap(err, "finding organisation")
+			return nil, errors.Wrap(err, "finding organisation")
 		}
-		organisationName = prevRecord.Name
 
-		err = repository.DeleteOrganisation(ctx, tx, cmd.OrganisationID)
+		// Accounts are deleted with the organisation, a restore of the organisation restores them by the same deletion time
+		accounts, err := repository.FindAllAccounts(ctx, tx, repository.AccountsFilter{
+			OrganisationID: &cmd.OrganisationID,
+		})
 		if err != nil {
-			return errors.Wrap(err, "deleting organisation")
+			return nil, errors.Wrap(err, "finding accounts of organisation")
+		}
+
+		deletedAt := h.timeSource.Now()
+		deletedAtPtr := &deletedAt
+		err = repository.UpdateOrganisation(ctx, tx, cmd.OrganisationID, repository.OrganisationChangeSet{
+			DeletedAt: &deletedAtPtr,
+		}, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "deleting organisation")
+		}
+
+		accountIDs := make([]uuid.UUID, len(accounts))
+		for i, account := range accounts {
+			accountIDs[i] = account.ID
+		}
+		err = repository.SetAccountsDeletedAt(ctx, tx, accountIDs, &deletedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "deleting accounts of organisation")
+		}
+
+		err = notification.Notify(ctx, tx, notification.OrganisationChanged{
+			Action:         notification.ChangeActionDeleted,
+			OrganisationID: cmd.OrganisationID,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "notifying organisation change")
+		}
+		for _, account := range accounts {
+			err = notification.Notify(ctx, tx, notification.AccountChanged{
+				Action:         notification.ChangeActionDeleted,
+				AccountID:      account.ID,
+				OrganisationID: account.OrganisationID,
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "notifying account change")
+			}
 		}
-		return nil
-	})
-	if err != nil {
-		return errors.Wrap(err, "running transaction")
-	}
 
-	log.
-		WithField("organisationID", cmd.OrganisationID).
-		WithField("organisationName", organisationName).
-		Info("Deleted organisation")
+		events := []event.Event{event.OrganisationDeleted{
+			OrganisationID: cmd.OrganisationID,
+			Name:           prevRecord.Name,
+		}}
+		for _, account := range accounts {
+			events = append(events, event.AccountDeleted{
+				AccountID:      account.ID,
+				OrganisationID: account.OrganisationID,
+			})
+		}
+		err = outbox.Append(ctx, tx, h.timeSource.Now(), events...)
+		if err != nil {
+			return nil, errors.Wrap(err, "appending organisation events")
+		}
 
-	return nil
+		return &audit.Entry{
+			OrganisationID: uuid.NullUUID{UUID: cmd.OrganisationID, Valid: true},
+			TargetIDs:      append([]uuid.UUID{cmd.OrganisationID}, accountIDs...),
+			Changes:        audit.Changes{}.Set("deletedAt", nil, deletedAt),
+		}, nil
+	})
 }
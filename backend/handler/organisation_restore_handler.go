@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/event"
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/audit"
+	"myvendor.mytld/myproject/backend/persistence/notification"
+	"myvendor.mytld/myproject/backend/persistence/outbox"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+func (h *Handler) OrganisationRestore(ctx context.Context, cmd command.OrganisationRestoreCmd) error {
+	return dispatchTx(ctx, h, cmd, func(ctx context.Context, tx *sql.Tx, cmd command.OrganisationRestoreCmd) (*audit.Entry, error) {
+		prevRecord, err := repository.FindDeletedOrganisationByID(ctx, tx, cmd.OrganisationID, nil)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, types.FieldError{
+				Field: "organisationId",
+				Code:  types.ErrorCodeNotExists,
+			}
+		} else if err != nil {
+			return nil, errors.Wrap(err, "finding organisation")
+		}
+
+		// Only accounts deleted together with the organisation are restored, not the ones deleted before
+		deletedAccounts, err := repository.FindAllAccounts(ctx, tx, repository.AccountsFilter{
+			OrganisationID: &cmd.OrganisationID,
+			WithDeleted:    true,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "finding accounts of organisation")
+		}
+		var accounts []model.Account
+		for _, account := range deletedAccounts {
+			if account.DeletedAt != nil && account.DeletedAt.Equal(*prevRecord.DeletedAt) {
+				accounts = append(accounts, account)
+			}
+		}
+
+		var deletedAt *time.Time
+		err = repository.UpdateOrganisation(ctx, tx, cmd.OrganisationID, repository.OrganisationChangeSet{
+			DeletedAt: &deletedAt,
+		}, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "restoring organisation")
+		}
+
+		accountIDs := make([]uuid.UUID, len(accounts))
+		for i, account := range accounts {
+			accountIDs[i] = account.ID
+		}
+		err = repository.SetAccountsDeletedAt(ctx, tx, accountIDs, nil)
+		if err != nil {
+			if constraintErr := repository.AccountConstraintErr(err); constraintErr != nil {
+				return nil, constraintErr
+			}
+			return nil, errors.Wrap(err, "restoring accounts of organisation")
+		}
+
+		err = notification.Notify(ctx, tx, notification.OrganisationChanged{
+			Action:         notification.ChangeActionRestored,
+			OrganisationID: cmd.OrganisationID,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "notifying organisation change")
+		}
+		for _, account := range accounts {
+			err = notification.Notify(ctx, tx, notification.AccountChanged{
+				Action:         notification.ChangeActionRestored,
+				AccountID:      account.ID,
+				OrganisationID: account.OrganisationID,
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "notifying account change")
+			}
+		}
+
+		events := []event.Event{event.OrganisationRestored{
+			OrganisationID: cmd.OrganisationID,
+			Name:           prevRecord.Name,
+		}}
+		for _, account := range accounts {
+			events = append(events, event.AccountRestored{
+				AccountID:      account.ID,
+				OrganisationID: account.OrganisationID,
+			})
+		}
+		err = outbox.Append(ctx, tx, h.timeSource.Now(), events...)
+		if err != nil {
+			return nil, errors.Wrap(err, "appending organisation events")
+		}
+
+		return &audit.Entry{
+			OrganisationID: uuid.NullUUID{UUID: cmd.OrganisationID, Valid: true},
+			TargetIDs:      append([]uuid.UUID{cmd.OrganisationID}, accountIDs...),
+			Changes:        audit.Changes{}.Set("deletedAt", prevRecord.DeletedAt, nil),
+		}, nil
+	})
+}
@@ -4,70 +4,62 @@ import (
 	"context"
 	"database/sql"
 
-	logger "github.com/apex/log"
 	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
 
 	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/event"
 	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/audit"
+	"myvendor.mytld/myproject/backend/persistence/notification"
+	"myvendor.mytld/myproject/backend/persistence/outbox"
 	"myvendor.mytld/myproject/backend/persistence/repository"
-	"myvendor.mytld/myproject/backend/security/authentication"
-	"myvendor.mytld/myproject/backend/security/authorization"
 )
 
 func (h *Handler) OrganisationUpdate(ctx context.Context, cmd command.OrganisationUpdateCmd) error {
-	log := logger.FromContext(ctx).
-		WithField("component", "handler").
-		WithField("handler", "OrganisationUpdate")
-
-	log.
-		WithField("cmd", cmd).
-		Debug("Handling organisation update command")
-
-	if err := cmd.Validate(); err != nil {
-		return err
-	}
-
-	authCtx := authentication.GetAuthContext(ctx)
-	if err := authorization.NewAuthorizer(authCtx).AllowsOrganisationUpdateCmd(cmd); err != nil {
-		return err
-	}
-
-	var prevOrganisationName string
-	err := repository.Transactional(ctx, h.db, func(tx *sql.Tx) error {
+	return dispatchTx(ctx, h, cmd, func(ctx context.Context, tx *sql.Tx, cmd command.OrganisationUpdateCmd) (*audit.Entry, error) {
 		prevRecord, err := repository.FindOrganisationByID(ctx, tx, cmd.OrganisationID, nil)
 		if errors.Is(err, repository.ErrNotFound) {
-			return types.FieldError{
+			return nil, types.FieldError{
 				Field: "organisationId",
 				Code:  types.ErrorCodeNotExists,
 			}
 		} else if err != nil {
-			return errors.Wrap(err, "finding organisation")
+			return nil, errors.Wrap(err, "finding organisation")
 		}
-		prevOrganisationName = prevRecord.Name
 
 		changeSet := repository.OrganisationChangeSet{
 			Name: &cmd.Name,
 		}
 
-		err = repository.UpdateOrganisation(ctx, tx, cmd.OrganisationID, changeSet)
+		err = repository.UpdateOrganisation(ctx, tx, cmd.OrganisationID, changeSet, cmd.ExpectedVersion)
 		if err != nil {
 			if constraintErr := repository.OrganisationConstraintErr(err); constraintErr != nil {
-				return constraintErr
+				return nil, constraintErr
 			}
-			return errors.Wrap(err, "update organisation")
+			return nil, errors.Wrap(err, "update organisation")
 		}
 
-		return nil
-	})
-	if err != nil {
-		return errors.Wrap(err, "running transaction")
-	}
+		err = notification.Notify(ctx, tx, notification.OrganisationChanged{
+			Action:         notification.ChangeActionUpdated,
+			OrganisationID: cmd.OrganisationID,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "notifying organisation change")
+		}
 
-	log.
-		WithField("organisationID", cmd.OrganisationID).
-		WithField("organisationName", cmd.Name).
-		WithField("prevOrganisationName", prevOrganisationName).
-		Info("Updated organisation")
+		err = outbox.Append(ctx, tx, h.timeSource.Now(), event.OrganisationUpdated{
+			OrganisationID: cmd.OrganisationID,
+			Name:           cmd.Name,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "appending organisation event")
+		}
 
-	return nil
+		return &audit.Entry{
+			OrganisationID: uuid.NullUUID{UUID: cmd.OrganisationID, Valid: true},
+			TargetIDs:      []uuid.UUID{cmd.OrganisationID},
+			Changes:        audit.Changes{}.Set("name", prevRecord.Name, cmd.Name),
+		}, nil
+	})
 }
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain/command"
+	domain_query "myvendor.mytld/myproject/backend/domain/query"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/mail"
+	"myvendor.mytld/myproject/backend/persistence/audit"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+func (h *Handler) SupportRequestSubmit(ctx context.Context, cmd command.SupportRequestSubmitCmd) error {
+	// The mail is sent in the transaction, a retry would send it again
+	return dispatchTxNoRetry(ctx, h, cmd, func(ctx context.Context, tx *sql.Tx, cmd command.SupportRequestSubmitCmd) (*audit.Entry, error) {
+		// Concurrent requests of the same account must not bypass the rate limit
+		err := repository.LockAccount(ctx, tx, cmd.AccountID)
+		if err != nil {
+			return nil, errors.Wrap(err, "locking account")
+		}
+
+		now := h.timeSource.Now()
+		count, err := repository.CountSupportRequestsSince(ctx, tx, cmd.AccountID, now.Add(-h.config.SupportRequestLimitInterval))
+		if err != nil {
+			return nil, errors.Wrap(err, "counting support requests")
+		}
+		if count >= h.config.SupportRequestLimit {
+			return nil, types.FieldError{
+				Code:      types.ErrorCodeRateLimitExceeded,
+				Arguments: []string{strconv.Itoa(h.config.SupportRequestLimit)},
+			}
+		}
+
+		err = repository.InsertSupportRequest(ctx, tx, repository.SupportRequestChangeSet{
+			ID:        &cmd.SupportRequestID,
+			AccountID: &cmd.AccountID,
+			Subject:   &cmd.Subject,
+			CreatedAt: &now,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "inserting support request")
+		}
+
+		account, err := repository.FindAccountByID(ctx, tx, cmd.AccountID, &domain_query.AccountQueryOpts{
+			IncludeOrganisation: true,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "finding account")
+		}
+
+		msg := mail.SupportFormMsg{
+			SenderEmailAddress: account.EmailAddress,
+			SenderName:         account.EmailAddress,
+			Subject:            cmd.Subject,
+			Message:            cmd.Message,
+		}
+		if account.Organisation != nil {
+			msg.OrganisationName = account.Organisation.Name
+		}
+		if cmd.Attachment != nil {
+			msg.FileName = cmd.Attachment.Filename
+			msg.AttachedFile = cmd.Attachment.Content()
+		}
+
+		// The mail is sent inside the transaction, so a failed delivery does not count towards the limit
+		err = h.mailer.Send(ctx, msg)
+		if err != nil {
+			return nil, errors.Wrap(err, "sending support form mail")
+		}
+
+		return &audit.Entry{
+			OrganisationID: account.OrganisationID,
+			TargetIDs:      []uuid.UUID{cmd.SupportRequestID},
+			Changes:        audit.Changes{}.Set("subject", nil, cmd.Subject),
+		}, nil
+	})
+}
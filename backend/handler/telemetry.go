@@ -5,9 +5,12 @@ import (
 	"go.opentelemetry.io/otel/metric/noop"
 )
 
+const instrumentationName = "myvendor.mytld/myproject/backend/handler"
+
 type instrumentation struct {
 	loginSuccessCounter metric.Int64Counter
 	loginFailedCounter  metric.Int64Counter
+	commandDuration     metric.Float64Histogram
 }
 
 func initInstrumentation(provider metric.MeterProvider) instrumentation {
@@ -15,7 +18,7 @@ func initInstrumentation(provider metric.MeterProvider) instrumentation {
 		provider = noop.NewMeterProvider()
 	}
 
-	meter := provider.Meter("myvendor.mytld/myproject/backend/handler")
+	meter := provider.Meter(instrumentationName)
 
 	return instrumentation{
 		loginSuccessCounter: mustInstrument(meter.Int64Counter(
@@ -28,6 +31,11 @@ func initInstrumentation(provider metric.MeterProvider) instrumentation {
 			metric.WithDescription("Number of failed logins."),
 			metric.WithUnit("{call}"),
 		)),
+		commandDuration: mustInstrument(meter.Float64Histogram(
+			"commands.duration",
+			metric.WithDescription("Duration of handled commands."),
+			metric.WithUnit("s"),
+		)),
 	}
 }
 
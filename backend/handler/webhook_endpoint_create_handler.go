@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/audit"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+func (h *Handler) WebhookEndpointCreate(ctx context.Context, cmd command.WebhookEndpointCreateCmd) error {
+	return dispatchTx(ctx, h, cmd, func(ctx context.Context, tx *sql.Tx, cmd command.WebhookEndpointCreateCmd) (*audit.Entry, error) {
+		_, err := repository.FindOrganisationByID(ctx, tx, cmd.OrganisationID, nil)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, types.FieldError{
+				Field: "organisationId",
+				Code:  types.ErrorCodeNotExists,
+			}
+		} else if err != nil {
+			return nil, errors.Wrap(err, "finding organisation")
+		}
+
+		changeSet := repository.WebhookEndpointChangeSet{
+			ID:             &cmd.WebhookEndpointID,
+			OrganisationID: &cmd.OrganisationID,
+			URL:            &cmd.URL,
+			Secret:         &cmd.Secret,
+			EventTypes:     cmd.EventTypes,
+		}
+
+		err = repository.InsertWebhookEndpoint(ctx, tx, changeSet)
+		if err != nil {
+			return nil, errors.Wrap(err, "inserting webhook endpoint")
+		}
+
+		return &audit.Entry{
+			OrganisationID: uuid.NullUUID{UUID: cmd.OrganisationID, Valid: true},
+			TargetIDs:      []uuid.UUID{cmd.WebhookEndpointID},
+			Changes: audit.Changes{}.
+				Set("url", nil, cmd.URL).
+				Set("eventTypes", nil, cmd.EventTypes).
+				SetRedacted("secret"),
+		}, nil
+	})
+}
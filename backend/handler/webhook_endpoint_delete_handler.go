@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/persistence/audit"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+func (h *Handler) WebhookEndpointDelete(ctx context.Context, cmd command.WebhookEndpointDeleteCmd) error {
+	return dispatchTx(ctx, h, cmd, func(ctx context.Context, tx *sql.Tx, cmd command.WebhookEndpointDeleteCmd) (*audit.Entry, error) {
+		prevRecord, err := findWebhookEndpointOfOrganisation(ctx, tx, cmd.WebhookEndpointID, cmd.OrganisationID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Deliveries are deleted by cascade, pending jobs skip deliveries that are gone
+		err = repository.DeleteWebhookEndpoint(ctx, tx, cmd.WebhookEndpointID)
+		if err != nil {
+			return nil, errors.Wrap(err, "deleting webhook endpoint")
+		}
+
+		return &audit.Entry{
+			OrganisationID: uuid.NullUUID{UUID: cmd.OrganisationID, Valid: true},
+			TargetIDs:      []uuid.UUID{cmd.WebhookEndpointID},
+			Changes: audit.Changes{}.
+				Set("url", prevRecord.URL, nil).
+				Set("eventTypes", prevRecord.EventTypes, nil),
+		}, nil
+	})
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/audit"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+func (h *Handler) WebhookEndpointUpdate(ctx context.Context, cmd command.WebhookEndpointUpdateCmd) error {
+	return dispatchTx(ctx, h, cmd, func(ctx context.Context, tx *sql.Tx, cmd command.WebhookEndpointUpdateCmd) (*audit.Entry, error) {
+		prevRecord, err := findWebhookEndpointOfOrganisation(ctx, tx, cmd.WebhookEndpointID, cmd.OrganisationID)
+		if err != nil {
+			return nil, err
+		}
+
+		changeSet := repository.WebhookEndpointChangeSet{
+			URL:        &cmd.URL,
+			EventTypes: cmd.EventTypes,
+		}
+
+		err = repository.UpdateWebhookEndpoint(ctx, tx, cmd.WebhookEndpointID, changeSet)
+		if err != nil {
+			return nil, errors.Wrap(err, "updating webhook endpoint")
+		}
+
+		return &audit.Entry{
+			OrganisationID: uuid.NullUUID{UUID: cmd.OrganisationID, Valid: true},
+			TargetIDs:      []uuid.UUID{cmd.WebhookEndpointID},
+			Changes: audit.Changes{}.
+				Set("url", prevRecord.URL, cmd.URL).
+				Set("eventTypes", prevRecord.EventTypes, cmd.EventTypes),
+		}, nil
+	})
+}
+
+// findWebhookEndpointOfOrganisation checks that an endpoint exists in the organisation the command was authorized for
+func findWebhookEndpointOfOrganisation(ctx context.Context, tx *sql.Tx, webhookEndpointID, organisationID uuid.UUID) (model.WebhookEndpoint, error) {
+	record, err := repository.FindWebhookEndpointByID(ctx, tx, webhookEndpointID)
+	if errors.Is(err, repository.ErrNotFound) || (err == nil && record.OrganisationID != organisationID) {
+		return record, types.FieldError{
+			Field: "id",
+			Code:  types.ErrorCodeNotExists,
+		}
+	} else if err != nil {
+		return record, errors.Wrap(err, "finding webhook endpoint")
+	}
+	return record, nil
+}
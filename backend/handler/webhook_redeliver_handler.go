@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/audit"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/webhook"
+)
+
+func (h *Handler) WebhookRedeliver(ctx context.Context, cmd command.WebhookRedeliverCmd) error {
+	return dispatchTx(ctx, h, cmd, func(ctx context.Context, tx *sql.Tx, cmd command.WebhookRedeliverCmd) (*audit.Entry, error) {
+		record, err := repository.FindWebhookDeliveryByID(ctx, tx, cmd.WebhookDeliveryID)
+		if errors.Is(err, repository.ErrNotFound) || (err == nil && record.OrganisationID != cmd.OrganisationID) {
+			return nil, types.FieldError{
+				Field: "deliveryId",
+				Code:  types.ErrorCodeNotExists,
+			}
+		} else if err != nil {
+			return nil, errors.Wrap(err, "finding webhook delivery")
+		}
+
+		// The attempts start again, so a redelivery gets the same retries as a new delivery
+		status := model.WebhookDeliveryStatusPending
+		attempts := 0
+		changeSet := repository.WebhookDeliveryChangeSet{
+			Status:   &status,
+			Attempts: &attempts,
+		}
+		err = repository.UpdateWebhookDelivery(ctx, tx, cmd.WebhookDeliveryID, changeSet)
+		if err != nil {
+			return nil, errors.Wrap(err, "updating webhook delivery")
+		}
+
+		err = webhook.Enqueue(ctx, tx, h.timeSource.Now(), cmd.WebhookDeliveryID)
+		if err != nil {
+			return nil, errors.Wrap(err, "enqueueing webhook delivery")
+		}
+
+		return &audit.Entry{
+			OrganisationID: uuid.NullUUID{UUID: cmd.OrganisationID, Valid: true},
+			TargetIDs:      []uuid.UUID{cmd.WebhookDeliveryID},
+			Changes:        audit.Changes{}.Set("status", record.Status, status),
+		}, nil
+	})
+}
@@ -0,0 +1,49 @@
+package imaging
+
+import (
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"github.com/friendsofgo/errors"
+)
+
+const jpegQuality = 85
+
+// Encoder writes an image in a specific format
+type Encoder func(w io.Writer, img image.Image) error
+
+// encoders by content type, more can be added with RegisterEncoder
+//
+//nolint:gochecknoglobals
+var encoders = map[string]Encoder{
+	"image/jpeg": func(w io.Writer, img image.Image) error {
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality})
+	},
+	"image/png": png.Encode,
+}
+
+// RegisterEncoder adds an encoder for a content type, it must be called during initialization
+func RegisterEncoder(contentType string, encoder Encoder) {
+	encoders[contentType] = encoder
+}
+
+// CanEncode checks if an encoder for the content type is registered
+func CanEncode(contentType string) bool {
+	_, ok := encoders[contentType]
+	return ok
+}
+
+// Encode writes an image with the encoder for the content type
+func Encode(w io.Writer, img image.Image, contentType string) error {
+	encoder, ok := encoders[contentType]
+	if !ok {
+		return ErrUnsupportedFormat
+	}
+	err := encoder(w, img)
+	if err != nil {
+		return errors.Wrapf(err, "encoding %s", contentType)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Package imaging validates, sanitizes and resizes uploaded images (JPEG, PNG and GIF).
+package imaging
+
+import (
+	"bytes"
+	std_errors "errors"
+	"image"
+	_ "image/gif" // Register GIF decoder
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+
+	"github.com/friendsofgo/errors"
+)
+
+// maxDecodePixels limits the pixels of images that are decoded, to guard against decompression bombs
+const maxDecodePixels = 50_000_000
+
+var (
+	ErrUnsupportedFormat = std_errors.New("unsupported image format")
+	ErrTooLarge          = std_errors.New("image too large to decode")
+)
+
+// Info about an encoded image that is read without decoding the pixels
+type Info struct {
+	// Format as registered in the image package (e.g. jpeg, png, gif)
+	Format string
+	Width  int
+	Height int
+}
+
+// DecodeInfo reads the format and dimensions of an image
+func DecodeInfo(r io.Reader) (Info, error) {
+	config, format, err := image.DecodeConfig(r)
+	if errors.Is(err, image.ErrFormat) {
+		return Info{}, ErrUnsupportedFormat
+	} else if err != nil {
+		return Info{}, errors.Wrap(err, "decoding image config")
+	}
+	return Info{
+		Format: format,
+		Width:  config.Width,
+		Height: config.Height,
+	}, nil
+}
+
+// Decode decodes an image after checking its dimensions
+func Decode(data []byte) (image.Image, error) {
+	if err := checkDimensions(data); err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding image")
+	}
+	return img, nil
+}
+
+// checkDimensions guards against decompression bombs before an image is decoded
+func checkDimensions(data []byte) error {
+	info, err := DecodeInfo(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if info.Width*info.Height > maxDecodePixels {
+		return ErrTooLarge
+	}
+	return nil
+}
+
+// IsImage checks if content of the given type is handled as an image
+func IsImage(contentType string) bool {
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+		return true
+	default:
+		return false
+	}
+}
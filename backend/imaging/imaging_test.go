@@ -0,0 +1,228 @@
+package imaging_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/imaging"
+)
+
+func TestDecodeInfo(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         []byte
+		expectedInfo imaging.Info
+		expectedErr  error
+	}{
+		{
+			name:         "png",
+			data:         encodePNG(t, newTestImage(40, 30)),
+			expectedInfo: imaging.Info{Format: "png", Width: 40, Height: 30},
+		},
+		{
+			name:         "jpeg",
+			data:         encodeJPEG(t, newTestImage(40, 30)),
+			expectedInfo: imaging.Info{Format: "jpeg", Width: 40, Height: 30},
+		},
+		{
+			name:        "no image",
+			data:        []byte("Hello world, this is not an image"),
+			expectedErr: imaging.ErrUnsupportedFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := imaging.DecodeInfo(bytes.NewReader(tt.data))
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedInfo, info)
+		})
+	}
+}
+
+func TestFit(t *testing.T) {
+	img := newTestImage(400, 200)
+
+	resized := imaging.Fit(img, 100, 100)
+	assert.Equal(t, image.Rect(0, 0, 100, 50), resized.Bounds())
+	// The left half is red, the right half blue
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, resized.At(10, 25))
+	assert.Equal(t, color.RGBA{B: 255, A: 255}, resized.At(90, 25))
+
+	resized = imaging.Fit(img, 1000, 50)
+	assert.Equal(t, image.Rect(0, 0, 100, 50), resized.Bounds())
+
+	// Smaller images are not scaled up
+	resized = imaging.Fit(img, 1000, 1000)
+	assert.Equal(t, image.Rect(0, 0, 400, 200), resized.Bounds())
+}
+
+func TestStripMetadata_JPEG(t *testing.T) {
+	data := encodeJPEG(t, newTestImage(40, 20))
+
+	t.Run("removes EXIF and comments", func(t *testing.T) {
+		withMetadata := insertJPEGSegments(data,
+			jpegSegment(0xe1, exifWithOrientation(1)),
+			jpegSegment(0xfe, []byte("Secret comment")),
+		)
+
+		stripped, err := imaging.StripMetadata(withMetadata, "image/jpeg")
+		require.NoError(t, err)
+
+		assert.NotContains(t, string(stripped), "Exif")
+		assert.NotContains(t, string(stripped), "Secret comment")
+		info, err := imaging.DecodeInfo(bytes.NewReader(stripped))
+		require.NoError(t, err)
+		assert.Equal(t, imaging.Info{Format: "jpeg", Width: 40, Height: 20}, info)
+	})
+
+	t.Run("applies orientation", func(t *testing.T) {
+		withMetadata := insertJPEGSegments(data, jpegSegment(0xe1, exifWithOrientation(6)))
+
+		stripped, err := imaging.StripMetadata(withMetadata, "image/jpeg")
+		require.NoError(t, err)
+
+		assert.NotContains(t, string(stripped), "Exif")
+		info, err := imaging.DecodeInfo(bytes.NewReader(stripped))
+		require.NoError(t, err)
+		assert.Equal(t, imaging.Info{Format: "jpeg", Width: 20, Height: 40}, info)
+	})
+}
+
+func TestStripMetadata_PNG(t *testing.T) {
+	data := encodePNG(t, newTestImage(40, 20))
+
+	// Insert a text chunk after the IHDR chunk (signature 8 bytes, IHDR 25 bytes)
+	withMetadata := append(append(append([]byte{}, data[:33]...), pngChunk("tEXt", []byte("Author\x00Jane Doe"))...), data[33:]...)
+
+	stripped, err := imaging.StripMetadata(withMetadata, "image/png")
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(stripped), "Jane Doe")
+	img, err := imaging.Decode(stripped)
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 40, 20), img.Bounds())
+}
+
+func TestStripMetadata_GIF(t *testing.T) {
+	frame := func(c color.Color) *image.Paletted {
+		return image.NewPaletted(image.Rect(0, 0, 40, 20), color.Palette{c})
+	}
+	var buf bytes.Buffer
+	require.NoError(t, gif.EncodeAll(&buf, &gif.GIF{
+		Image: []*image.Paletted{frame(color.RGBA{R: 255, A: 255}), frame(color.RGBA{B: 255, A: 255})},
+		Delay: []int{10, 10},
+	}))
+	data := buf.Bytes()
+
+	comment := append([]byte{0x21, 0xfe, 14}, "Secret comment"...)
+	comment = append(comment, 0)
+
+	// Insert a comment extension before the trailer
+	trailer := len(data) - 1
+	withMetadata := append(append(append([]byte{}, data[:trailer]...), comment...), data[trailer:]...)
+
+	stripped, err := imaging.StripMetadata(withMetadata, "image/gif")
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(stripped), "Secret comment")
+	g, err := gif.DecodeAll(bytes.NewReader(stripped))
+	require.NoError(t, err)
+	assert.Len(t, g.Image, 2)
+}
+
+func TestGenerateVariant(t *testing.T) {
+	data := encodePNG(t, newTestImage(800, 400))
+
+	variant, err := imaging.GenerateVariant(data, imaging.VariantThumbnail, "image/jpeg")
+	require.NoError(t, err)
+
+	info, err := imaging.DecodeInfo(bytes.NewReader(variant))
+	require.NoError(t, err)
+	assert.Equal(t, imaging.Info{Format: "jpeg", Width: 160, Height: 80}, info)
+
+	variant, err = imaging.GenerateVariant(data, imaging.VariantThumbnail, "image/png")
+	require.NoError(t, err)
+
+	info, err = imaging.DecodeInfo(bytes.NewReader(variant))
+	require.NoError(t, err)
+	assert.Equal(t, imaging.Info{Format: "png", Width: 160, Height: 80}, info)
+}
+
+// newTestImage creates an image with a red left and a blue right half
+func newTestImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if x < width/2 {
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
+// exifWithOrientation builds an EXIF APP1 payload with a big endian TIFF header and only the orientation tag
+func exifWithOrientation(orientation uint16) []byte {
+	payload := []byte("Exif\x00\x00MM\x00\x2a\x00\x00\x00\x08")
+	payload = binary.BigEndian.AppendUint16(payload, 1)
+	payload = binary.BigEndian.AppendUint16(payload, 0x0112)
+	payload = binary.BigEndian.AppendUint16(payload, 3)
+	payload = binary.BigEndian.AppendUint32(payload, 1)
+	payload = binary.BigEndian.AppendUint16(payload, orientation)
+	payload = binary.BigEndian.AppendUint16(payload, 0)
+	return binary.BigEndian.AppendUint32(payload, 0)
+}
+
+func jpegSegment(marker byte, payload []byte) []byte {
+	segment := []byte{0xff, marker}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// insertJPEGSegments inserts segments right after the start of image marker
+func insertJPEGSegments(data []byte, segments ...[]byte) []byte {
+	result := append([]byte{}, data[:2]...)
+	for _, segment := range segments {
+		result = append(result, segment...)
+	}
+	return append(result, data[2:]...)
+}
+
+func pngChunk(chunkType string, data []byte) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, chunkType...)
+	chunk = append(chunk, data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
@@ -0,0 +1,60 @@
+package imaging
+
+import (
+	"bytes"
+	"image/gif"
+
+	"github.com/disintegration/imaging"
+	"github.com/friendsofgo/errors"
+)
+
+// StripMetadata removes metadata like EXIF (camera, GPS location), XMP and comments from JPEG, PNG and GIF images.
+// Images are decoded and encoded again, which only keeps the pixels. JPEG images are rotated according to their
+// EXIF orientation first, so they are still displayed upright. GIF images keep all frames.
+// Other formats are returned unchanged.
+func StripMetadata(data []byte, contentType string) ([]byte, error) {
+	switch contentType {
+	case "image/jpeg", "image/png":
+		return reencode(data, contentType)
+	case "image/gif":
+		return reencodeGIF(data)
+	default:
+		return data, nil
+	}
+}
+
+func reencode(data []byte, contentType string) ([]byte, error) {
+	if err := checkDimensions(data); err != nil {
+		return nil, err
+	}
+
+	img, err := imaging.Decode(bytes.NewReader(data), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding image")
+	}
+
+	var buf bytes.Buffer
+	err = Encode(&buf, img, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func reencodeGIF(data []byte) ([]byte, error) {
+	if err := checkDimensions(data); err != nil {
+		return nil, err
+	}
+
+	g, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding GIF")
+	}
+
+	var buf bytes.Buffer
+	err = gif.EncodeAll(&buf, g)
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding GIF")
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,70 @@
+package imaging
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Fit scales an image down to fit into the given bounds while keeping the aspect ratio.
+// Images that already fit are not scaled up.
+func Fit(img image.Image, maxWidth, maxHeight int) *image.RGBA {
+	src := toRGBA(img)
+	srcWidth, srcHeight := src.Rect.Dx(), src.Rect.Dy()
+	if srcWidth <= maxWidth && srcHeight <= maxHeight {
+		return src
+	}
+
+	width, height := maxWidth, srcHeight*maxWidth/srcWidth
+	if height > maxHeight {
+		width, height = srcWidth*maxHeight/srcHeight, maxHeight
+	}
+	return resizeArea(src, max(width, 1), max(height, 1))
+}
+
+// resizeArea downscales with an area average (box filter), every source pixel contributes to exactly one target pixel.
+// Pixels of image.RGBA are alpha-premultiplied, so they can be averaged directly.
+func resizeArea(src *image.RGBA, width, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	srcWidth, srcHeight := src.Rect.Dx(), src.Rect.Dy()
+
+	for y := 0; y < height; y++ {
+		y0 := y * srcHeight / height
+		y1 := max((y+1)*srcHeight/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := x * srcWidth / width
+			x1 := max((x+1)*srcWidth/width, x0+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				i := sy*src.Stride + x0*4
+				for sx := x0; sx < x1; sx++ {
+					r += uint64(src.Pix[i])
+					g += uint64(src.Pix[i+1])
+					b += uint64(src.Pix[i+2])
+					a += uint64(src.Pix[i+3])
+					n++
+					i += 4
+				}
+			}
+
+			j := y*dst.Stride + x*4
+			dst.Pix[j] = uint8(r / n)
+			dst.Pix[j+1] = uint8(g / n)
+			dst.Pix[j+2] = uint8(b / n)
+			dst.Pix[j+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
+
+// toRGBA converts an image to RGBA with bounds starting at the origin
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Rect, img, bounds.Min, draw.Src)
+	return rgba
+}
@@ -0,0 +1,56 @@
+package imaging
+
+import "bytes"
+
+// Variant is a resized version of an image that is generated on demand
+type Variant struct {
+	Name      string
+	MaxWidth  int
+	MaxHeight int
+}
+
+// Only predefined variants can be requested, so the number of generated and cached images is bounded
+var (
+	VariantThumbnail = Variant{Name: "thumbnail", MaxWidth: 160, MaxHeight: 160}
+	VariantSmall     = Variant{Name: "small", MaxWidth: 480, MaxHeight: 480}
+	VariantLarge     = Variant{Name: "large", MaxWidth: 1600, MaxHeight: 1600}
+)
+
+// Variants returns all predefined variants
+func Variants() []Variant {
+	return []Variant{VariantThumbnail, VariantSmall, VariantLarge}
+}
+
+// VariantByName returns a predefined variant
+func VariantByName(name string) (Variant, bool) {
+	for _, v := range Variants() {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return Variant{}, false
+}
+
+// VariantContentType returns the content type of a variant for an image.
+// JPEG stays JPEG, everything else might have transparency and is encoded as PNG.
+func VariantContentType(sourceContentType string) string {
+	if sourceContentType == "image/jpeg" {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
+
+// GenerateVariant decodes an image, resizes it to the variant and encodes it with the content type
+func GenerateVariant(data []byte, variant Variant, contentType string) ([]byte, error) {
+	img, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = Encode(&buf, Fit(img, variant.MaxWidth, variant.MaxHeight), contentType)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,27 @@
+package audit
+
+import (
+	"context"
+)
+
+type ctxKey string
+
+const requestMetadataKey ctxKey = "auditRequestMetadata"
+
+// RequestMetadata identifies the request of a command in the audit log
+type RequestMetadata struct {
+	RequestID string
+	// IPAddress of the client (considering proxy headers)
+	IPAddress string
+}
+
+// WithRequestMetadata stores metadata of the current request in the context (set by a http middleware)
+func WithRequestMetadata(ctx context.Context, metadata RequestMetadata) context.Context {
+	return context.WithValue(ctx, requestMetadataKey, metadata)
+}
+
+// GetRequestMetadata gets the request metadata from the context, it is empty outside of requests (e.g. for the CLI)
+func GetRequestMetadata(ctx context.Context) RequestMetadata {
+	metadata, _ := ctx.Value(requestMetadataKey).(RequestMetadata)
+	return metadata
+}
@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/qrb/qrbsql"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/security/authentication"
+)
+
+// Entry describes an administrative command for the audit log
+type Entry struct {
+	// CommandType is the name of the handler (e.g. AccountUpdate)
+	CommandType    string
+	OrganisationID uuid.NullUUID
+	TargetIDs      []uuid.UUID
+	Changes        Changes
+}
+
+// Change of a field by a command
+type Change struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+	// Redacted is set for secrets (e.g. passwords), their values are not recorded
+	Redacted bool `json:"redacted,omitempty"`
+}
+
+// Changes maps fields to their change
+type Changes map[string]Change
+
+// Set records a change of the field if before and after differ.
+// Values are stored as JSON, use nil for before or after if the record was created or deleted.
+func (c Changes) Set(field string, before, after any) Changes {
+	if !reflect.DeepEqual(before, after) {
+		c[field] = Change{Before: before, After: after}
+	}
+	return c
+}
+
+// SetRedacted records a change of a secret field without its values
+func (c Changes) SetRedacted(field string) Changes {
+	c[field] = Change{Redacted: true}
+	return c
+}
+
+// Record appends an entry to the audit log.
+// It must be called in the transaction of the command, so an entry is only recorded if the command is committed.
+// Actor and request metadata are taken from the context.
+func Record(ctx context.Context, executor qrbsql.Executor, occurredAt time.Time, entry Entry) error {
+	changes := entry.Changes
+	if changes == nil {
+		changes = Changes{}
+	}
+	encodedChanges, err := json.Marshal(changes)
+	if err != nil {
+		return errors.Wrap(err, "encoding changes")
+	}
+
+	// Time ordered IDs keep the order of entries that occurred at the same time
+	entryID, err := uuid.NewV7()
+	if err != nil {
+		return errors.Wrap(err, "generating audit entry id")
+	}
+
+	record := model.AuditEntry{
+		ID:             entryID,
+		OccurredAt:     occurredAt,
+		OrganisationID: entry.OrganisationID,
+		CommandType:    entry.CommandType,
+		TargetIDs:      entry.TargetIDs,
+		Changes:        encodedChanges,
+	}
+	if record.TargetIDs == nil {
+		record.TargetIDs = []uuid.UUID{}
+	}
+
+	authCtx := authentication.GetAuthContext(ctx)
+	if authCtx.Authenticated && authCtx.AccountID != uuid.Nil {
+		record.ActorAccountID = uuid.NullUUID{UUID: authCtx.AccountID, Valid: true}
+		record.ImpersonatorAccountID = authCtx.ImpersonatorAccountID
+	}
+
+	metadata := GetRequestMetadata(ctx)
+	if metadata.RequestID != "" {
+		record.RequestID = &metadata.RequestID
+	}
+	if metadata.IPAddress != "" {
+		record.IPAddress = &metadata.IPAddress
+	}
+
+	err = repository.InsertAuditEntry(ctx, executor, repository.AuditEntryToChangeSet(record))
+	if err != nil {
+		return errors.Wrapf(err, "inserting audit entry for %s", entry.CommandType)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package audit_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"myvendor.mytld/myproject/backend/persistence/audit"
+)
+
+func TestChanges_Set(t *testing.T) {
+	name := "Acme Inc."
+	changes := audit.Changes{}.
+		Set("name", "Acme Inc.", "Acme Ltd.").
+		Set("role", "SystemAdministrator", "SystemAdministrator").
+		Set("organisationId", nil, nil).
+		Set("email", nil, &name).
+		SetRedacted("password")
+
+	assert.Equal(t, audit.Changes{
+		"name":     {Before: "Acme Inc.", After: "Acme Ltd."},
+		"email":    {Before: nil, After: &name},
+		"password": {Redacted: true},
+	}, changes)
+}
@@ -0,0 +1,93 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/qrb/qrbsql"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+// DefaultMaxAttempts is the number of attempts for a job if not set when enqueueing
+const DefaultMaxAttempts = 10
+
+// Job is the payload of a background job, it is stored as JSON in the queue
+type Job interface {
+	JobType() string
+}
+
+type enqueueOptions struct {
+	runAt       *time.Time
+	uniqueKey   *string
+	maxAttempts int
+}
+
+type EnqueueOption func(o *enqueueOptions)
+
+// RunAt schedules the job to run at the given time instead of immediately
+func RunAt(runAt time.Time) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.runAt = &runAt
+	}
+}
+
+// UniqueKey skips enqueueing the job if a job of the same type with the same key is pending or running
+func UniqueKey(key string) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.uniqueKey = &key
+	}
+}
+
+// MaxAttempts sets the number of attempts after which a failing job is not retried anymore
+func MaxAttempts(attempts int) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.maxAttempts = attempts
+	}
+}
+
+// Enqueue adds a job to the queue.
+// If called in a transaction, the job is only visible to workers after the transaction is committed.
+// It returns false if the job was skipped because of its unique key.
+func Enqueue(ctx context.Context, executor qrbsql.Executor, now time.Time, job Job, opts ...EnqueueOption) (enqueued bool, err error) {
+	options := enqueueOptions{
+		maxAttempts: DefaultMaxAttempts,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	payload, err := json.Marshal(job)
+	if err != nil {
+		return false, errors.Wrapf(err, "encoding %s", job.JobType())
+	}
+
+	jobID, err := uuid.NewV7()
+	if err != nil {
+		return false, errors.Wrap(err, "generating job id")
+	}
+
+	runAt := now
+	if options.runAt != nil {
+		runAt = *options.runAt
+	}
+
+	enqueued, err = repository.InsertJob(ctx, executor, repository.JobToChangeSet(model.Job{
+		ID:          jobID,
+		JobType:     job.JobType(),
+		Payload:     payload,
+		UniqueKey:   options.uniqueKey,
+		Status:      model.JobStatusPending,
+		MaxAttempts: options.maxAttempts,
+		RunAt:       runAt,
+		CreatedAt:   now,
+	}))
+	if err != nil {
+		return false, errors.Wrapf(err, "inserting %s", job.JobType())
+	}
+	return enqueued, nil
+}
@@ -0,0 +1,49 @@
+package jobqueue
+
+import (
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+const meterName = "myvendor.mytld/myproject/backend/persistence/jobqueue"
+
+type instrumentation struct {
+	meter       metric.Meter
+	queueDepth  metric.Int64ObservableGauge
+	jobLatency  metric.Float64Histogram
+	jobDuration metric.Float64Histogram
+}
+
+func initInstrumentation(provider metric.MeterProvider) instrumentation {
+	if provider == nil {
+		provider = noop.NewMeterProvider()
+	}
+
+	meter := provider.Meter(meterName)
+
+	return instrumentation{
+		meter: meter,
+		queueDepth: mustInstrument(meter.Int64ObservableGauge(
+			"jobs.queue.depth",
+			metric.WithDescription("Number of pending jobs that are due."),
+			metric.WithUnit("{job}"),
+		)),
+		jobLatency: mustInstrument(meter.Float64Histogram(
+			"jobs.latency",
+			metric.WithDescription("Time between the scheduled run time of a job and its start."),
+			metric.WithUnit("s"),
+		)),
+		jobDuration: mustInstrument(meter.Float64Histogram(
+			"jobs.duration",
+			metric.WithDescription("Duration of job runs."),
+			metric.WithUnit("s"),
+		)),
+	}
+}
+
+func mustInstrument[T any](instrument T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return instrument
+}
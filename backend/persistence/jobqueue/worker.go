@@ -0,0 +1,312 @@
+package jobqueue
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	logger "github.com/apex/log"
+	"github.com/friendsofgo/errors"
+	"github.com/getsentry/sentry-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+const (
+	defaultConcurrency  = 4
+	defaultPollInterval = time.Second
+	defaultLockDuration = 5 * time.Minute
+	defaultBackoffBase  = 10 * time.Second
+	defaultBackoffMax   = time.Hour
+)
+
+// HandlerFunc runs a job with the raw JSON payload
+type HandlerFunc func(ctx context.Context, payload json.RawMessage) error
+
+// Worker runs jobs from the queue with a pool of goroutines.
+// Multiple workers (e.g. in several server instances) can run concurrently, a job is only claimed by one worker at a time.
+type Worker struct {
+	db         *sql.DB
+	timeSource types.TimeSource
+
+	concurrency  int
+	pollInterval time.Duration
+	lockDuration time.Duration
+	backoffBase  time.Duration
+	backoffMax   time.Duration
+
+	instrumentation instrumentation
+
+	mu       sync.RWMutex
+	handlers map[string]HandlerFunc
+}
+
+type Option func(w *Worker)
+
+// WithConcurrency sets the number of jobs that are run in parallel
+func WithConcurrency(concurrency int) Option {
+	return func(w *Worker) {
+		w.concurrency = concurrency
+	}
+}
+
+// WithPollInterval sets the interval for checking the queue for due jobs if it was empty
+func WithPollInterval(interval time.Duration) Option {
+	return func(w *Worker) {
+		w.pollInterval = interval
+	}
+}
+
+// WithLockDuration sets the maximum duration of a job run.
+// A job that is not finished after this duration is cancelled and can be claimed again.
+func WithLockDuration(duration time.Duration) Option {
+	return func(w *Worker) {
+		w.lockDuration = duration
+	}
+}
+
+// WithBackoff sets the delay after the first failed attempt, it is doubled for every further attempt up to max
+func WithBackoff(base, max time.Duration) Option {
+	return func(w *Worker) {
+		w.backoffBase = base
+		w.backoffMax = max
+	}
+}
+
+// WithMeterProvider sets the provider for the queue depth and job latency metrics
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(w *Worker) {
+		w.instrumentation = initInstrumentation(provider)
+	}
+}
+
+func NewWorker(db *sql.DB, timeSource types.TimeSource, opts ...Option) *Worker {
+	w := &Worker{
+		db:              db,
+		timeSource:      timeSource,
+		concurrency:     defaultConcurrency,
+		pollInterval:    defaultPollInterval,
+		lockDuration:    defaultLockDuration,
+		backoffBase:     defaultBackoffBase,
+		backoffMax:      defaultBackoffMax,
+		instrumentation: initInstrumentation(nil),
+		handlers:        make(map[string]HandlerFunc),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Handle registers a handler for a job type
+func (w *Worker) Handle(jobType string, handler HandlerFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers[jobType] = handler
+}
+
+// Register registers a typed handler for jobs of type J.
+// Jobs are run at least once (e.g. if a worker crashes), so handlers should be idempotent.
+func Register[J Job](w *Worker, handler func(ctx context.Context, job J) error) {
+	var zero J
+	w.Handle(zero.JobType(), func(ctx context.Context, payload json.RawMessage) error {
+		var job J
+		if err := json.Unmarshal(payload, &job); err != nil {
+			return errors.Wrapf(err, "decoding %s", job.JobType())
+		}
+		return handler(ctx, job)
+	})
+}
+
+// Run runs jobs until the context is cancelled.
+// It returns after the jobs that are currently running are finished.
+func (w *Worker) Run(ctx context.Context) error {
+	log := logger.FromContext(ctx).
+		WithField("component", "jobWorker")
+
+	registration, err := w.instrumentation.meter.RegisterCallback(w.observeQueueDepth, w.instrumentation.queueDepth)
+	if err != nil {
+		return errors.Wrap(err, "registering queue depth callback")
+	}
+	defer func() {
+		_ = registration.Unregister()
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.runLoop(ctx, log)
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+func (w *Worker) runLoop(ctx context.Context, log logger.Interface) {
+	for {
+		worked, err := w.Work(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.WithError(err).Error("Could not run job")
+		}
+		// Continue without waiting while there are due jobs
+		if worked && err == nil {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.pollInterval):
+		}
+	}
+}
+
+// Work claims and runs a single due job.
+// It returns false if no job was due.
+func (w *Worker) Work(ctx context.Context) (worked bool, err error) {
+	now := w.timeSource.Now()
+
+	var claimed []model.Job
+	err = repository.Transactional(ctx, w.db, func(tx *sql.Tx) error {
+		claimed, err = repository.ClaimDueJobs(ctx, tx, now, now.Add(w.lockDuration), 1)
+		return err
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "claiming job")
+	}
+	if len(claimed) == 0 {
+		return false, nil
+	}
+	job := claimed[0]
+
+	w.instrumentation.jobLatency.Record(ctx, now.Sub(job.RunAt).Seconds(),
+		metric.WithAttributes(attribute.String("job.type", job.JobType)),
+	)
+
+	runErr := w.run(ctx, job)
+
+	finishedAt := w.timeSource.Now()
+	changeSet := w.result(job, finishedAt, runErr)
+
+	w.instrumentation.jobDuration.Record(ctx, finishedAt.Sub(now).Seconds(),
+		metric.WithAttributes(
+			attribute.String("job.type", job.JobType),
+			attribute.String("job.status", string(*changeSet.Status)),
+		),
+	)
+
+	// The job result must be stored even if the worker is shutting down
+	err = repository.UpdateJob(context.WithoutCancel(ctx), w.db, job.ID, changeSet)
+	if err != nil {
+		return true, errors.Wrap(err, "updating job")
+	}
+
+	log := logger.FromContext(ctx).
+		WithField("component", "jobWorker").
+		WithField("jobID", job.ID).
+		WithField("jobType", job.JobType).
+		WithField("attempts", job.Attempts)
+	if runErr != nil {
+		log.
+			WithError(runErr).
+			WithField("status", *changeSet.Status).
+			Warn("Job failed")
+	} else {
+		log.Debug("Job completed")
+	}
+
+	return true, nil
+}
+
+func (w *Worker) run(ctx context.Context, job model.Job) (err error) {
+	w.mu.RLock()
+	handler, ok := w.handlers[job.JobType]
+	w.mu.RUnlock()
+	// Another version of the application might handle the job, so it is retried
+	if !ok {
+		return errors.Errorf("no handler for job type %s", job.JobType)
+	}
+
+	// Jobs get a goroutine local Sentry hub and run to completion on shutdown, bounded by the lock duration
+	hub := sentry.CurrentHub().Clone()
+	hub.Scope().SetTag("section", "job")
+	hub.Scope().SetTag("jobType", job.JobType)
+	jobCtx, cancel := context.WithTimeout(sentry.SetHubOnContext(context.WithoutCancel(ctx), hub), w.lockDuration)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			hub.Recover(r)
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	err = handler(jobCtx, job.Payload)
+	if err != nil && job.Attempts >= job.MaxAttempts {
+		hub.CaptureException(err)
+	}
+	return err
+}
+
+func (w *Worker) result(job model.Job, now time.Time, runErr error) repository.JobChangeSet {
+	var lockedUntil *time.Time
+	if runErr == nil {
+		status := model.JobStatusCompleted
+		completedAt := &now
+		var lastError *string
+		return repository.JobChangeSet{
+			Status:      &status,
+			LockedUntil: &lockedUntil,
+			CompletedAt: &completedAt,
+			LastError:   &lastError,
+		}
+	}
+
+	status := model.JobStatusPending
+	if job.Attempts >= job.MaxAttempts {
+		status = model.JobStatusFailed
+	}
+	runAt := now.Add(w.backoff(job.Attempts))
+	lastError := runErr.Error()
+	lastErrorPtr := &lastError
+	return repository.JobChangeSet{
+		Status:      &status,
+		RunAt:       &runAt,
+		LockedUntil: &lockedUntil,
+		LastError:   &lastErrorPtr,
+	}
+}
+
+func (w *Worker) backoff(attempts int) time.Duration {
+	delay := w.backoffBase
+	for i := 1; i < attempts && delay < w.backoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, w.backoffMax)
+}
+
+func (w *Worker) observeQueueDepth(ctx context.Context, observer metric.Observer) error {
+	counts, err := repository.CountPendingJobsByType(ctx, w.db, w.timeSource.Now())
+	if err != nil {
+		return errors.Wrap(err, "counting pending jobs")
+	}
+	for _, count := range counts {
+		observer.ObserveInt64(w.instrumentation.queueDepth, int64(count.Count),
+			metric.WithAttributes(attribute.String("job.type", count.JobType)),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package jobqueue_test
+
+import (
+	"context"
+	"database/sql"
+	std_errors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/persistence/jobqueue"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/test"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+	test_telemetry "myvendor.mytld/myproject/backend/test/telemetry"
+)
+
+type sendReportJob struct {
+	ReportName string `json:"reportName"`
+}
+
+func (sendReportJob) JobType() string {
+	return "sendReport"
+}
+
+func TestWorker_Work(t *testing.T) {
+	ctx := context.Background()
+	db := test_db.CreateTestDatabase(t)
+	timeSource := test.NewSettableTimeSource(test.FixedTime().Now())
+	reader, meterProvider := test_telemetry.SetupTestMeter(t)
+
+	worker := jobqueue.NewWorker(db, timeSource,
+		jobqueue.WithBackoff(time.Minute, time.Hour),
+		jobqueue.WithMeterProvider(meterProvider),
+	)
+
+	var (
+		sent     []string
+		failures = 1
+	)
+	jobqueue.Register(worker, func(_ context.Context, job sendReportJob) error {
+		if failures > 0 {
+			failures--
+			return std_errors.New("mail server unavailable")
+		}
+		sent = append(sent, job.ReportName)
+		return nil
+	})
+
+	enqueued, err := jobqueue.Enqueue(ctx, db, timeSource.Now(), sendReportJob{ReportName: "daily"}, jobqueue.UniqueKey("daily"))
+	require.NoError(t, err)
+	assert.True(t, enqueued)
+
+	// A job with the same unique key is skipped while the first one is pending
+	enqueued, err = jobqueue.Enqueue(ctx, db, timeSource.Now(), sendReportJob{ReportName: "daily"}, jobqueue.UniqueKey("daily"))
+	require.NoError(t, err)
+	assert.False(t, enqueued)
+
+	enqueued, err = jobqueue.Enqueue(ctx, db, timeSource.Now(), sendReportJob{ReportName: "weekly"}, jobqueue.RunAt(timeSource.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	assert.True(t, enqueued)
+
+	// First attempt fails and is retried after the backoff
+	worked, err := worker.Work(ctx)
+	require.NoError(t, err)
+	assert.True(t, worked)
+	assert.Empty(t, sent)
+
+	pending := findJobs(t, db, model.JobStatusPending)
+	require.Len(t, pending, 2)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.Equal(t, timeSource.Now().Add(time.Minute), pending[0].RunAt.UTC())
+	require.NotNil(t, pending[0].LastError)
+	assert.Equal(t, "mail server unavailable", *pending[0].LastError)
+
+	worked, err = worker.Work(ctx)
+	require.NoError(t, err)
+	assert.False(t, worked, "no job should be due before the backoff elapsed")
+
+	timeSource.Advance(time.Minute)
+
+	worked, err = worker.Work(ctx)
+	require.NoError(t, err)
+	assert.True(t, worked)
+	assert.Equal(t, []string{"daily"}, sent)
+
+	completed := findJobs(t, db, model.JobStatusCompleted)
+	require.Len(t, completed, 1)
+	assert.Equal(t, 2, completed[0].Attempts)
+
+	test_telemetry.AssertMeterHistogramCount(t, reader, "myvendor.mytld/myproject/backend/persistence/jobqueue", "jobs.latency", 2)
+	test_telemetry.AssertMeterHistogramCount(t, reader, "myvendor.mytld/myproject/backend/persistence/jobqueue", "jobs.duration", 2)
+
+	// The unique key can be used again after the job was completed
+	enqueued, err = jobqueue.Enqueue(ctx, db, timeSource.Now(), sendReportJob{ReportName: "daily"}, jobqueue.UniqueKey("daily"))
+	require.NoError(t, err)
+	assert.True(t, enqueued)
+
+	// Scheduled jobs run when they are due
+	timeSource.Advance(time.Hour)
+
+	for {
+		worked, err = worker.Work(ctx)
+		require.NoError(t, err)
+		if !worked {
+			break
+		}
+	}
+	assert.ElementsMatch(t, []string{"daily", "daily", "weekly"}, sent)
+}
+
+func TestWorker_Work_FailsAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	db := test_db.CreateTestDatabase(t)
+	timeSource := test.NewSettableTimeSource(test.FixedTime().Now())
+
+	worker := jobqueue.NewWorker(db, timeSource, jobqueue.WithBackoff(time.Minute, time.Hour))
+	jobqueue.Register(worker, func(context.Context, sendReportJob) error {
+		panic("unexpected report")
+	})
+
+	_, err := jobqueue.Enqueue(ctx, db, timeSource.Now(), sendReportJob{ReportName: "daily"}, jobqueue.MaxAttempts(2))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		worked, err := worker.Work(ctx)
+		require.NoError(t, err)
+		assert.True(t, worked)
+		timeSource.Advance(time.Hour)
+	}
+
+	worked, err := worker.Work(ctx)
+	require.NoError(t, err)
+	assert.False(t, worked)
+
+	failed := findJobs(t, db, model.JobStatusFailed)
+	require.Len(t, failed, 1)
+	assert.Equal(t, 2, failed[0].Attempts)
+	require.NotNil(t, failed[0].LastError)
+	assert.Equal(t, "panic: unexpected report", *failed[0].LastError)
+}
+
+func TestWorker_Work_ReclaimsExpiredLocks(t *testing.T) {
+	ctx := context.Background()
+	db := test_db.CreateTestDatabase(t)
+	timeSource := test.NewSettableTimeSource(test.FixedTime().Now())
+
+	_, err := jobqueue.Enqueue(ctx, db, timeSource.Now(), sendReportJob{ReportName: "daily"})
+	require.NoError(t, err)
+
+	// Simulate a worker that crashed after claiming the job
+	claimed, err := repository.ClaimDueJobs(ctx, db, timeSource.Now(), timeSource.Now().Add(time.Minute), 1)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	var sent []string
+	worker := jobqueue.NewWorker(db, timeSource)
+	jobqueue.Register(worker, func(_ context.Context, job sendReportJob) error {
+		sent = append(sent, job.ReportName)
+		return nil
+	})
+
+	worked, err := worker.Work(ctx)
+	require.NoError(t, err)
+	assert.False(t, worked, "job should be locked")
+
+	timeSource.Advance(time.Minute)
+
+	worked, err = worker.Work(ctx)
+	require.NoError(t, err)
+	assert.True(t, worked)
+	assert.Equal(t, []string{"daily"}, sent)
+}
+
+func findJobs(t *testing.T, db *sql.DB, status model.JobStatus) []model.Job {
+	t.Helper()
+
+	jobs, err := repository.FindAllJobs(context.Background(), db, repository.JobsFilter{Status: &status}, repository.WithSort("runAt", repository.SortOrderAsc))
+	require.NoError(t, err)
+	return jobs
+}
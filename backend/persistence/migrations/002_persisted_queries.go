@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upPersistedQueries, downPersistedQueries)
+}
+
+func upPersistedQueries(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		CREATE TABLE persisted_queries
+		(
+			hash       text        NOT NULL PRIMARY KEY,
+			document   text        NOT NULL,
+			created_at timestamptz NOT NULL DEFAULT NOW()
+		);
+	`)
+	return err
+}
+
+func downPersistedQueries(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		DROP TABLE persisted_queries;
+	`)
+	return err
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAssets, downAssets)
+}
+
+func upAssets(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		CREATE TABLE assets
+		(
+			asset_id        uuid        NOT NULL PRIMARY KEY,
+			organisation_id uuid REFERENCES organisations (organisation_id) ON DELETE CASCADE,
+			storage_key     text        NOT NULL UNIQUE,
+			filename        text        NOT NULL,
+			content_type    text        NOT NULL,
+			size            bigint      NOT NULL CHECK (size >= 0),
+			checksum        text        NOT NULL,
+			created_by      uuid REFERENCES accounts (account_id) ON DELETE SET NULL,
+			created_at      timestamptz NOT NULL DEFAULT NOW()
+		);
+
+		CREATE INDEX assets_organisation_id_idx ON assets (organisation_id);
+	`)
+	return err
+}
+
+func downAssets(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		DROP TABLE assets;
+	`)
+	return err
+}
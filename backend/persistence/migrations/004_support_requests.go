@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upSupportRequests, downSupportRequests)
+}
+
+func upSupportRequests(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		CREATE TABLE support_requests
+		(
+			support_request_id uuid        NOT NULL PRIMARY KEY,
+			account_id         uuid        NOT NULL REFERENCES accounts (account_id) ON DELETE CASCADE,
+			subject            text        NOT NULL,
+			created_at         timestamptz NOT NULL DEFAULT NOW()
+		);
+
+		CREATE INDEX support_requests_account_id_created_at_idx ON support_requests (account_id, created_at);
+	`)
+	return err
+}
+
+func downSupportRequests(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		DROP TABLE support_requests;
+	`)
+	return err
+}
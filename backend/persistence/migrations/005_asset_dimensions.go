@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAssetDimensions, downAssetDimensions)
+}
+
+func upAssetDimensions(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		ALTER TABLE assets
+			ADD COLUMN width  integer CHECK (width > 0),
+			ADD COLUMN height integer CHECK (height > 0);
+	`)
+	return err
+}
+
+func downAssetDimensions(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		ALTER TABLE assets
+			DROP COLUMN width,
+			DROP COLUMN height;
+	`)
+	return err
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upOutboxEvents, downOutboxEvents)
+}
+
+func upOutboxEvents(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		CREATE TABLE outbox_events
+		(
+			event_id        uuid        NOT NULL PRIMARY KEY,
+			event_type      text        NOT NULL,
+			payload         jsonb       NOT NULL,
+			status          text        NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead')),
+			attempts        integer     NOT NULL DEFAULT 0,
+			next_attempt_at timestamptz NOT NULL DEFAULT NOW(),
+			last_error      text,
+			occurred_at     timestamptz NOT NULL DEFAULT NOW(),
+			delivered_at    timestamptz
+		);
+
+		CREATE INDEX outbox_events_pending_idx ON outbox_events (next_attempt_at) WHERE status = 'pending';
+	`)
+	return err
+}
+
+func downOutboxEvents(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		DROP TABLE outbox_events;
+	`)
+	return err
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upJobs, downJobs)
+}
+
+func upJobs(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		CREATE TABLE jobs
+		(
+			job_id       uuid        NOT NULL PRIMARY KEY,
+			job_type     text        NOT NULL,
+			payload      jsonb       NOT NULL,
+			unique_key   text,
+			status       text        NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+			attempts     integer     NOT NULL DEFAULT 0,
+			max_attempts integer     NOT NULL,
+			run_at       timestamptz NOT NULL DEFAULT NOW(),
+			locked_until timestamptz,
+			last_error   text,
+			created_at   timestamptz NOT NULL DEFAULT NOW(),
+			completed_at timestamptz
+		);
+
+		CREATE INDEX jobs_due_idx ON jobs (run_at) WHERE status IN ('pending', 'running');
+		CREATE UNIQUE INDEX jobs_unique_key_idx ON jobs (job_type, unique_key) WHERE status IN ('pending', 'running');
+	`)
+	return err
+}
+
+func downJobs(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		DROP TABLE jobs;
+	`)
+	return err
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upCronJobs, downCronJobs)
+}
+
+func upCronJobs(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		CREATE TABLE cron_jobs
+		(
+			name             text        NOT NULL PRIMARY KEY,
+			schedule         text        NOT NULL,
+			next_run_at      timestamptz NOT NULL,
+			last_run_at      timestamptz,
+			last_finished_at timestamptz,
+			last_status      text CHECK (last_status IN ('succeeded', 'failed')),
+			last_error       text
+		);
+	`)
+	return err
+}
+
+func downCronJobs(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		DROP TABLE cron_jobs;
+	`)
+	return err
+}
@@ -0,0 +1,64 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upWebhooks, downWebhooks)
+}
+
+func upWebhooks(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		CREATE TABLE webhook_endpoints
+		(
+			webhook_endpoint_id uuid        NOT NULL PRIMARY KEY,
+			organisation_id     uuid        NOT NULL REFERENCES organisations (organisation_id) ON DELETE CASCADE,
+			url                 text        NOT NULL,
+			secret              text        NOT NULL,
+			event_types         text[]      NOT NULL DEFAULT '{}',
+			created_at          timestamptz NOT NULL DEFAULT NOW(),
+			updated_at          timestamptz NOT NULL DEFAULT NOW()
+		);
+
+		CREATE INDEX webhook_endpoints_organisation_id_idx ON webhook_endpoints (organisation_id);
+
+		CREATE TRIGGER set_timestamp
+			BEFORE UPDATE ON webhook_endpoints
+			FOR EACH ROW
+			EXECUTE PROCEDURE trigger_set_timestamp();
+
+		CREATE TABLE webhook_deliveries
+		(
+			webhook_delivery_id uuid        NOT NULL PRIMARY KEY,
+			webhook_endpoint_id uuid        NOT NULL REFERENCES webhook_endpoints (webhook_endpoint_id) ON DELETE CASCADE,
+			organisation_id     uuid        NOT NULL REFERENCES organisations (organisation_id) ON DELETE CASCADE,
+			event_id            uuid        NOT NULL,
+			event_type          text        NOT NULL,
+			payload             jsonb       NOT NULL,
+			status              text        NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+			attempts            integer     NOT NULL DEFAULT 0,
+			response_status     integer,
+			last_error          text,
+			created_at          timestamptz NOT NULL DEFAULT NOW(),
+			last_attempt_at     timestamptz,
+			delivered_at        timestamptz,
+			-- An event is delivered once per endpoint, even if the outbox delivers it again
+			UNIQUE (webhook_endpoint_id, event_id)
+		);
+
+		CREATE INDEX webhook_deliveries_organisation_id_idx ON webhook_deliveries (organisation_id);
+	`)
+	return err
+}
+
+func downWebhooks(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		DROP TABLE webhook_deliveries;
+		DROP TABLE webhook_endpoints;
+	`)
+	return err
+}
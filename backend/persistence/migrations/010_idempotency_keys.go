@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upIdempotencyKeys, downIdempotencyKeys)
+}
+
+func upIdempotencyKeys(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		CREATE TABLE idempotency_keys
+		(
+			account_id   uuid        NOT NULL REFERENCES accounts (account_id) ON DELETE CASCADE,
+			key          text        NOT NULL,
+			request_hash text        NOT NULL,
+			response     jsonb,
+			created_at   timestamptz NOT NULL DEFAULT NOW(),
+			completed_at timestamptz,
+			expires_at   timestamptz NOT NULL,
+			-- Keys are scoped to an account, so clients cannot replay responses of other accounts
+			PRIMARY KEY (account_id, key)
+		);
+
+		CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
+	`)
+	return err
+}
+
+func downIdempotencyKeys(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		DROP TABLE idempotency_keys;
+	`)
+	return err
+}
@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upRowVersions, downRowVersions)
+}
+
+func upRowVersions(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		-- Only changes of other columns increment the version, so a login (last_login) does not cause a version conflict
+		CREATE OR REPLACE FUNCTION trigger_increment_version()
+		RETURNS TRIGGER AS $$
+		BEGIN
+			IF (to_jsonb(OLD) - '{version,updated_at,last_login}'::text[]) IS DISTINCT FROM
+				(to_jsonb(NEW) - '{version,updated_at,last_login}'::text[]) THEN
+				NEW.version = OLD.version + 1;
+			END IF;
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql;
+
+		ALTER TABLE organisations ADD COLUMN version integer NOT NULL DEFAULT 1;
+
+		CREATE TRIGGER set_version
+			BEFORE UPDATE ON organisations
+			FOR EACH ROW
+			EXECUTE PROCEDURE trigger_increment_version();
+
+		ALTER TABLE accounts ADD COLUMN version integer NOT NULL DEFAULT 1;
+
+		CREATE TRIGGER set_version
+			BEFORE UPDATE ON accounts
+			FOR EACH ROW
+			EXECUTE PROCEDURE trigger_increment_version();
+	`)
+	return err
+}
+
+func downRowVersions(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		DROP TRIGGER set_version ON accounts;
+		ALTER TABLE accounts DROP COLUMN version;
+
+		DROP TRIGGER set_version ON organisations;
+		ALTER TABLE organisations DROP COLUMN version;
+
+		DROP FUNCTION trigger_increment_version;
+	`)
+	return err
+}
@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upSoftDelete, downSoftDelete)
+}
+
+func upSoftDelete(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		ALTER TABLE organisations ADD COLUMN deleted_at timestamptz;
+		ALTER TABLE accounts ADD COLUMN deleted_at timestamptz;
+
+		-- The email address of a deleted account can be used for a new account
+		DROP INDEX accounts_email_address_idx;
+		CREATE UNIQUE INDEX accounts_email_address_idx ON accounts (LOWER(email_address)) WHERE deleted_at IS NULL;
+
+		CREATE INDEX organisations_deleted_at_idx ON organisations (deleted_at) WHERE deleted_at IS NOT NULL;
+		CREATE INDEX accounts_deleted_at_idx ON accounts (deleted_at) WHERE deleted_at IS NOT NULL;
+	`)
+	return err
+}
+
+func downSoftDelete(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		-- Deleted rows would conflict with the unique index and be visible again
+		DELETE FROM organisations WHERE deleted_at IS NOT NULL;
+		DELETE FROM accounts WHERE deleted_at IS NOT NULL;
+
+		DROP INDEX accounts_deleted_at_idx;
+		DROP INDEX organisations_deleted_at_idx;
+
+		DROP INDEX accounts_email_address_idx;
+		CREATE UNIQUE INDEX accounts_email_address_idx ON accounts (LOWER(email_address));
+
+		ALTER TABLE accounts DROP COLUMN deleted_at;
+		ALTER TABLE organisations DROP COLUMN deleted_at;
+	`)
+	return err
+}
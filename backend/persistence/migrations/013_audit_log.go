@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAuditLog, downAuditLog)
+}
+
+func upAuditLog(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		-- Accounts and organisations are not referenced by foreign keys, entries must outlive them
+		CREATE TABLE audit_log
+		(
+			audit_entry_id          uuid PRIMARY KEY,
+			occurred_at             timestamptz NOT NULL DEFAULT NOW(),
+			actor_account_id        uuid,
+			impersonator_account_id uuid,
+			organisation_id         uuid,
+			command_type            text        NOT NULL,
+			target_ids              uuid[]      NOT NULL DEFAULT '{}',
+			changes                 jsonb       NOT NULL DEFAULT '{}',
+			request_id              text,
+			ip_address              text
+		);
+
+		CREATE INDEX audit_log_occurred_at_idx ON audit_log (occurred_at);
+		CREATE INDEX audit_log_organisation_id_idx ON audit_log (organisation_id);
+		CREATE INDEX audit_log_actor_account_id_idx ON audit_log (actor_account_id);
+		CREATE INDEX audit_log_target_ids_idx ON audit_log USING gin (target_ids);
+
+		CREATE OR REPLACE FUNCTION trigger_audit_log_append_only()
+		RETURNS TRIGGER AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_log is append-only';
+		END;
+		$$ LANGUAGE plpgsql;
+
+		CREATE TRIGGER append_only
+			BEFORE UPDATE OR DELETE ON audit_log
+			FOR EACH ROW
+			EXECUTE PROCEDURE trigger_audit_log_append_only();
+
+		CREATE TRIGGER append_only_truncate
+			BEFORE TRUNCATE ON audit_log
+			FOR EACH STATEMENT
+			EXECUTE PROCEDURE trigger_audit_log_append_only();
+	`)
+	return err
+}
+
+func downAuditLog(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		DROP TABLE audit_log;
+		DROP FUNCTION trigger_audit_log_append_only;
+	`)
+	return err
+}
@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upFullTextSearch, downFullTextSearch)
+}
+
+func upFullTextSearch(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		-- Tests install the extension beforehand with "ctl test preparedb", since concurrent CREATE EXTENSION can fail
+		CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+		-- The simple configuration does not stem words, since names and email addresses are not in a specific language
+		ALTER TABLE organisations ADD COLUMN search_vector tsvector
+			GENERATED ALWAYS AS (to_tsvector('simple', name)) STORED;
+		CREATE INDEX organisations_search_vector_idx ON organisations USING GIN (search_vector);
+
+		-- Email addresses are a single token for the parser, so the parts are added as separate words
+		ALTER TABLE accounts ADD COLUMN search_vector tsvector
+			GENERATED ALWAYS AS (to_tsvector('simple', email_address || ' ' || translate(email_address, '@.-_+', '     '))) STORED;
+		CREATE INDEX accounts_search_vector_idx ON accounts USING GIN (search_vector);
+
+		CREATE INDEX accounts_email_address_trgm_idx ON accounts USING GIN (email_address gin_trgm_ops);
+
+		-- Generated columns are not computed yet in BEFORE triggers, so they are ignored for the row version
+		CREATE OR REPLACE FUNCTION trigger_increment_version()
+		RETURNS TRIGGER AS $$
+		BEGIN
+			IF (to_jsonb(OLD) - '{version,updated_at,last_login,search_vector}'::text[]) IS DISTINCT FROM
+				(to_jsonb(NEW) - '{version,updated_at,last_login,search_vector}'::text[]) THEN
+				NEW.version = OLD.version + 1;
+			END IF;
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql;
+	`)
+	return err
+}
+
+func downFullTextSearch(ctx context.Context, tx *sql.Tx) error {
+	// The extension is not dropped, it could be used outside of this schema
+	_, err := tx.ExecContext(ctx, `
+		CREATE OR REPLACE FUNCTION trigger_increment_version()
+		RETURNS TRIGGER AS $$
+		BEGIN
+			IF (to_jsonb(OLD) - '{version,updated_at,last_login}'::text[]) IS DISTINCT FROM
+				(to_jsonb(NEW) - '{version,updated_at,last_login}'::text[]) THEN
+				NEW.version = OLD.version + 1;
+			END IF;
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql;
+
+		DROP INDEX accounts_email_address_trgm_idx;
+
+		DROP INDEX accounts_search_vector_idx;
+		ALTER TABLE accounts DROP COLUMN search_vector;
+
+		DROP INDEX organisations_search_vector_idx;
+		ALTER TABLE organisations DROP COLUMN search_vector;
+	`)
+	return err
+}
@@ -2,5 +2,8 @@ package migrations
 
 import "embed"
 
-//go:embed *.go
+// FS contains the Go and SQL migrations. Migration files start with their version, so the pattern does not embed
+// other files like tests, and it does not fail to compile while there are no SQL migrations.
+//
+//go:embed [0-9]*
 var FS embed.FS
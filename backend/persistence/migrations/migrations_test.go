@@ -0,0 +1,11 @@
+package migrations_test
+
+import (
+	"testing"
+
+	test_db "myvendor.mytld/myproject/backend/test/db"
+)
+
+func TestMigrations_RoundTrip(t *testing.T) {
+	test_db.RequireMigrationsRoundTrip(t)
+}
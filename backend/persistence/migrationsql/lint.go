@@ -0,0 +1,223 @@
+package migrationsql
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Finding is a dangerous statement found by Lint
+type Finding struct {
+	Filename  string
+	Version   int64
+	Rule      string
+	Message   string
+	Statement string
+}
+
+// ignoreMarker in a comment of a statement ignores the given rule (or all rules if none is given), e.g. "-- lint:ignore create-index-not-concurrently"
+const ignoreMarker = "lint:ignore"
+
+type rule struct {
+	name    string
+	message string
+	// onExistingTable is set for rules that are only relevant if the table was not created in the same migration
+	onExistingTable bool
+	// matches gets the normalized statement (upper case without comments and repeated whitespace)
+	matches func(stmt string, migration Migration) bool
+}
+
+//nolint:gochecknoglobals
+var (
+	createTableRegexp     = regexp.MustCompile(`^CREATE (UNLOGGED )?TABLE (IF NOT EXISTS )?([^ (]+)`)
+	createIndexRegexp     = regexp.MustCompile(`^CREATE (UNIQUE )?INDEX `)
+	indexTableRegexp      = regexp.MustCompile(` ON (ONLY )?([^ (]+)`)
+	dropIndexRegexp       = regexp.MustCompile(`^DROP INDEX `)
+	alterTableRegexp      = regexp.MustCompile(`^ALTER TABLE (IF EXISTS )?(ONLY )?([^ ]+)`)
+	addColumnRegexp       = regexp.MustCompile(`ADD (COLUMN )?(IF NOT EXISTS )?[^ ]+ [^,]*`)
+	alterColumnTypeRegexp = regexp.MustCompile(`ALTER (COLUMN )?[^ ]+ (SET DATA )?TYPE `)
+)
+
+//nolint:gochecknoglobals
+var rules = []rule{
+	{
+		name:            "create-index-not-concurrently",
+		message:         "CREATE INDEX blocks writes to the table until the index is built, use CREATE INDEX CONCURRENTLY in a migration without transaction",
+		onExistingTable: true,
+		matches: func(stmt string, _ Migration) bool {
+			return createIndexRegexp.MatchString(stmt) && !strings.Contains(stmt, " CONCURRENTLY ")
+		},
+	},
+	{
+		name:            "drop-index-not-concurrently",
+		message:         "DROP INDEX blocks reads and writes of the table, use DROP INDEX CONCURRENTLY in a migration without transaction",
+		onExistingTable: true,
+		matches: func(stmt string, _ Migration) bool {
+			return dropIndexRegexp.MatchString(stmt) && !strings.Contains(stmt, " CONCURRENTLY ")
+		},
+	},
+	{
+		name:    "concurrently-in-transaction",
+		message: "CONCURRENTLY cannot run in a transaction, disable the transaction of the migration (-- +goose NO TRANSACTION or AddMigrationNoTxContext)",
+		matches: func(stmt string, migration Migration) bool {
+			return !migration.NoTransaction && strings.Contains(stmt, " CONCURRENTLY ")
+		},
+	},
+	{
+		name:            "add-column-not-null-without-default",
+		message:         "adding a NOT NULL column without DEFAULT fails if the table has rows",
+		onExistingTable: true,
+		matches: func(stmt string, _ Migration) bool {
+			if !alterTableRegexp.MatchString(stmt) {
+				return false
+			}
+			for _, addColumn := range addColumnRegexp.FindAllString(stmt, -1) {
+				if strings.Contains(addColumn, " NOT NULL") && !strings.Contains(addColumn, " DEFAULT ") && !strings.Contains(addColumn, " CONSTRAINT ") {
+					return true
+				}
+			}
+			return false
+		},
+	},
+	{
+		name:            "add-stored-generated-column",
+		message:         "adding a stored generated column rewrites the table while holding an exclusive lock",
+		onExistingTable: true,
+		matches: func(stmt string, _ Migration) bool {
+			return alterTableRegexp.MatchString(stmt) && strings.Contains(stmt, " GENERATED ALWAYS AS ") && strings.Contains(stmt, " STORED")
+		},
+	},
+	{
+		name:            "alter-column-type",
+		message:         "changing the type of a column can rewrite the table while holding an exclusive lock",
+		onExistingTable: true,
+		matches: func(stmt string, _ Migration) bool {
+			return alterTableRegexp.MatchString(stmt) && alterColumnTypeRegexp.MatchString(stmt)
+		},
+	},
+	{
+		name:            "set-not-null",
+		message:         "SET NOT NULL scans the table while holding an exclusive lock, add a CHECK constraint NOT VALID and validate it first",
+		onExistingTable: true,
+		matches: func(stmt string, _ Migration) bool {
+			return alterTableRegexp.MatchString(stmt) && strings.Contains(stmt, " SET NOT NULL")
+		},
+	},
+	{
+		name:            "add-constraint-without-not-valid",
+		message:         "adding a foreign key or check constraint scans the table while holding a lock, add it NOT VALID and validate it in a separate statement",
+		onExistingTable: true,
+		matches: func(stmt string, _ Migration) bool {
+			return alterTableRegexp.MatchString(stmt) &&
+				strings.Contains(stmt, " ADD CONSTRAINT ") &&
+				(strings.Contains(stmt, " FOREIGN KEY ") || strings.Contains(stmt, " CHECK ")) &&
+				!strings.Contains(stmt, " NOT VALID")
+		},
+	},
+	{
+		name:            "rename",
+		message:         "renaming breaks running instances of the previous version during a deployment",
+		onExistingTable: true,
+		matches: func(stmt string, _ Migration) bool {
+			return alterTableRegexp.MatchString(stmt) && strings.Contains(stmt, " RENAME ")
+		},
+	},
+	{
+		name:            "drop-column",
+		message:         "dropping a column breaks running instances of the previous version that still use it",
+		onExistingTable: true,
+		matches: func(stmt string, _ Migration) bool {
+			return alterTableRegexp.MatchString(stmt) && strings.Contains(stmt, " DROP COLUMN ")
+		},
+	},
+}
+
+// Lint checks the up statements of a migration for statements that are dangerous on a database in production
+// (e.g. locking a table for a long time). Statements on tables created in the same migration are not checked for locks.
+func Lint(migration Migration) []Finding {
+	createdTables := make(map[string]bool)
+
+	var findings []Finding
+	for _, statement := range migration.Up {
+		stmt := normalize(statement)
+
+		if m := createTableRegexp.FindStringSubmatch(stmt); m != nil {
+			createdTables[tableName(m[3])] = true
+		}
+		table := statementTable(stmt)
+		ignored := ignoredRules(statement)
+
+		for _, r := range rules {
+			if r.onExistingTable && table != "" && createdTables[table] {
+				continue
+			}
+			if ignored[r.name] || ignored[""] {
+				continue
+			}
+			if !r.matches(stmt, migration) {
+				continue
+			}
+			findings = append(findings, Finding{
+				Filename:  migration.Filename,
+				Version:   migration.Version,
+				Rule:      r.name,
+				Message:   r.message,
+				Statement: statement,
+			})
+		}
+	}
+	return findings
+}
+
+// normalize removes comments and repeated whitespace and converts the statement to upper case.
+// A space is added at the end, so keywords can be matched with surrounding spaces.
+func normalize(statement string) string {
+	var lines []string
+	for _, line := range strings.Split(statement, "\n") {
+		if i := strings.Index(line, "--"); i >= 0 {
+			line = line[:i]
+		}
+		lines = append(lines, line)
+	}
+	stmt := strings.Join(strings.Fields(strings.Join(lines, " ")), " ")
+	stmt = strings.TrimSuffix(stmt, ";")
+	return strings.ToUpper(stmt) + " "
+}
+
+// statementTable gets the table of an ALTER TABLE or CREATE INDEX statement
+func statementTable(stmt string) string {
+	if m := alterTableRegexp.FindStringSubmatch(stmt); m != nil {
+		return tableName(m[3])
+	}
+	if createIndexRegexp.MatchString(stmt) {
+		if m := indexTableRegexp.FindStringSubmatch(stmt); m != nil {
+			return tableName(m[2])
+		}
+	}
+	return ""
+}
+
+func tableName(name string) string {
+	return strings.Trim(name, `"`)
+}
+
+func ignoredRules(statement string) map[string]bool {
+	ignored := make(map[string]bool)
+	for _, line := range strings.Split(statement, "\n") {
+		i := strings.Index(line, "--")
+		if i < 0 {
+			continue
+		}
+		_, after, found := strings.Cut(line[i:], ignoreMarker)
+		if !found {
+			continue
+		}
+		names := strings.Fields(after)
+		if len(names) == 0 {
+			ignored[""] = true
+		}
+		for _, name := range names {
+			ignored[name] = true
+		}
+	}
+	return ignored
+}
@@ -0,0 +1,126 @@
+package migrationsql_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"myvendor.mytld/myproject/backend/persistence/migrationsql"
+)
+
+func TestLint(t *testing.T) {
+	tt := []struct {
+		name          string
+		noTransaction bool
+		up            []string
+		expectedRules []string
+	}{
+		{
+			name:          "create index",
+			up:            []string{"CREATE INDEX accounts_role_idx ON accounts (role_identifier);"},
+			expectedRules: []string{"create-index-not-concurrently"},
+		},
+		{
+			name:          "create index concurrently",
+			noTransaction: true,
+			up:            []string{"create unique index concurrently accounts_role_idx on accounts (role_identifier);"},
+		},
+		{
+			name:          "create index concurrently in transaction",
+			up:            []string{"CREATE INDEX CONCURRENTLY accounts_role_idx ON accounts (role_identifier);"},
+			expectedRules: []string{"concurrently-in-transaction"},
+		},
+		{
+			name: "create index on created table",
+			up: []string{
+				"CREATE TABLE roles (role_identifier text PRIMARY KEY, name text NOT NULL);",
+				"CREATE INDEX roles_name_idx ON roles (name);",
+				"ALTER TABLE roles ADD COLUMN description text NOT NULL;",
+			},
+		},
+		{
+			name:          "drop index",
+			up:            []string{"DROP INDEX accounts_role_idx;"},
+			expectedRules: []string{"drop-index-not-concurrently"},
+		},
+		{
+			name:          "add not null column without default",
+			up:            []string{"ALTER TABLE accounts ADD COLUMN nickname text NOT NULL;"},
+			expectedRules: []string{"add-column-not-null-without-default"},
+		},
+		{
+			name: "add not null column with default",
+			up:   []string{"ALTER TABLE accounts ADD COLUMN version integer NOT NULL DEFAULT 1, ADD COLUMN nickname text;"},
+		},
+		{
+			name:          "add stored generated column",
+			up:            []string{"ALTER TABLE accounts ADD COLUMN search tsvector GENERATED ALWAYS AS (to_tsvector('simple', email_address)) STORED;"},
+			expectedRules: []string{"add-stored-generated-column"},
+		},
+		{
+			name:          "alter column type",
+			up:            []string{"ALTER TABLE accounts ALTER COLUMN role_identifier TYPE varchar(64);"},
+			expectedRules: []string{"alter-column-type"},
+		},
+		{
+			name:          "set not null",
+			up:            []string{"ALTER TABLE accounts ALTER COLUMN organisation_id SET NOT NULL;"},
+			expectedRules: []string{"set-not-null"},
+		},
+		{
+			name:          "add foreign key",
+			up:            []string{"ALTER TABLE accounts ADD CONSTRAINT accounts_role_fkey FOREIGN KEY (role_identifier) REFERENCES roles;"},
+			expectedRules: []string{"add-constraint-without-not-valid"},
+		},
+		{
+			name: "add foreign key not valid",
+			up:   []string{"ALTER TABLE accounts ADD CONSTRAINT accounts_role_fkey FOREIGN KEY (role_identifier) REFERENCES roles NOT VALID;"},
+		},
+		{
+			name:          "rename column",
+			up:            []string{"ALTER TABLE accounts RENAME COLUMN role_identifier TO role;"},
+			expectedRules: []string{"rename"},
+		},
+		{
+			name:          "drop column",
+			up:            []string{"ALTER TABLE accounts DROP COLUMN last_login;"},
+			expectedRules: []string{"drop-column"},
+		},
+		{
+			name: "ignored rule",
+			up: []string{
+				"-- The table is small, lint:ignore create-index-not-concurrently\nCREATE INDEX accounts_role_idx ON accounts (role_identifier);",
+			},
+		},
+		{
+			name: "ignored other rule",
+			up: []string{
+				"-- lint:ignore drop-column\nCREATE INDEX accounts_role_idx ON accounts (role_identifier);",
+			},
+			expectedRules: []string{"create-index-not-concurrently"},
+		},
+		{
+			name: "ignored all rules",
+			up: []string{
+				"-- lint:ignore\nALTER TABLE accounts RENAME COLUMN role_identifier TO role, DROP COLUMN last_login;",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			findings := migrationsql.Lint(migrationsql.Migration{
+				Filename:      "001_test.sql",
+				Version:       1,
+				NoTransaction: tc.noTransaction,
+				Up:            tc.up,
+			})
+
+			var rules []string
+			for _, finding := range findings {
+				rules = append(rules, finding.Rule)
+			}
+			assert.Equal(t, tc.expectedRules, rules)
+		})
+	}
+}
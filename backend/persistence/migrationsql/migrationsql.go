@@ -0,0 +1,249 @@
+// Package migrationsql extracts the SQL of migrations without running them, e.g. for a dry-run or linting.
+package migrationsql
+
+import (
+	"bufio"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/pressly/goose/v3"
+)
+
+// Migration is the SQL of a migration file
+type Migration struct {
+	Filename string
+	Version  int64
+	// NoTransaction is set if the migration is not run in a transaction (needed for CREATE INDEX CONCURRENTLY)
+	NoTransaction bool
+	// Up are the statements of the up migration.
+	// Statements of Go migrations are extracted from raw string literals in up functions, so they are incomplete if the SQL is built dynamically.
+	Up []string
+}
+
+// Go returns whether it is a Go migration
+func (m Migration) Go() bool {
+	return path.Ext(m.Filename) == ".go"
+}
+
+// ParseAll parses all Go and SQL migrations in the root of fsys ordered by version
+func ParseAll(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, errors.Wrap(err, "reading migrations")
+	}
+
+	var migrations []Migration
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".go" && ext != ".sql") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		// Files without a version (e.g. for embedding) are not migrations
+		if _, err := goose.NumericComponent(entry.Name()); err != nil {
+			continue
+		}
+
+		migration, err := Parse(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Parse parses a Go or SQL migration
+func Parse(fsys fs.FS, filename string) (Migration, error) {
+	version, err := goose.NumericComponent(filename)
+	if err != nil {
+		return Migration{}, errors.Wrapf(err, "getting version of %s", filename)
+	}
+	content, err := fs.ReadFile(fsys, filename)
+	if err != nil {
+		return Migration{}, errors.Wrapf(err, "reading %s", filename)
+	}
+
+	migration := Migration{
+		Filename: filename,
+		Version:  version,
+	}
+	switch path.Ext(filename) {
+	case ".sql":
+		migration.NoTransaction, migration.Up = parseSQL(string(content))
+	case ".go":
+		migration.NoTransaction, migration.Up, err = parseGo(filename, content)
+		if err != nil {
+			return Migration{}, errors.Wrapf(err, "parsing %s", filename)
+		}
+	default:
+		return Migration{}, errors.Errorf("unsupported migration type of %s", filename)
+	}
+	return migration, nil
+}
+
+// parseSQL gets the statements of the up section by the goose annotations, statements end with a semicolon at the end of a line
+func parseSQL(content string) (noTransaction bool, up []string) {
+	var (
+		inUp      bool
+		inBlock   bool
+		statement strings.Builder
+		scanner   = bufio.NewScanner(strings.NewReader(content))
+		flush     = func() {
+			if s := strings.TrimSpace(statement.String()); s != "" {
+				up = append(up, s)
+			}
+			statement.Reset()
+		}
+	)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if annotation, ok := strings.CutPrefix(trimmed, "-- +goose "); ok {
+			switch strings.ToUpper(strings.TrimSpace(annotation)) {
+			case "UP":
+				inUp = true
+			case "DOWN":
+				flush()
+				inUp = false
+			case "NO TRANSACTION":
+				noTransaction = true
+			case "STATEMENTBEGIN":
+				inBlock = true
+			case "STATEMENTEND":
+				inBlock = false
+				if inUp {
+					flush()
+				}
+			}
+			continue
+		}
+		if !inUp {
+			continue
+		}
+
+		statement.WriteString(line)
+		statement.WriteString("\n")
+		if !inBlock && strings.HasSuffix(trimmed, ";") {
+			flush()
+		}
+	}
+	flush()
+
+	return noTransaction, up
+}
+
+// parseGo gets the statements from raw string literals in the up functions that are registered with goose.AddMigration...
+// (e.g. upSoftDelete in goose.AddMigrationContext(upSoftDelete, downSoftDelete))
+func parseGo(filename string, content []byte) (noTransaction bool, up []string, err error) {
+	file, err := parser.ParseFile(token.NewFileSet(), filename, content, 0)
+	if err != nil {
+		return false, nil, err
+	}
+
+	upFuncNames := make(map[string]struct{})
+	var upBodies []*ast.BlockStmt
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		// Named migrations get the filename as the first argument
+		upArg := 0
+		switch {
+		case strings.HasPrefix(sel.Sel.Name, "AddMigration"):
+		case strings.HasPrefix(sel.Sel.Name, "AddNamedMigration"):
+			upArg = 1
+		default:
+			return true
+		}
+		if strings.Contains(sel.Sel.Name, "NoTx") {
+			noTransaction = true
+		}
+		if len(call.Args) <= upArg {
+			return true
+		}
+		switch fn := call.Args[upArg].(type) {
+		case *ast.Ident:
+			upFuncNames[fn.Name] = struct{}{}
+		case *ast.FuncLit:
+			upBodies = append(upBodies, fn.Body)
+		}
+		return true
+	})
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Body == nil {
+			continue
+		}
+		if _, isUp := upFuncNames[fn.Name.Name]; isUp {
+			upBodies = append(upBodies, fn.Body)
+		}
+	}
+
+	for _, body := range upBodies {
+		ast.Inspect(body, func(node ast.Node) bool {
+			// Interpreted string literals are usually not SQL (e.g. error messages)
+			lit, ok := node.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING || !strings.HasPrefix(lit.Value, "`") {
+				return true
+			}
+			value, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				return true
+			}
+			up = append(up, splitStatements(value)...)
+			return true
+		})
+	}
+
+	return noTransaction, up, nil
+}
+
+// splitStatements splits multiple statements of a string by semicolons at the end of a line
+func splitStatements(sql string) []string {
+	var (
+		statements []string
+		statement  strings.Builder
+	)
+	for _, line := range strings.Split(sql, "\n") {
+		statement.WriteString(line)
+		statement.WriteString("\n")
+		if strings.HasSuffix(strings.TrimSpace(line), ";") {
+			if s := dedent(statement.String()); s != "" {
+				statements = append(statements, s)
+			}
+			statement.Reset()
+		}
+	}
+	if s := dedent(statement.String()); s != "" {
+		statements = append(statements, s)
+	}
+	return statements
+}
+
+// dedent removes the indentation of statements in Go strings
+func dedent(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,101 @@
+package migrationsql_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/persistence/migrationsql"
+)
+
+const sqlMigration = `-- +goose NO TRANSACTION
+-- +goose Up
+CREATE INDEX CONCURRENTLY accounts_role_idx ON accounts (role_identifier);
+
+-- +goose StatementBegin
+CREATE FUNCTION touch() RETURNS trigger AS $$
+BEGIN
+	NEW.updated_at = now();
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP FUNCTION touch();
+DROP INDEX accounts_role_idx;
+`
+
+const goMigration = "package migrations\n" + `
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upRoles, downRoles)
+}
+
+func upRoles(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, ` + "`" + `
+		CREATE TABLE roles (role_identifier text PRIMARY KEY);
+		-- Every role is inserted
+		INSERT INTO roles VALUES ('SystemAdministrator');
+	` + "`" + `)
+	if err != nil {
+		return fmt.Errorf("creating roles: %w", err)
+	}
+	return nil
+}
+
+// upsertRole is a helper that is not registered as a migration
+func upsertRole(ctx context.Context, tx *sql.Tx, role string) error {
+	_, err := tx.ExecContext(ctx, ` + "`INSERT INTO roles VALUES ($1) ON CONFLICT DO NOTHING;`" + `, role)
+	return err
+}
+
+func downRoles(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, ` + "`DROP TABLE roles;`" + `)
+	return err
+}
+`
+
+func TestParseAll(t *testing.T) {
+	fsys := fstest.MapFS{
+		"embed.go":               {Data: []byte("package migrations\n")},
+		"002_account_roles.sql":  {Data: []byte(sqlMigration)},
+		"001_roles.go":           {Data: []byte(goMigration)},
+		"003_without_version.md": {Data: []byte("# Notes\n")},
+	}
+
+	migrations, err := migrationsql.ParseAll(fsys)
+	require.NoError(t, err)
+
+	require.Len(t, migrations, 2)
+
+	assert.Equal(t, migrationsql.Migration{
+		Filename:      "001_roles.go",
+		Version:       1,
+		NoTransaction: false,
+		Up: []string{
+			"CREATE TABLE roles (role_identifier text PRIMARY KEY);",
+			"-- Every role is inserted\nINSERT INTO roles VALUES ('SystemAdministrator');",
+		},
+	}, migrations[0])
+	assert.True(t, migrations[0].Go())
+
+	assert.Equal(t, migrationsql.Migration{
+		Filename:      "002_account_roles.sql",
+		Version:       2,
+		NoTransaction: true,
+		Up: []string{
+			"CREATE INDEX CONCURRENTLY accounts_role_idx ON accounts (role_identifier);",
+			"CREATE FUNCTION touch() RETURNS trigger AS $$\nBEGIN\n\tNEW.updated_at = now();\n\tRETURN NEW;\nEND;\n$$ LANGUAGE plpgsql;",
+		},
+	}, migrations[1])
+	assert.False(t, migrations[1].Go())
+}
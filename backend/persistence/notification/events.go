@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// Event is a change notification that is published on a Postgres channel
+type Event interface {
+	Channel() string
+}
+
+type ChangeAction string
+
+const (
+	ChangeActionCreated ChangeAction = "created"
+	ChangeActionUpdated ChangeAction = "updated"
+	ChangeActionDeleted ChangeAction = "deleted"
+	// ChangeActionRestored is published if a soft deleted record was restored
+	ChangeActionRestored ChangeAction = "restored"
+)
+
+const (
+	channelAccountChanged      = "account_changed"
+	channelOrganisationChanged = "organisation_changed"
+)
+
+// AccountChanged is published if an account was created, updated, deleted or restored
+type AccountChanged struct {
+	Action    ChangeAction `json:"action"`
+	AccountID uuid.UUID    `json:"accountId"`
+	// OrganisationID is the organisation of the account after the change (or before it was deleted)
+	OrganisationID uuid.NullUUID `json:"organisationId"`
+	// PreviousOrganisationID is the organisation before the change, it is only set if the account was moved to another organisation
+	PreviousOrganisationID uuid.NullUUID `json:"previousOrganisationId"`
+}
+
+func (AccountChanged) Channel() string {
+	return channelAccountChanged
+}
+
+// OrganisationChanged is published if an organisation was created, updated, deleted or restored
+type OrganisationChanged struct {
+	Action         ChangeAction `json:"action"`
+	OrganisationID uuid.UUID    `json:"organisationId"`
+}
+
+func (OrganisationChanged) Channel() string {
+	return channelOrganisationChanged
+}
@@ -0,0 +1,172 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	std_errors "errors"
+	"sync"
+	"time"
+
+	logger "github.com/apex/log"
+	"github.com/friendsofgo/errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+const (
+	reconnectDelay       = 2 * time.Second
+	subscriberBufferSize = 16
+)
+
+var errNoPgxConn = std_errors.New("notification: database driver must be pgx")
+
+// Listener receives notifications with LISTEN on a dedicated database connection and fans them out to subscribers.
+// A single listener per server instance is sufficient, since notifications reach all instances.
+type Listener struct {
+	db *sql.DB
+
+	listening     chan struct{}
+	listeningOnce sync.Once
+
+	mu          sync.Mutex
+	subscribers map[string]map[chan []byte]struct{}
+}
+
+func NewListener(db *sql.DB) *Listener {
+	return &Listener{
+		db:          db,
+		listening:   make(chan struct{}),
+		subscribers: make(map[string]map[chan []byte]struct{}),
+	}
+}
+
+// Run listens for notifications until the context is cancelled, the connection is re-established after errors.
+func (l *Listener) Run(ctx context.Context) {
+	log := logger.FromContext(ctx).
+		WithField("component", "notification.listener")
+
+	for {
+		err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.
+			WithError(err).
+			Warnf("Listening for notifications failed, reconnecting in %s", reconnectDelay)
+
+		select {
+		case <-time.After(reconnectDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Listening is closed after the listener is listening for the first time
+func (l *Listener) Listening() <-chan struct{} {
+	return l.listening
+}
+
+func (l *Listener) listen(ctx context.Context) error {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "getting connection")
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		stdlibConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errNoPgxConn
+		}
+		pgxConn := stdlibConn.Conn()
+
+		// Errors are joined with driver.ErrBadConn, so the connection is not re-used by the pool while still listening
+		for _, channel := range []string{channelAccountChanged, channelOrganisationChanged} {
+			if _, err := pgxConn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+				return std_errors.Join(errors.Wrapf(err, "listening on %s", channel), driver.ErrBadConn)
+			}
+		}
+
+		l.listeningOnce.Do(func() { close(l.listening) })
+
+		for {
+			notification, err := pgxConn.WaitForNotification(ctx)
+			if err != nil {
+				return std_errors.Join(errors.Wrap(err, "waiting for notification"), driver.ErrBadConn)
+			}
+			l.dispatch(ctx, notification.Channel, []byte(notification.Payload))
+		}
+	})
+}
+
+func (l *Listener) dispatch(ctx context.Context, channel string, payload []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for ch := range l.subscribers[channel] {
+		select {
+		case ch <- payload:
+		default:
+			logger.FromContext(ctx).
+				WithField("component", "notification.listener").
+				WithField("channel", channel).
+				Warn("Subscriber is too slow, dropping notification")
+		}
+	}
+}
+
+func (l *Listener) subscribe(ctx context.Context, channel string) <-chan []byte {
+	ch := make(chan []byte, subscriberBufferSize)
+
+	l.mu.Lock()
+	if l.subscribers[channel] == nil {
+		l.subscribers[channel] = make(map[chan []byte]struct{})
+	}
+	l.subscribers[channel][ch] = struct{}{}
+	l.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		l.mu.Lock()
+		delete(l.subscribers[channel], ch)
+		l.mu.Unlock()
+		close(ch)
+	}()
+
+	return ch
+}
+
+// Subscribe to events of a type until the context is done.
+// The returned channel is closed after the context is done.
+func Subscribe[E Event](ctx context.Context, l *Listener) <-chan E {
+	var zero E
+	payloads := l.subscribe(ctx, zero.Channel())
+
+	events := make(chan E)
+	go func() {
+		defer close(events)
+
+		for payload := range payloads {
+			var event E
+			if err := json.Unmarshal(payload, &event); err != nil {
+				logger.FromContext(ctx).
+					WithField("component", "notification.listener").
+					WithError(err).
+					Warn("Could not decode notification payload")
+				continue
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
@@ -0,0 +1,27 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/friendsofgo/errors"
+	. "github.com/networkteam/qrb"
+	"github.com/networkteam/qrb/qrbsql"
+)
+
+// Notify publishes an event with pg_notify.
+// If called in a transaction, the notification is only delivered to listeners after the transaction is committed.
+func Notify(ctx context.Context, executor qrbsql.Executor, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "encoding notification payload")
+	}
+
+	query := Select(Func("pg_notify", Arg(event.Channel()), Arg(string(payload))))
+
+	_, err = qrbsql.Build(query).WithExecutor(executor).Exec(ctx)
+	if err != nil {
+		return errors.Wrap(err, "sending notification")
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/qrb/qrbsql"
+
+	"myvendor.mytld/myproject/backend/domain/event"
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+// Append records events in the outbox.
+// It must be called in the transaction of the change, so events are only delivered if the change is committed.
+func Append(ctx context.Context, executor qrbsql.Executor, occurredAt time.Time, events ...event.Event) error {
+	for _, e := range events {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return errors.Wrapf(err, "encoding %s", e.EventType())
+		}
+
+		// Time ordered IDs keep the order of events that occurred at the same time
+		eventID, err := uuid.NewV7()
+		if err != nil {
+			return errors.Wrap(err, "generating event id")
+		}
+
+		err = repository.InsertOutboxEvent(ctx, executor, repository.OutboxEventToChangeSet(model.OutboxEvent{
+			ID:            eventID,
+			EventType:     e.EventType(),
+			Payload:       payload,
+			Status:        model.OutboxEventStatusPending,
+			NextAttemptAt: occurredAt,
+			OccurredAt:    occurredAt,
+		}))
+		if err != nil {
+			return errors.Wrapf(err, "inserting %s", e.EventType())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	logger "github.com/apex/log"
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain/event"
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultBatchSize    = 50
+	defaultMaxAttempts  = 10
+	defaultBackoffBase  = 5 * time.Second
+	defaultBackoffMax   = time.Hour
+)
+
+// HandlerFunc handles a delivered event.
+// Events are delivered at least once, so handlers must be idempotent.
+type HandlerFunc func(ctx context.Context, e event.Event) error
+
+// Metadata describes the outbox record of an event that is passed to subscribers
+type Metadata struct {
+	EventID    uuid.UUID
+	OccurredAt time.Time
+}
+
+type metadataCtxKey struct{}
+
+// MetadataFromContext returns the metadata of the event that is handled by a subscriber
+func MetadataFromContext(ctx context.Context) (Metadata, bool) {
+	metadata, ok := ctx.Value(metadataCtxKey{}).(Metadata)
+	return metadata, ok
+}
+
+type subscriber struct {
+	name       string
+	handler    HandlerFunc
+	eventTypes map[string]struct{}
+}
+
+func (s subscriber) accepts(eventType string) bool {
+	if len(s.eventTypes) == 0 {
+		return true
+	}
+	_, ok := s.eventTypes[eventType]
+	return ok
+}
+
+// Dispatcher delivers pending events from the outbox to subscribers.
+// Multiple dispatchers (e.g. one per server instance) can run concurrently, since due events are locked while they are delivered.
+type Dispatcher struct {
+	db         *sql.DB
+	timeSource types.TimeSource
+
+	pollInterval time.Duration
+	batchSize    int
+	maxAttempts  int
+	backoffBase  time.Duration
+	backoffMax   time.Duration
+
+	mu          sync.RWMutex
+	subscribers []subscriber
+}
+
+type Option func(d *Dispatcher)
+
+// WithPollInterval sets the interval for checking the outbox for due events
+func WithPollInterval(interval time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.pollInterval = interval
+	}
+}
+
+// WithBatchSize sets the maximum number of events that are delivered in one transaction
+func WithBatchSize(size int) Option {
+	return func(d *Dispatcher) {
+		d.batchSize = size
+	}
+}
+
+// WithMaxAttempts sets the number of failed attempts after which an event is marked as dead
+func WithMaxAttempts(attempts int) Option {
+	return func(d *Dispatcher) {
+		d.maxAttempts = attempts
+	}
+}
+
+// WithBackoff sets the delay after the first failed attempt, it is doubled for every further attempt up to max
+func WithBackoff(base, max time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.backoffBase = base
+		d.backoffMax = max
+	}
+}
+
+func NewDispatcher(db *sql.DB, timeSource types.TimeSource, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		db:           db,
+		timeSource:   timeSource,
+		pollInterval: defaultPollInterval,
+		batchSize:    defaultBatchSize,
+		maxAttempts:  defaultMaxAttempts,
+		backoffBase:  defaultBackoffBase,
+		backoffMax:   defaultBackoffMax,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Subscribe registers a handler for the given event types (or all events if none are given).
+// The name identifies the subscriber in logs and errors.
+func (d *Dispatcher) Subscribe(name string, handler HandlerFunc, eventTypes ...string) {
+	s := subscriber{
+		name:    name,
+		handler: handler,
+	}
+	if len(eventTypes) > 0 {
+		s.eventTypes = make(map[string]struct{}, len(eventTypes))
+		for _, eventType := range eventTypes {
+			s.eventTypes[eventType] = struct{}{}
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscribers = append(d.subscribers, s)
+}
+
+// Subscribe registers a typed handler for events of type E
+func Subscribe[E event.Event](d *Dispatcher, name string, handler func(ctx context.Context, e E) error) {
+	var zero E
+	d.Subscribe(name, func(ctx context.Context, e event.Event) error {
+		return handler(ctx, e.(E))
+	}, zero.EventType())
+}
+
+// Run delivers due events until the context is cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	log := logger.FromContext(ctx).
+		WithField("component", "outboxDispatcher")
+
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Deliver batches without waiting while there is a backlog
+		for {
+			n, err := d.DispatchPending(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.WithError(err).Error("Could not dispatch outbox events")
+				}
+				break
+			}
+			if n < d.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending delivers one batch of due events and returns the number of processed events.
+// Failed events are scheduled for a retry with exponential backoff or marked as dead after the maximum number of attempts.
+func (d *Dispatcher) DispatchPending(ctx context.Context) (int, error) {
+	log := logger.FromContext(ctx).
+		WithField("component", "outboxDispatcher")
+
+	var processed int
+	err := repository.Transactional(ctx, d.db, func(tx *sql.Tx) error {
+		now := d.timeSource.Now()
+
+		records, err := repository.FindDueOutboxEventsForUpdate(ctx, tx, now, d.batchSize)
+		if err != nil {
+			return errors.Wrap(err, "finding due outbox events")
+		}
+
+		for _, record := range records {
+			changeSet := d.deliver(ctx, record, now)
+
+			err = repository.UpdateOutboxEvent(ctx, tx, record.ID, changeSet)
+			if err != nil {
+				return errors.Wrap(err, "updating outbox event")
+			}
+
+			if changeSet.LastError != nil {
+				log.
+					WithField("eventID", record.ID).
+					WithField("eventType", record.EventType).
+					WithField("attempts", *changeSet.Attempts).
+					WithField("status", *changeSet.Status).
+					WithField("error", **changeSet.LastError).
+					Warn("Could not deliver outbox event")
+			}
+		}
+		processed = len(records)
+
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "running transaction")
+	}
+
+	return processed, nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, record model.OutboxEvent, now time.Time) repository.OutboxEventChangeSet {
+	attempts := record.Attempts + 1
+
+	e, err := event.Decode(record.EventType, record.Payload)
+	if err == nil {
+		ctx = context.WithValue(ctx, metadataCtxKey{}, Metadata{
+			EventID:    record.ID,
+			OccurredAt: record.OccurredAt,
+		})
+		err = d.notifySubscribers(ctx, e)
+	}
+	if err == nil {
+		status := model.OutboxEventStatusDelivered
+		deliveredAt := &now
+		var lastError *string
+		return repository.OutboxEventChangeSet{
+			Status:      &status,
+			Attempts:    &attempts,
+			DeliveredAt: &deliveredAt,
+			LastError:   &lastError,
+		}
+	}
+
+	status := model.OutboxEventStatusPending
+	// Events that cannot be decoded will not succeed on a retry
+	if attempts >= d.maxAttempts || errors.Is(err, event.ErrUnknownEventType) {
+		status = model.OutboxEventStatusDead
+	}
+	nextAttemptAt := now.Add(d.backoff(attempts))
+	lastError := err.Error()
+	lastErrorPtr := &lastError
+	return repository.OutboxEventChangeSet{
+		Status:        &status,
+		Attempts:      &attempts,
+		NextAttemptAt: &nextAttemptAt,
+		LastError:     &lastErrorPtr,
+	}
+}
+
+// notifySubscribers calls all matching subscribers.
+// If one subscriber fails, the event is retried for all subscribers.
+func (d *Dispatcher) notifySubscribers(ctx context.Context, e event.Event) error {
+	d.mu.RLock()
+	subscribers := d.subscribers
+	d.mu.RUnlock()
+
+	for _, s := range subscribers {
+		if !s.accepts(e.EventType()) {
+			continue
+		}
+		if err := callSubscriber(ctx, s, e); err != nil {
+			return errors.Wrapf(err, "subscriber %s", s.name)
+		}
+	}
+	return nil
+}
+
+func callSubscriber(ctx context.Context, s subscriber, e event.Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return s.handler(ctx, e)
+}
+
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.backoffBase
+	for i := 1; i < attempts && delay < d.backoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, d.backoffMax)
+}
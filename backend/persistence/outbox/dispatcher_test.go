@@ -0,0 +1,98 @@
+package outbox_test
+
+import (
+	"context"
+	"database/sql"
+	std_errors "errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/domain/event"
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/persistence/outbox"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/test"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+)
+
+func TestDispatcher_DispatchPending(t *testing.T) {
+	ctx := context.Background()
+	db := test_db.CreateTestDatabase(t)
+	timeSource := test.NewSettableTimeSource(test.FixedTime().Now())
+
+	organisationID := uuid.Must(uuid.FromString("0190e6a4-2a8e-7c6f-8a0a-2d9c1b2a3f60"))
+	err := repository.Transactional(ctx, db, func(tx *sql.Tx) error {
+		return outbox.Append(ctx, tx, timeSource.Now(),
+			event.OrganisationCreated{OrganisationID: organisationID, Name: "Acme Inc."},
+			event.OrganisationUpdated{OrganisationID: organisationID, Name: "Acme Corp."},
+		)
+	})
+	require.NoError(t, err)
+
+	dispatcher := outbox.NewDispatcher(db, timeSource,
+		outbox.WithMaxAttempts(2),
+		outbox.WithBackoff(time.Minute, time.Hour),
+	)
+
+	var (
+		created []event.OrganisationCreated
+		updated int
+	)
+	outbox.Subscribe(dispatcher, "created", func(_ context.Context, e event.OrganisationCreated) error {
+		created = append(created, e)
+		return nil
+	})
+	outbox.Subscribe(dispatcher, "updated", func(_ context.Context, e event.OrganisationUpdated) error {
+		updated++
+		return std_errors.New("receiver unavailable")
+	})
+
+	n, err := dispatcher.DispatchPending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []event.OrganisationCreated{{OrganisationID: organisationID, Name: "Acme Inc."}}, created)
+
+	delivered := findOutboxEvents(t, db, model.OutboxEventStatusDelivered)
+	require.Len(t, delivered, 1)
+	assert.Equal(t, event.TypeOrganisationCreated, delivered[0].EventType)
+
+	pending := findOutboxEvents(t, db, model.OutboxEventStatusPending)
+	require.Len(t, pending, 1)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.Equal(t, timeSource.Now().Add(time.Minute), pending[0].NextAttemptAt.UTC())
+	require.NotNil(t, pending[0].LastError)
+	assert.Contains(t, *pending[0].LastError, "receiver unavailable")
+
+	// Nothing is due before the backoff elapsed
+	n, err = dispatcher.DispatchPending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	timeSource.Advance(time.Minute)
+
+	n, err = dispatcher.DispatchPending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 2, updated)
+
+	dead := findOutboxEvents(t, db, model.OutboxEventStatusDead)
+	require.Len(t, dead, 1)
+	assert.Equal(t, 2, dead[0].Attempts)
+
+	requeued, err := repository.RequeueDeadOutboxEvents(ctx, db, timeSource.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), requeued)
+	assert.Len(t, findOutboxEvents(t, db, model.OutboxEventStatusPending), 1)
+}
+
+func findOutboxEvents(t *testing.T, db *sql.DB, status model.OutboxEventStatus) []model.OutboxEvent {
+	t.Helper()
+
+	events, err := repository.FindAllOutboxEvents(context.Background(), db, repository.OutboxEventsFilter{Status: &status})
+	require.NoError(t, err)
+	return events
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/friendsofgo/errors"
 	"github.com/gofrs/uuid"
@@ -21,11 +22,17 @@ import (
 type AccountsFilter struct {
 	Opts           *domain_query.AccountQueryOpts
 	OrganisationID *uuid.UUID
-	IDs            []uuid.UUID
+	// OrganisationIDs filters accounts to be assigned to one of the given organisations
+	OrganisationIDs []uuid.UUID
+	IDs             []uuid.UUID
 	// SearchTerm filters accounts by text fields (email address or organisation name)
 	SearchTerm string
+	// OrderByRank orders accounts by relevance for the search term, it should not be combined with sorting
+	OrderByRank bool
 	// Roles filters account to have one of the given roles
 	Roles []types.Role
+	// WithDeleted includes soft deleted accounts
+	WithDeleted bool
 }
 
 func accountBuildFindQuery(opts *domain_query.AccountQueryOpts) builder.SelectBuilder {
@@ -40,9 +47,22 @@ func accountBuildFindQuery(opts *domain_query.AccountQueryOpts) builder.SelectBu
 		})
 }
 
+// FindAccountByID finds an account that is not deleted
 func FindAccountByID(ctx context.Context, executor qrbsql.Executor, id uuid.UUID, opts *domain_query.AccountQueryOpts) (model.Account, error) {
 	query := accountBuildFindQuery(opts).
-		Where(account.ID.Eq(Arg(id)))
+		Where(account.ID.Eq(Arg(id))).
+		Where(account.DeletedAt.IsNull())
+
+	return constructsql.ScanRow[model.Account](
+		qrbsql.Build(query).WithExecutor(executor).QueryRow(ctx),
+	)
+}
+
+// FindDeletedAccountByID finds a soft deleted account (e.g. for restoring it)
+func FindDeletedAccountByID(ctx context.Context, executor qrbsql.Executor, id uuid.UUID, opts *domain_query.AccountQueryOpts) (model.Account, error) {
+	query := accountBuildFindQuery(opts).
+		Where(account.ID.Eq(Arg(id))).
+		Where(account.DeletedAt.IsNotNull())
 
 	return constructsql.ScanRow[model.Account](
 		qrbsql.Build(query).WithExecutor(executor).QueryRow(ctx),
@@ -51,7 +71,8 @@ func FindAccountByID(ctx context.Context, executor qrbsql.Executor, id uuid.UUID
 
 func FindAccountByEmailAddress(ctx context.Context, executor qrbsql.Executor, emailAddress string, opts *domain_query.AccountQueryOpts) (model.Account, error) {
 	query := accountBuildFindQuery(opts).
-		Where(fn.Lower(account.EmailAddress).Eq(Arg(strings.ToLower(emailAddress))))
+		Where(fn.Lower(account.EmailAddress).Eq(Arg(strings.ToLower(emailAddress)))).
+		Where(account.DeletedAt.IsNull())
 
 	return constructsql.ScanRow[model.Account](
 		qrbsql.Build(query).WithExecutor(executor).QueryRow(ctx),
@@ -61,23 +82,22 @@ func FindAccountByEmailAddress(ctx context.Context, executor qrbsql.Executor, em
 func applyAccountFilter(filter AccountsFilter) func(q builder.SelectBuilder) builder.SelectBuilder {
 	return func(q builder.SelectBuilder) builder.SelectBuilder {
 		return q.
+			ApplyIf(!filter.WithDeleted, func(q builder.SelectBuilder) builder.SelectBuilder {
+				return q.Where(account.DeletedAt.IsNull())
+			}).
 			ApplyIf(len(filter.IDs) > 0, func(q builder.SelectBuilder) builder.SelectBuilder {
 				return q.Where(account.ID.Eq(Any(Arg(filter.IDs))))
 			}).
 			ApplyIf(filter.SearchTerm != "", func(q builder.SelectBuilder) builder.SelectBuilder {
-				var incOrg builder.Exp
-				if filter.Opts != nil && filter.Opts.IncludeOrganisation {
-					incOrg = organisation.Name.ILike(Arg("%" + filter.SearchTerm + "%"))
-				}
-
-				return q.Where(Or(
-					account.EmailAddress.ILike(Arg("%"+filter.SearchTerm+"%")),
-					incOrg,
-				))
+				includeOrganisation := filter.Opts != nil && filter.Opts.IncludeOrganisation
+				return q.Where(accountSearchCondition(filter.SearchTerm, includeOrganisation))
 			}).
 			ApplyIf(filter.OrganisationID != nil, func(q builder.SelectBuilder) builder.SelectBuilder {
 				return q.Where(account.OrganisationID.Eq(Arg(*filter.OrganisationID)))
 			}).
+			ApplyIf(len(filter.OrganisationIDs) > 0, func(q builder.SelectBuilder) builder.SelectBuilder {
+				return q.Where(account.OrganisationID.Eq(Any(Arg(filter.OrganisationIDs))))
+			}).
 			ApplyIf(len(filter.Roles) > 0, func(q builder.SelectBuilder) builder.SelectBuilder {
 				return q.Where(account.Role.Eq(Any(Args(filter.Roles))))
 			})
@@ -86,7 +106,11 @@ func applyAccountFilter(filter AccountsFilter) func(q builder.SelectBuilder) bui
 
 func FindAllAccounts(ctx context.Context, executor qrbsql.Executor, filter AccountsFilter, pagingOpts ...PagingOption) ([]model.Account, error) {
 	query := accountBuildFindQuery(filter.Opts).
-		ApplyIf(true, applyAccountFilter(filter))
+		ApplyIf(true, applyAccountFilter(filter)).
+		ApplyIf(filter.OrderByRank && filter.SearchTerm != "", func(q builder.SelectBuilder) builder.SelectBuilder {
+			return q.OrderBy(accountSearchRank(filter.SearchTerm)).Desc().
+				OrderBy(account.ID).SelectBuilder
+		})
 
 	query, err := applyPagingOptions(query, pagingOpts, accountSortFields)
 	if err != nil {
@@ -98,6 +122,31 @@ func FindAllAccounts(ctx context.Context, executor qrbsql.Executor, filter Accou
 	)
 }
 
+// FindAccountsPerOrganisation finds at most limit accounts for each organisation in filter.OrganisationIDs (e.g. for batched loading).
+// Sorting of the paging options is applied to the accounts of each organisation.
+func FindAccountsPerOrganisation(ctx context.Context, executor qrbsql.Executor, filter AccountsFilter, limit int, pagingOpts ...PagingOption) ([]model.Account, error) {
+	organisationIDs := filter.OrganisationIDs
+	filter.OrganisationIDs = nil
+
+	accountsQuery := Select(buildAccountJSON(filter.Opts)).As("account").
+		From(account).
+		Where(account.OrganisationID.Eq(N("organisation_ids.organisation_id"))).
+		ApplyIf(true, applyAccountFilter(filter))
+
+	accountsQuery, err := applyPagingOptions(accountsQuery, append(pagingOpts, WithLimit(limit)), accountSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	query := Select(N("organisation_accounts.account")).
+		From(Func("unnest", Arg(organisationIDs).Cast("uuid[]"))).As("organisation_ids").ColumnAliases("organisation_id").
+		CrossJoinLateral(accountsQuery).As("organisation_accounts")
+
+	return constructsql.CollectRows[model.Account](
+		qrbsql.Build(query).WithExecutor(executor).Query(ctx),
+	)
+}
+
 func CountAccounts(ctx context.Context, executor qrbsql.Executor, filter AccountsFilter) (count int, err error) {
 	query := Select(fn.Count(N("*"))).
 		From(account).
@@ -108,6 +157,19 @@ func CountAccounts(ctx context.Context, executor qrbsql.Executor, filter Account
 	)
 }
 
+// LockAccount locks the account row until the end of the transaction to serialize concurrent actions of an account
+func LockAccount(ctx context.Context, executor qrbsql.Executor, id uuid.UUID) error {
+	query := Select(Bool(true)).
+		From(account).
+		Where(account.ID.Eq(Arg(id))).
+		ForNoKeyUpdate()
+
+	_, err := constructsql.ScanRow[bool](
+		qrbsql.Build(query).WithExecutor(executor).QueryRow(ctx),
+	)
+	return err
+}
+
 func InsertAccount(ctx context.Context, executor qrbsql.Executor, changeSet AccountChangeSet) error {
 	query := InsertInto(account).
 		SetMap(changeSet.toMap())
@@ -116,16 +178,43 @@ func InsertAccount(ctx context.Context, executor qrbsql.Executor, changeSet Acco
 	return err
 }
 
-func UpdateAccount(ctx context.Context, executor qrbsql.Executor, id uuid.UUID, changeSet AccountChangeSet) error {
+// UpdateAccount updates an account, only if it has the expected version if set (see ErrVersionConflict)
+func UpdateAccount(ctx context.Context, executor qrbsql.Executor, id uuid.UUID, changeSet AccountChangeSet, expectedVersion *int) error {
 	query := Update(account).
 		SetMap(changeSet.toMap()).
-		Where(account.ID.Eq(Arg(id)))
+		Where(account.ID.Eq(Arg(id))).
+		ApplyIf(expectedVersion != nil, func(q builder.UpdateBuilder) builder.UpdateBuilder {
+			return q.Where(account.Version.Eq(Arg(*expectedVersion)))
+		})
 
-	return constructsql.AssertRowsAffected("update", 1)(
+	return assertUpdatedAtVersion(expectedVersion)(
 		qrbsql.Build(query).WithExecutor(executor).Exec(ctx),
 	)
 }
 
+// SetAccountsDeletedAt soft deletes (or restores if deletedAt is nil) multiple accounts
+func SetAccountsDeletedAt(ctx context.Context, executor qrbsql.Executor, ids []uuid.UUID, deletedAt *time.Time) error {
+	query := Update(account).
+		Set("deleted_at", Arg(deletedAt)).
+		Where(account.ID.Eq(Any(Arg(ids))))
+
+	return constructsql.AssertRowsAffected("update", len(ids))(
+		qrbsql.Build(query).WithExecutor(executor).Exec(ctx),
+	)
+}
+
+// DeleteAccountsDeletedBefore purges accounts that were soft deleted before the given time
+func DeleteAccountsDeletedBefore(ctx context.Context, executor qrbsql.Executor, before time.Time) (int64, error) {
+	query := DeleteFrom(account).
+		Where(account.DeletedAt.Lt(Arg(before)))
+
+	result, err := qrbsql.Build(query).WithExecutor(executor).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 func DeleteAccount(ctx context.Context, executor qrbsql.Executor, id uuid.UUID) error {
 	query := DeleteFrom(account).
 		Where(account.ID.Eq(Arg(id)))
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/networkteam/construct/v2/constructsql"
+	. "github.com/networkteam/qrb"
+	"github.com/networkteam/qrb/qrbsql"
+)
+
+// TryAdvisoryLock tries to acquire a session level advisory lock without waiting.
+// The executor must be a single connection (e.g. sql.Conn), since the lock belongs to the session and is released with AdvisoryUnlock.
+func TryAdvisoryLock(ctx context.Context, executor qrbsql.Executor, key int64) (bool, error) {
+	query := Select(Func("pg_try_advisory_lock", Arg(key)))
+
+	return constructsql.ScanRow[bool](
+		qrbsql.Build(query).WithExecutor(executor).QueryRow(ctx),
+	)
+}
+
+func AdvisoryUnlock(ctx context.Context, executor qrbsql.Executor, key int64) error {
+	query := Select(Func("pg_advisory_unlock", Arg(key)))
+
+	_, err := qrbsql.Build(query).WithExecutor(executor).Exec(ctx)
+	return err
+}
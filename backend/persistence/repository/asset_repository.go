@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/construct/v2/constructsql"
+	. "github.com/networkteam/qrb"
+	"github.com/networkteam/qrb/qrbsql"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+)
+
+func FindAssetByID(ctx context.Context, executor qrbsql.Executor, id uuid.UUID) (model.Asset, error) {
+	query := Select(assetDefaultJson).
+		From(asset).
+		Where(asset.ID.Eq(Arg(id)))
+
+	return constructsql.ScanRow[model.Asset](
+		qrbsql.Build(query).WithExecutor(executor).QueryRow(ctx),
+	)
+}
+
+func InsertAsset(ctx context.Context, executor qrbsql.Executor, changeSet AssetChangeSet) error {
+	q := InsertInto(asset).
+		SetMap(changeSet.toMap())
+
+	_, err := qrbsql.Build(q).WithExecutor(executor).Exec(ctx)
+	return err
+}
+
+func DeleteAsset(ctx context.Context, executor qrbsql.Executor, id uuid.UUID) error {
+	query := DeleteFrom(asset).
+		Where(asset.ID.Eq(Arg(id)))
+
+	return constructsql.AssertRowsAffected("delete", 1)(
+		qrbsql.Build(query).WithExecutor(executor).Exec(ctx),
+	)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/construct/v2/constructsql"
+	. "github.com/networkteam/qrb"
+	"github.com/networkteam/qrb/builder"
+	"github.com/networkteam/qrb/fn"
+	"github.com/networkteam/qrb/qrbsql"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+)
+
+type AuditEntriesFilter struct {
+	OrganisationID *uuid.UUID
+	ActorAccountID *uuid.UUID
+	CommandType    *string
+	// TargetID only returns entries of commands that affected the record
+	TargetID *uuid.UUID
+	// OccurredFrom is inclusive, OccurredTo exclusive
+	OccurredFrom *time.Time
+	OccurredTo   *time.Time
+}
+
+func applyAuditEntryFilter(filter AuditEntriesFilter) func(q builder.SelectBuilder) builder.SelectBuilder {
+	return func(q builder.SelectBuilder) builder.SelectBuilder {
+		return q.
+			ApplyIf(filter.OrganisationID != nil, func(q builder.SelectBuilder) builder.SelectBuilder {
+				return q.Where(auditEntry.OrganisationID.Eq(Arg(*filter.OrganisationID)))
+			}).
+			ApplyIf(filter.ActorAccountID != nil, func(q builder.SelectBuilder) builder.SelectBuilder {
+				return q.Where(auditEntry.ActorAccountID.Eq(Arg(*filter.ActorAccountID)))
+			}).
+			ApplyIf(filter.CommandType != nil, func(q builder.SelectBuilder) builder.SelectBuilder {
+				return q.Where(auditEntry.CommandType.Eq(Arg(*filter.CommandType)))
+			}).
+			ApplyIf(filter.TargetID != nil, func(q builder.SelectBuilder) builder.SelectBuilder {
+				return q.Where(auditEntry.TargetIDs.Op("@>", Arg([]uuid.UUID{*filter.TargetID})))
+			}).
+			ApplyIf(filter.OccurredFrom != nil, func(q builder.SelectBuilder) builder.SelectBuilder {
+				return q.Where(auditEntry.OccurredAt.Gte(Arg(*filter.OccurredFrom)))
+			}).
+			ApplyIf(filter.OccurredTo != nil, func(q builder.SelectBuilder) builder.SelectBuilder {
+				return q.Where(auditEntry.OccurredAt.Lt(Arg(*filter.OccurredTo)))
+			})
+	}
+}
+
+func FindAllAuditEntries(ctx context.Context, executor qrbsql.Executor, filter AuditEntriesFilter, pagingOpts ...PagingOption) ([]model.AuditEntry, error) {
+	query := Select(auditEntryDefaultJson).
+		From(auditEntry).
+		ApplyIf(true, applyAuditEntryFilter(filter))
+
+	query, err := applyPagingOptions(query, pagingOpts, auditEntrySortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return constructsql.CollectRows[model.AuditEntry](
+		qrbsql.Build(query).WithExecutor(executor).Query(ctx),
+	)
+}
+
+func CountAuditEntries(ctx context.Context, executor qrbsql.Executor, filter AuditEntriesFilter) (count int, err error) {
+	query := Select(fn.Count(N("*"))).
+		From(auditEntry).
+		ApplyIf(true, applyAuditEntryFilter(filter))
+
+	return constructsql.ScanRow[int](
+		qrbsql.Build(query).WithExecutor(executor).QueryRow(ctx),
+	)
+}
+
+// InsertAuditEntry appends an entry, there are no functions for updating or deleting entries
+func InsertAuditEntry(ctx context.Context, executor qrbsql.Executor, changeSet AuditEntryChangeSet) error {
+	query := InsertInto(auditEntry).
+		SetMap(changeSet.toMap())
+
+	_, err := qrbsql.Build(query).WithExecutor(executor).Exec(ctx)
+	return err
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	std_errors "errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	. "github.com/networkteam/qrb"
+	"github.com/networkteam/qrb/qrbsql"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+)
+
+var errNoPgxConn = std_errors.New("connection is not a pgx connection")
+
+// InsertOrganisations inserts organisations with their timestamps in a single statement (e.g. for generated data).
+// The number of organisations is limited by the maximum of 65535 parameters of a statement.
+func InsertOrganisations(ctx context.Context, executor qrbsql.Executor, organisations []model.Organisation) error {
+	if len(organisations) == 0 {
+		return nil
+	}
+
+	q := InsertInto(organisation).
+		ColumnNames("organisation_id", "name", "created_at", "updated_at")
+	for _, o := range organisations {
+		q = q.Values(Arg(o.ID), Arg(o.Name), Arg(o.CreatedAt), Arg(o.UpdatedAt))
+	}
+
+	_, err := qrbsql.Build(q).WithExecutor(executor).Exec(ctx)
+	return err
+}
+
+// CopyAccounts inserts accounts with their timestamps using COPY, which is much faster than INSERT for large volumes.
+// COPY runs in the transaction that is open on the connection (if any), so it is committed or rolled back with it.
+func CopyAccounts(ctx context.Context, conn *sql.Conn, accounts []model.Account) error {
+	return conn.Raw(func(driverConn any) error {
+		stdlibConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errNoPgxConn
+		}
+
+		_, err := stdlibConn.Conn().CopyFrom(
+			ctx,
+			pgx.Identifier{"accounts"},
+			[]string{"account_id", "email_address", "secret", "password_hash", "role_identifier", "organisation_id", "last_login", "created_at", "updated_at"},
+			pgx.CopyFromSlice(len(accounts), func(i int) ([]any, error) {
+				a := accounts[i]
+				return []any{a.ID, a.EmailAddress, a.Secret, a.PasswordHash, string(a.Role), a.OrganisationID, a.LastLogin, a.CreatedAt, a.UpdatedAt}, nil
+			}),
+		)
+		return err
+	})
+}
@@ -0,0 +1,82 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	std_errors "errors"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/test"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+)
+
+func TestCopyAccounts_InTransaction(t *testing.T) {
+	ctx := context.Background()
+	db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+	now := test.FixedTime().Now()
+
+	organisation := model.Organisation{
+		ID:        uuid.Must(uuid.FromString("0190e6a4-2a8e-7c6f-8a0a-2d9c1b2a3f60")),
+		Name:      "Blue Harbor Logistics GmbH",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	accounts := []model.Account{
+		{
+			ID:             uuid.Must(uuid.FromString("0190e6a4-2a8e-7c6f-8a0a-2d9c1b2a3f61")),
+			EmailAddress:   "anna.schmidt@blue-harbor.example.com",
+			Secret:         []byte("secret"),
+			PasswordHash:   []byte("hash"),
+			Role:           types.RoleOrganisationAdministrator,
+			OrganisationID: uuid.NullUUID{UUID: organisation.ID, Valid: true},
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		},
+	}
+
+	baseCount, err := repository.CountAccounts(ctx, db, repository.AccountsFilter{})
+	require.NoError(t, err)
+
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	insert := func(tx *sql.Tx) error {
+		err := repository.InsertOrganisations(ctx, tx, []model.Organisation{organisation})
+		if err != nil {
+			return err
+		}
+		return repository.CopyAccounts(ctx, conn, accounts)
+	}
+
+	t.Run("rolled back with the transaction", func(t *testing.T) {
+		errFailed := std_errors.New("failed")
+		err := repository.Transactional(ctx, conn, func(tx *sql.Tx) error {
+			if err := insert(tx); err != nil {
+				return err
+			}
+			return errFailed
+		})
+		require.ErrorIs(t, err, errFailed)
+
+		count, err := repository.CountAccounts(ctx, db, repository.AccountsFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, baseCount, count)
+	})
+
+	t.Run("committed with the transaction", func(t *testing.T) {
+		err := repository.Transactional(ctx, conn, insert)
+		require.NoError(t, err)
+
+		count, err := repository.CountAccounts(ctx, db, repository.AccountsFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, baseCount+1, count)
+	})
+}
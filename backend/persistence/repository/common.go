@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
 	"github.com/hashicorp/go-multierror"
 	"github.com/networkteam/qrb/builder"
 )
@@ -114,6 +115,65 @@ func WithOffset(offset int) PagingOption {
 	}
 }
 
+// KeysetCursor is the position of a row in a listing that is paginated by keyset
+type KeysetCursor struct {
+	// SortValue is the value of the sort field of the row (unused if sorted by id only)
+	SortValue string
+	ID        uuid.UUID
+}
+
+// WithKeyset orders by the given field with the id as a tie-breaker and only returns rows after the given cursor (if set).
+// The sort field must not be nullable, since NULL values cannot be compared in a keyset condition.
+func WithKeyset(field, order string, after *KeysetCursor) PagingOption {
+	return func(query builder.SelectBuilder, sortFieldMapping map[string]builder.IdentExp) (builder.SelectBuilder, error) {
+		col, ok := sortFieldMapping[strings.ToLower(field)]
+		if !ok {
+			return query, errors.Wrap(ErrInvalidSortField, field)
+		}
+		idCol, ok := sortFieldMapping["id"]
+		if !ok {
+			return query, errors.Wrap(ErrInvalidSortField, "id")
+		}
+
+		beyond := func(col builder.IdentExp, value builder.Exp) builder.Exp {
+			if order == SortOrderDesc {
+				return col.Lt(value)
+			}
+			return col.Gt(value)
+		}
+
+		sortByID := col.Ident() == idCol.Ident()
+
+		if after != nil {
+			if sortByID {
+				query = query.Where(beyond(idCol, builder.Arg(after.ID)))
+			} else {
+				query = query.Where(builder.Or(
+					beyond(col, builder.Arg(after.SortValue)),
+					builder.And(
+						col.Eq(builder.Arg(after.SortValue)),
+						beyond(idCol, builder.Arg(after.ID)),
+					),
+				))
+			}
+		}
+
+		orderCols := []builder.IdentExp{col, idCol}
+		if sortByID {
+			orderCols = orderCols[:1]
+		}
+		for _, orderCol := range orderCols {
+			if order == SortOrderDesc {
+				query = query.OrderBy(orderCol).Desc().SelectBuilder
+			} else {
+				query = query.OrderBy(orderCol).Asc().SelectBuilder
+			}
+		}
+
+		return query, nil
+	}
+}
+
 func applyPagingOptions(query builder.SelectBuilder, opts []PagingOption, sortFieldMapping map[string]builder.IdentExp) (builder.SelectBuilder, error) {
 	for _, opt := range opts {
 		var err error
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/networkteam/construct/v2/constructsql"
+	. "github.com/networkteam/qrb"
+	"github.com/networkteam/qrb/qrbsql"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+)
+
+func FindAllCronJobs(ctx context.Context, executor qrbsql.Executor) ([]model.CronJob, error) {
+	query := Select(cronJobDefaultJson).
+		From(cronJob).
+		OrderBy(cronJob.Name)
+
+	return constructsql.CollectRows[model.CronJob](
+		qrbsql.Build(query).WithExecutor(executor).Query(ctx),
+	)
+}
+
+func FindCronJobByName(ctx context.Context, executor qrbsql.Executor, name string) (model.CronJob, error) {
+	query := Select(cronJobDefaultJson).
+		From(cronJob).
+		Where(cronJob.Name.Eq(Arg(name)))
+
+	return constructsql.ScanRow[model.CronJob](
+		qrbsql.Build(query).WithExecutor(executor).QueryRow(ctx),
+	)
+}
+
+// InsertCronJob inserts the state of a cron job if it does not exist yet
+func InsertCronJob(ctx context.Context, executor qrbsql.Executor, changeSet CronJobChangeSet) error {
+	query := InsertInto(cronJob).
+		SetMap(changeSet.toMap()).
+		OnConflict(N("name")).DoNothing()
+
+	_, err := qrbsql.Build(query).WithExecutor(executor).Exec(ctx)
+	return err
+}
+
+func UpdateCronJob(ctx context.Context, executor qrbsql.Executor, name string, changeSet CronJobChangeSet) error {
+	query := Update(cronJob).
+		SetMap(changeSet.toMap()).
+		Where(cronJob.Name.Eq(Arg(name)))
+
+	return constructsql.AssertRowsAffected("update", 1)(
+		qrbsql.Build(query).WithExecutor(executor).Exec(ctx),
+	)
+}
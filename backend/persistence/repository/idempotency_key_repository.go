@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/construct/v2/constructsql"
+	. "github.com/networkteam/qrb"
+	"github.com/networkteam/qrb/qrbsql"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+)
+
+func FindIdempotencyKey(ctx context.Context, executor qrbsql.Executor, accountID uuid.UUID, key string) (model.IdempotencyKey, error) {
+	query := Select(idempotencyKeyDefaultJson).
+		From(idempotencyKey).
+		Where(And(
+			idempotencyKey.AccountID.Eq(Arg(accountID)),
+			idempotencyKey.Key.Eq(Arg(key)),
+		))
+
+	return constructsql.ScanRow[model.IdempotencyKey](
+		qrbsql.Build(query).WithExecutor(executor).QueryRow(ctx),
+	)
+}
+
+// InsertIdempotencyKey inserts a key if it does not exist for the account.
+// It returns false if the key exists, so a concurrent or earlier request with the key must be checked.
+func InsertIdempotencyKey(ctx context.Context, executor qrbsql.Executor, changeSet IdempotencyKeyChangeSet) (inserted bool, err error) {
+	query := InsertInto(idempotencyKey).
+		SetMap(changeSet.toMap()).
+		OnConflict().DoNothing()
+
+	result, err := qrbsql.Build(query).WithExecutor(executor).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func UpdateIdempotencyKey(ctx context.Context, executor qrbsql.Executor, accountID uuid.UUID, key string, changeSet IdempotencyKeyChangeSet) error {
+	query := Update(idempotencyKey).
+		SetMap(changeSet.toMap()).
+		Where(And(
+			idempotencyKey.AccountID.Eq(Arg(accountID)),
+			idempotencyKey.Key.Eq(Arg(key)),
+		))
+
+	return constructsql.AssertRowsAffected("update", 1)(
+		qrbsql.Build(query).WithExecutor(executor).Exec(ctx),
+	)
+}
+
+func DeleteIdempotencyKey(ctx context.Context, executor qrbsql.Executor, accountID uuid.UUID, key string) error {
+	query := DeleteFrom(idempotencyKey).
+		Where(And(
+			idempotencyKey.AccountID.Eq(Arg(accountID)),
+			idempotencyKey.Key.Eq(Arg(key)),
+		))
+
+	_, err := qrbsql.Build(query).WithExecutor(executor).Exec(ctx)
+	return err
+}
+
+// DeleteIdempotencyKeysExpiredBefore deletes keys after their retention
+func DeleteIdempotencyKeysExpiredBefore(ctx context.Context, executor qrbsql.Executor, before time.Time) (int64, error) {
+	query := DeleteFrom(idempotencyKey).
+		Where(idempotencyKey.ExpiresAt.Lt(Arg(before)))
+
+	result, err := qrbsql.Build(query).WithExecutor(executor).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/construct/v2/constructsql"
+	. "github.com/networkteam/qrb"
+	"github.com/networkteam/qrb/builder"
+	"github.com/networkteam/qrb/fn"
+	"github.com/networkteam/qrb/qrbsql"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+)
+
+type JobsFilter struct {
+	Status  *model.JobStatus
+	JobType *string
+}
+
+func FindAllJobs(ctx context.Context, executor qrbsql.Executor, filter JobsFilter, pagingOpts ...PagingOption) ([]model.Job, error) {
+	query := Select(jobDefaultJson).
+		From(job).
+		ApplyIf(filter.Status != nil, func(q builder.SelectBuilder) builder.SelectBuilder {
+			return q.Where(job.Status.Eq(Arg(*filter.Status)))
+		}).
+		ApplyIf(filter.JobType != nil, func(q builder.SelectBuilder) builder.SelectBuilder {
+			return q.Where(job.JobType.Eq(Arg(*filter.JobType)))
+		})
+
+	query, err := applyPagingOptions(query, pagingOpts, jobSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return constructsql.CollectRows[model.Job](
+		qrbsql.Build(query).WithExecutor(executor).Query(ctx),
+	)
+}
+
+// InsertJob inserts a job if no job with the same type and unique key is pending or running.
+// It returns false if the job was skipped because of the unique key.
+func InsertJob(ctx context.Context, executor qrbsql.Executor, changeSet JobChangeSet) (inserted bool, err error) {
+	query := InsertInto(job).
+		SetMap(changeSet.toMap()).
+		OnConflict().DoNothing()
+
+	result, err := qrbsql.Build(query).WithExecutor(executor).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// ClaimDueJobs marks due jobs as running until lockedUntil and returns them.
+// Jobs that are still running after their lock expired (e.g. because a worker crashed) are claimed again.
+// Rows locked by concurrent claims are skipped, so every job is claimed by exactly one worker.
+func ClaimDueJobs(ctx context.Context, executor qrbsql.Executor, now, lockedUntil time.Time, limit int) ([]model.Job, error) {
+	dueJobs := Select(job.ID).
+		From(job).
+		Where(Or(
+			And(
+				job.Status.Eq(Arg(model.JobStatusPending)),
+				job.RunAt.Lte(Arg(now)),
+			),
+			And(
+				job.Status.Eq(Arg(model.JobStatusRunning)),
+				job.LockedUntil.Lte(Arg(now)),
+			),
+		)).
+		OrderBy(job.RunAt).
+		Limit(Arg(limit)).
+		ForUpdate().SkipLocked()
+
+	query := Update(job).
+		Set("status", Arg(model.JobStatusRunning)).
+		Set("attempts", job.Attempts.Plus(Int(1))).
+		Set("locked_until", Arg(lockedUntil)).
+		Where(job.ID.In(dueJobs)).
+		Returning(jobDefaultJson)
+
+	return constructsql.CollectRows[model.Job](
+		qrbsql.Build(query).WithExecutor(executor).Query(ctx),
+	)
+}
+
+func UpdateJob(ctx context.Context, executor qrbsql.Executor, id uuid.UUID, changeSet JobChangeSet) error {
+	query := Update(job).
+		SetMap(changeSet.toMap()).
+		Where(job.ID.Eq(Arg(id)))
+
+	return constructsql.AssertRowsAffected("update", 1)(
+		qrbsql.Build(query).WithExecutor(executor).Exec(ctx),
+	)
+}
+
+type JobTypeCount struct {
+	JobType string
+	Count   int
+}
+
+// CountPendingJobsByType counts the pending jobs that are due at now by job type
+func CountPendingJobsByType(ctx context.Context, executor qrbsql.Executor, now time.Time) ([]JobTypeCount, error) {
+	query := Select(
+		fn.JsonBuildObject().
+			Prop("JobType", job.JobType).
+			Prop("Count", fn.Count(N("*"))),
+	).
+		From(job).
+		Where(And(
+			job.Status.Eq(Arg(model.JobStatusPending)),
+			job.RunAt.Lte(Arg(now)),
+		)).
+		GroupBy(job.JobType)
+
+	return constructsql.CollectRows[JobTypeCount](
+		qrbsql.Build(query).WithExecutor(executor).Query(ctx),
+	)
+}
+
+// DeleteJobsCompletedBefore deletes completed jobs, failed jobs are kept for inspection
+func DeleteJobsCompletedBefore(ctx context.Context, executor qrbsql.Executor, before time.Time) (int64, error) {
+	query := DeleteFrom(job).
+		Where(And(
+			job.Status.Eq(Arg(model.JobStatusCompleted)),
+			job.CompletedAt.Lt(Arg(before)),
+		))
+
+	result, err := qrbsql.Build(query).WithExecutor(executor).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -24,8 +24,11 @@ var account = struct {
 	OrganisationID builder.IdentExp
 	CreatedAt      builder.IdentExp
 	UpdatedAt      builder.IdentExp
+	Version        builder.IdentExp
+	DeletedAt      builder.IdentExp
 }{
 	CreatedAt:      qrb.N("accounts.created_at"),
+	DeletedAt:      qrb.N("accounts.deleted_at"),
 	EmailAddress:   qrb.N("accounts.email_address"),
 	ID:             qrb.N("accounts.account_id"),
 	Identer:        qrb.N("accounts"),
@@ -35,11 +38,13 @@ var account = struct {
 	Role:           qrb.N("accounts.role_identifier"),
 	Secret:         qrb.N("accounts.secret"),
 	UpdatedAt:      qrb.N("accounts.updated_at"),
+	Version:        qrb.N("accounts.version"),
 }
 
 var accountSortFields = map[string]builder.IdentExp{
 	"createdat":    account.CreatedAt,
 	"emailaddress": account.EmailAddress,
+	"id":           account.ID,
 	"lastlogin":    account.LastLogin,
 	"role":         account.Role,
 	"updatedat":    account.UpdatedAt,
@@ -53,6 +58,7 @@ type AccountChangeSet struct {
 	Role           *domain.Role
 	LastLogin      **time.Time
 	OrganisationID *uuid.NullUUID
+	DeletedAt      **time.Time
 }
 
 func (c AccountChangeSet) toMap() map[string]interface{} {
@@ -78,6 +84,9 @@ func (c AccountChangeSet) toMap() map[string]interface{} {
 	if c.OrganisationID != nil {
 		m["organisation_id"] = *c.OrganisationID
 	}
+	if c.DeletedAt != nil {
+		m["deleted_at"] = *c.DeletedAt
+	}
 	return m
 }
 
@@ -91,6 +100,7 @@ func AccountToChangeSet(r model.Account) (c AccountChangeSet) {
 	c.Role = &r.Role
 	c.LastLogin = &r.LastLogin
 	c.OrganisationID = &r.OrganisationID
+	c.DeletedAt = &r.DeletedAt
 	return
 }
 
@@ -103,4 +113,6 @@ var accountDefaultJson = fn.JsonBuildObject().
 	Prop("LastLogin", account.LastLogin).
 	Prop("OrganisationID", account.OrganisationID).
 	Prop("CreatedAt", account.CreatedAt).
-	Prop("UpdatedAt", account.UpdatedAt)
+	Prop("UpdatedAt", account.UpdatedAt).
+	Prop("Version", account.Version).
+	Prop("DeletedAt", account.DeletedAt)
@@ -0,0 +1,124 @@
+// Code generated by construct, DO NOT EDIT.
+package repository
+
+import (
+	uuid "github.com/gofrs/uuid"
+	qrb "github.com/networkteam/qrb"
+	builder "github.com/networkteam/qrb/builder"
+	fn "github.com/networkteam/qrb/fn"
+
+	domain "myvendor.mytld/myproject/backend/domain/model"
+)
+
+var asset = struct {
+	builder.Identer
+	ID             builder.IdentExp
+	OrganisationID builder.IdentExp
+	StorageKey     builder.IdentExp
+	Filename       builder.IdentExp
+	ContentType    builder.IdentExp
+	Size           builder.IdentExp
+	Checksum       builder.IdentExp
+	CreatedBy      builder.IdentExp
+	Width          builder.IdentExp
+	Height         builder.IdentExp
+	CreatedAt      builder.IdentExp
+}{
+	Checksum:       qrb.N("assets.checksum"),
+	ContentType:    qrb.N("assets.content_type"),
+	CreatedAt:      qrb.N("assets.created_at"),
+	CreatedBy:      qrb.N("assets.created_by"),
+	Filename:       qrb.N("assets.filename"),
+	Height:         qrb.N("assets.height"),
+	ID:             qrb.N("assets.asset_id"),
+	Identer:        qrb.N("assets"),
+	OrganisationID: qrb.N("assets.organisation_id"),
+	Size:           qrb.N("assets.size"),
+	StorageKey:     qrb.N("assets.storage_key"),
+	Width:          qrb.N("assets.width"),
+}
+
+var assetSortFields = map[string]builder.IdentExp{
+	"contenttype": asset.ContentType,
+	"createdat":   asset.CreatedAt,
+	"filename":    asset.Filename,
+	"id":          asset.ID,
+	"size":        asset.Size,
+}
+
+type AssetChangeSet struct {
+	ID             *uuid.UUID
+	OrganisationID *uuid.NullUUID
+	StorageKey     *string
+	Filename       *string
+	ContentType    *string
+	Size           *int64
+	Checksum       *string
+	CreatedBy      *uuid.NullUUID
+	Width          **int
+	Height         **int
+}
+
+func (c AssetChangeSet) toMap() map[string]interface{} {
+	m := make(map[string]interface{})
+	if c.ID != nil {
+		m["asset_id"] = *c.ID
+	}
+	if c.OrganisationID != nil {
+		m["organisation_id"] = *c.OrganisationID
+	}
+	if c.StorageKey != nil {
+		m["storage_key"] = *c.StorageKey
+	}
+	if c.Filename != nil {
+		m["filename"] = *c.Filename
+	}
+	if c.ContentType != nil {
+		m["content_type"] = *c.ContentType
+	}
+	if c.Size != nil {
+		m["size"] = *c.Size
+	}
+	if c.Checksum != nil {
+		m["checksum"] = *c.Checksum
+	}
+	if c.CreatedBy != nil {
+		m["created_by"] = *c.CreatedBy
+	}
+	if c.Width != nil {
+		m["width"] = *c.Width
+	}
+	if c.Height != nil {
+		m["height"] = *c.Height
+	}
+	return m
+}
+
+func AssetToChangeSet(r domain.Asset) (c AssetChangeSet) {
+	if r.ID != uuid.Nil {
+		c.ID = &r.ID
+	}
+	c.OrganisationID = &r.OrganisationID
+	c.StorageKey = &r.StorageKey
+	c.Filename = &r.Filename
+	c.ContentType = &r.ContentType
+	c.Size = &r.Size
+	c.Checksum = &r.Checksum
+	c.CreatedBy = &r.CreatedBy
+	c.Width = &r.Width
+	c.Height = &r.Height
+	return
+}
+
+var assetDefaultJson = fn.JsonBuildObject().
+	Prop("ID", asset.ID).
+	Prop("OrganisationID", asset.OrganisationID).
+	Prop("StorageKey", asset.StorageKey).
+	Prop("Filename", asset.Filename).
+	Prop("ContentType", asset.ContentType).
+	Prop("Size", asset.Size).
+	Prop("Checksum", asset.Checksum).
+	Prop("CreatedBy", asset.CreatedBy).
+	Prop("Width", asset.Width).
+	Prop("Height", asset.Height).
+	Prop("CreatedAt", asset.CreatedAt)
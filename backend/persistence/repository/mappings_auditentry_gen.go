@@ -0,0 +1,124 @@
+// Code generated by construct, DO NOT EDIT.
+package repository
+
+import (
+	"encoding/json"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	qrb "github.com/networkteam/qrb"
+	builder "github.com/networkteam/qrb/builder"
+	fn "github.com/networkteam/qrb/fn"
+
+	domain "myvendor.mytld/myproject/backend/domain/model"
+)
+
+var auditEntry = struct {
+	builder.Identer
+	ID                    builder.IdentExp
+	OccurredAt            builder.IdentExp
+	ActorAccountID        builder.IdentExp
+	ImpersonatorAccountID builder.IdentExp
+	OrganisationID        builder.IdentExp
+	CommandType           builder.IdentExp
+	TargetIDs             builder.IdentExp
+	Changes               builder.IdentExp
+	RequestID             builder.IdentExp
+	IPAddress             builder.IdentExp
+}{
+	ActorAccountID:        qrb.N("audit_log.actor_account_id"),
+	Changes:               qrb.N("audit_log.changes"),
+	CommandType:           qrb.N("audit_log.command_type"),
+	ID:                    qrb.N("audit_log.audit_entry_id"),
+	IPAddress:             qrb.N("audit_log.ip_address"),
+	Identer:               qrb.N("audit_log"),
+	ImpersonatorAccountID: qrb.N("audit_log.impersonator_account_id"),
+	OccurredAt:            qrb.N("audit_log.occurred_at"),
+	OrganisationID:        qrb.N("audit_log.organisation_id"),
+	RequestID:             qrb.N("audit_log.request_id"),
+	TargetIDs:             qrb.N("audit_log.target_ids"),
+}
+
+var auditEntrySortFields = map[string]builder.IdentExp{
+	"commandtype": auditEntry.CommandType,
+	"id":          auditEntry.ID,
+	"occurredat":  auditEntry.OccurredAt,
+}
+
+type AuditEntryChangeSet struct {
+	ID                    *uuid.UUID
+	OccurredAt            *time.Time
+	ActorAccountID        *uuid.NullUUID
+	ImpersonatorAccountID *uuid.NullUUID
+	OrganisationID        *uuid.NullUUID
+	CommandType           *string
+	TargetIDs             []uuid.UUID
+	Changes               *json.RawMessage
+	RequestID             **string
+	IPAddress             **string
+}
+
+func (c AuditEntryChangeSet) toMap() map[string]interface{} {
+	m := make(map[string]interface{})
+	if c.ID != nil {
+		m["audit_entry_id"] = *c.ID
+	}
+	if c.OccurredAt != nil {
+		m["occurred_at"] = *c.OccurredAt
+	}
+	if c.ActorAccountID != nil {
+		m["actor_account_id"] = *c.ActorAccountID
+	}
+	if c.ImpersonatorAccountID != nil {
+		m["impersonator_account_id"] = *c.ImpersonatorAccountID
+	}
+	if c.OrganisationID != nil {
+		m["organisation_id"] = *c.OrganisationID
+	}
+	if c.CommandType != nil {
+		m["command_type"] = *c.CommandType
+	}
+	if c.TargetIDs != nil {
+		m["target_ids"] = c.TargetIDs
+	}
+	if c.Changes != nil {
+		m["changes"] = *c.Changes
+	}
+	if c.RequestID != nil {
+		m["request_id"] = *c.RequestID
+	}
+	if c.IPAddress != nil {
+		m["ip_address"] = *c.IPAddress
+	}
+	return m
+}
+
+func AuditEntryToChangeSet(r domain.AuditEntry) (c AuditEntryChangeSet) {
+	if r.ID != uuid.Nil {
+		c.ID = &r.ID
+	}
+	if !r.OccurredAt.IsZero() {
+		c.OccurredAt = &r.OccurredAt
+	}
+	c.ActorAccountID = &r.ActorAccountID
+	c.ImpersonatorAccountID = &r.ImpersonatorAccountID
+	c.OrganisationID = &r.OrganisationID
+	c.CommandType = &r.CommandType
+	c.TargetIDs = r.TargetIDs
+	c.Changes = &r.Changes
+	c.RequestID = &r.RequestID
+	c.IPAddress = &r.IPAddress
+	return
+}
+
+var auditEntryDefaultJson = fn.JsonBuildObject().
+	Prop("ID", auditEntry.ID).
+	Prop("OccurredAt", auditEntry.OccurredAt).
+	Prop("ActorAccountID", auditEntry.ActorAccountID).
+	Prop("ImpersonatorAccountID", auditEntry.ImpersonatorAccountID).
+	Prop("OrganisationID", auditEntry.OrganisationID).
+	Prop("CommandType", auditEntry.CommandType).
+	Prop("TargetIDs", auditEntry.TargetIDs).
+	Prop("Changes", auditEntry.Changes).
+	Prop("RequestID", auditEntry.RequestID).
+	Prop("IPAddress", auditEntry.IPAddress)
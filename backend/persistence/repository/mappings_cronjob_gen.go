@@ -0,0 +1,96 @@
+// Code generated by construct, DO NOT EDIT.
+package repository
+
+import (
+	"time"
+
+	qrb "github.com/networkteam/qrb"
+	builder "github.com/networkteam/qrb/builder"
+	fn "github.com/networkteam/qrb/fn"
+
+	domain "myvendor.mytld/myproject/backend/domain/model"
+)
+
+var cronJob = struct {
+	builder.Identer
+	Name           builder.IdentExp
+	Schedule       builder.IdentExp
+	NextRunAt      builder.IdentExp
+	LastRunAt      builder.IdentExp
+	LastFinishedAt builder.IdentExp
+	LastStatus     builder.IdentExp
+	LastError      builder.IdentExp
+}{
+	Identer:        qrb.N("cron_jobs"),
+	LastError:      qrb.N("cron_jobs.last_error"),
+	LastFinishedAt: qrb.N("cron_jobs.last_finished_at"),
+	LastRunAt:      qrb.N("cron_jobs.last_run_at"),
+	LastStatus:     qrb.N("cron_jobs.last_status"),
+	Name:           qrb.N("cron_jobs.name"),
+	NextRunAt:      qrb.N("cron_jobs.next_run_at"),
+	Schedule:       qrb.N("cron_jobs.schedule"),
+}
+
+var cronJobSortFields = map[string]builder.IdentExp{
+	"lastrunat": cronJob.LastRunAt,
+	"name":      cronJob.Name,
+	"nextrunat": cronJob.NextRunAt,
+}
+
+type CronJobChangeSet struct {
+	Name           *string
+	Schedule       *string
+	NextRunAt      *time.Time
+	LastRunAt      **time.Time
+	LastFinishedAt **time.Time
+	LastStatus     **domain.CronRunStatus
+	LastError      **string
+}
+
+func (c CronJobChangeSet) toMap() map[string]interface{} {
+	m := make(map[string]interface{})
+	if c.Name != nil {
+		m["name"] = *c.Name
+	}
+	if c.Schedule != nil {
+		m["schedule"] = *c.Schedule
+	}
+	if c.NextRunAt != nil {
+		m["next_run_at"] = *c.NextRunAt
+	}
+	if c.LastRunAt != nil {
+		m["last_run_at"] = *c.LastRunAt
+	}
+	if c.LastFinishedAt != nil {
+		m["last_finished_at"] = *c.LastFinishedAt
+	}
+	if c.LastStatus != nil {
+		m["last_status"] = *c.LastStatus
+	}
+	if c.LastError != nil {
+		m["last_error"] = *c.LastError
+	}
+	return m
+}
+
+func CronJobToChangeSet(r domain.CronJob) (c CronJobChangeSet) {
+	c.Name = &r.Name
+	c.Schedule = &r.Schedule
+	if !r.NextRunAt.IsZero() {
+		c.NextRunAt = &r.NextRunAt
+	}
+	c.LastRunAt = &r.LastRunAt
+	c.LastFinishedAt = &r.LastFinishedAt
+	c.LastStatus = &r.LastStatus
+	c.LastError = &r.LastError
+	return
+}
+
+var cronJobDefaultJson = fn.JsonBuildObject().
+	Prop("Name", cronJob.Name).
+	Prop("Schedule", cronJob.Schedule).
+	Prop("NextRunAt", cronJob.NextRunAt).
+	Prop("LastRunAt", cronJob.LastRunAt).
+	Prop("LastFinishedAt", cronJob.LastFinishedAt).
+	Prop("LastStatus", cronJob.LastStatus).
+	Prop("LastError", cronJob.LastError)
@@ -0,0 +1,101 @@
+// Code generated by construct, DO NOT EDIT.
+package repository
+
+import (
+	"encoding/json"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	qrb "github.com/networkteam/qrb"
+	builder "github.com/networkteam/qrb/builder"
+	fn "github.com/networkteam/qrb/fn"
+
+	domain "myvendor.mytld/myproject/backend/domain/model"
+)
+
+var idempotencyKey = struct {
+	builder.Identer
+	AccountID   builder.IdentExp
+	Key         builder.IdentExp
+	RequestHash builder.IdentExp
+	Response    builder.IdentExp
+	CreatedAt   builder.IdentExp
+	CompletedAt builder.IdentExp
+	ExpiresAt   builder.IdentExp
+}{
+	AccountID:   qrb.N("idempotency_keys.account_id"),
+	CompletedAt: qrb.N("idempotency_keys.completed_at"),
+	CreatedAt:   qrb.N("idempotency_keys.created_at"),
+	ExpiresAt:   qrb.N("idempotency_keys.expires_at"),
+	Identer:     qrb.N("idempotency_keys"),
+	Key:         qrb.N("idempotency_keys.key"),
+	RequestHash: qrb.N("idempotency_keys.request_hash"),
+	Response:    qrb.N("idempotency_keys.response"),
+}
+
+var idempotencyKeySortFields = map[string]builder.IdentExp{
+	"createdat": idempotencyKey.CreatedAt,
+	"expiresat": idempotencyKey.ExpiresAt,
+}
+
+type IdempotencyKeyChangeSet struct {
+	AccountID   *uuid.UUID
+	Key         *string
+	RequestHash *string
+	Response    *json.RawMessage
+	CreatedAt   *time.Time
+	CompletedAt **time.Time
+	ExpiresAt   *time.Time
+}
+
+func (c IdempotencyKeyChangeSet) toMap() map[string]interface{} {
+	m := make(map[string]interface{})
+	if c.AccountID != nil {
+		m["account_id"] = *c.AccountID
+	}
+	if c.Key != nil {
+		m["key"] = *c.Key
+	}
+	if c.RequestHash != nil {
+		m["request_hash"] = *c.RequestHash
+	}
+	if c.Response != nil {
+		m["response"] = *c.Response
+	}
+	if c.CreatedAt != nil {
+		m["created_at"] = *c.CreatedAt
+	}
+	if c.CompletedAt != nil {
+		m["completed_at"] = *c.CompletedAt
+	}
+	if c.ExpiresAt != nil {
+		m["expires_at"] = *c.ExpiresAt
+	}
+	return m
+}
+
+func IdempotencyKeyToChangeSet(r domain.IdempotencyKey) (c IdempotencyKeyChangeSet) {
+	if r.AccountID != uuid.Nil {
+		c.AccountID = &r.AccountID
+	}
+	c.Key = &r.Key
+	c.RequestHash = &r.RequestHash
+	c.Response = &r.Response
+	if !r.CreatedAt.IsZero() {
+		c.CreatedAt = &r.CreatedAt
+	}
+	c.CompletedAt = &r.CompletedAt
+	if !r.ExpiresAt.IsZero() {
+		c.ExpiresAt = &r.ExpiresAt
+	}
+	return
+}
+
+var idempotencyKeyDefaultJson = fn.JsonBuildObject().
+	Prop("AccountID", idempotencyKey.AccountID).
+	Prop("Key", idempotencyKey.Key).
+	Prop("RequestHash", idempotencyKey.RequestHash).
+	Prop("Response", idempotencyKey.Response).
+	Prop("CreatedAt", idempotencyKey.CreatedAt).
+	Prop("CompletedAt", idempotencyKey.CompletedAt).
+	Prop("ExpiresAt", idempotencyKey.ExpiresAt)
@@ -0,0 +1,143 @@
+// Code generated by construct, DO NOT EDIT.
+package repository
+
+import (
+	"encoding/json"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	qrb "github.com/networkteam/qrb"
+	builder "github.com/networkteam/qrb/builder"
+	fn "github.com/networkteam/qrb/fn"
+
+	domain "myvendor.mytld/myproject/backend/domain/model"
+)
+
+var job = struct {
+	builder.Identer
+	ID          builder.IdentExp
+	JobType     builder.IdentExp
+	Payload     builder.IdentExp
+	UniqueKey   builder.IdentExp
+	Status      builder.IdentExp
+	Attempts    builder.IdentExp
+	MaxAttempts builder.IdentExp
+	RunAt       builder.IdentExp
+	LockedUntil builder.IdentExp
+	LastError   builder.IdentExp
+	CreatedAt   builder.IdentExp
+	CompletedAt builder.IdentExp
+}{
+	Attempts:    qrb.N("jobs.attempts"),
+	CompletedAt: qrb.N("jobs.completed_at"),
+	CreatedAt:   qrb.N("jobs.created_at"),
+	ID:          qrb.N("jobs.job_id"),
+	Identer:     qrb.N("jobs"),
+	JobType:     qrb.N("jobs.job_type"),
+	LastError:   qrb.N("jobs.last_error"),
+	LockedUntil: qrb.N("jobs.locked_until"),
+	MaxAttempts: qrb.N("jobs.max_attempts"),
+	Payload:     qrb.N("jobs.payload"),
+	RunAt:       qrb.N("jobs.run_at"),
+	Status:      qrb.N("jobs.status"),
+	UniqueKey:   qrb.N("jobs.unique_key"),
+}
+
+var jobSortFields = map[string]builder.IdentExp{
+	"createdat": job.CreatedAt,
+	"jobtype":   job.JobType,
+	"runat":     job.RunAt,
+	"status":    job.Status,
+}
+
+type JobChangeSet struct {
+	ID          *uuid.UUID
+	JobType     *string
+	Payload     *json.RawMessage
+	UniqueKey   **string
+	Status      *domain.JobStatus
+	Attempts    *int
+	MaxAttempts *int
+	RunAt       *time.Time
+	LockedUntil **time.Time
+	LastError   **string
+	CreatedAt   *time.Time
+	CompletedAt **time.Time
+}
+
+func (c JobChangeSet) toMap() map[string]interface{} {
+	m := make(map[string]interface{})
+	if c.ID != nil {
+		m["job_id"] = *c.ID
+	}
+	if c.JobType != nil {
+		m["job_type"] = *c.JobType
+	}
+	if c.Payload != nil {
+		m["payload"] = *c.Payload
+	}
+	if c.UniqueKey != nil {
+		m["unique_key"] = *c.UniqueKey
+	}
+	if c.Status != nil {
+		m["status"] = *c.Status
+	}
+	if c.Attempts != nil {
+		m["attempts"] = *c.Attempts
+	}
+	if c.MaxAttempts != nil {
+		m["max_attempts"] = *c.MaxAttempts
+	}
+	if c.RunAt != nil {
+		m["run_at"] = *c.RunAt
+	}
+	if c.LockedUntil != nil {
+		m["locked_until"] = *c.LockedUntil
+	}
+	if c.LastError != nil {
+		m["last_error"] = *c.LastError
+	}
+	if c.CreatedAt != nil {
+		m["created_at"] = *c.CreatedAt
+	}
+	if c.CompletedAt != nil {
+		m["completed_at"] = *c.CompletedAt
+	}
+	return m
+}
+
+func JobToChangeSet(r domain.Job) (c JobChangeSet) {
+	if r.ID != uuid.Nil {
+		c.ID = &r.ID
+	}
+	c.JobType = &r.JobType
+	c.Payload = &r.Payload
+	c.UniqueKey = &r.UniqueKey
+	c.Status = &r.Status
+	c.Attempts = &r.Attempts
+	c.MaxAttempts = &r.MaxAttempts
+	if !r.RunAt.IsZero() {
+		c.RunAt = &r.RunAt
+	}
+	c.LockedUntil = &r.LockedUntil
+	c.LastError = &r.LastError
+	if !r.CreatedAt.IsZero() {
+		c.CreatedAt = &r.CreatedAt
+	}
+	c.CompletedAt = &r.CompletedAt
+	return
+}
+
+var jobDefaultJson = fn.JsonBuildObject().
+	Prop("ID", job.ID).
+	Prop("JobType", job.JobType).
+	Prop("Payload", job.Payload).
+	Prop("UniqueKey", job.UniqueKey).
+	Prop("Status", job.Status).
+	Prop("Attempts", job.Attempts).
+	Prop("MaxAttempts", job.MaxAttempts).
+	Prop("RunAt", job.RunAt).
+	Prop("LockedUntil", job.LockedUntil).
+	Prop("LastError", job.LastError).
+	Prop("CreatedAt", job.CreatedAt).
+	Prop("CompletedAt", job.CompletedAt)
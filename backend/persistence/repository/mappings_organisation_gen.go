@@ -2,6 +2,8 @@
 package repository
 
 import (
+	"time"
+
 	uuid "github.com/gofrs/uuid"
 	qrb "github.com/networkteam/qrb"
 	builder "github.com/networkteam/qrb/builder"
@@ -16,23 +18,29 @@ var organisation = struct {
 	Name      builder.IdentExp
 	CreatedAt builder.IdentExp
 	UpdatedAt builder.IdentExp
+	Version   builder.IdentExp
+	DeletedAt builder.IdentExp
 }{
 	CreatedAt: qrb.N("organisations.created_at"),
+	DeletedAt: qrb.N("organisations.deleted_at"),
 	ID:        qrb.N("organisations.organisation_id"),
 	Identer:   qrb.N("organisations"),
 	Name:      qrb.N("organisations.name"),
 	UpdatedAt: qrb.N("organisations.updated_at"),
+	Version:   qrb.N("organisations.version"),
 }
 
 var organisationSortFields = map[string]builder.IdentExp{
 	"createdat": organisation.CreatedAt,
+	"id":        organisation.ID,
 	"name":      organisation.Name,
 	"updatedat": organisation.UpdatedAt,
 }
 
 type OrganisationChangeSet struct {
-	ID   *uuid.UUID
-	Name *string
+	ID        *uuid.UUID
+	Name      *string
+	DeletedAt **time.Time
 }
 
 func (c OrganisationChangeSet) toMap() map[string]interface{} {
@@ -43,6 +51,9 @@ func (c OrganisationChangeSet) toMap() map[string]interface{} {
 	if c.Name != nil {
 		m["name"] = *c.Name
 	}
+	if c.DeletedAt != nil {
+		m["deleted_at"] = *c.DeletedAt
+	}
 	return m
 }
 
@@ -51,6 +62,7 @@ func OrganisationToChangeSet(r domain.Organisation) (c OrganisationChangeSet) {
 		c.ID = &r.ID
 	}
 	c.Name = &r.Name
+	c.DeletedAt = &r.DeletedAt
 	return
 }
 
@@ -58,4 +70,6 @@ var organisationDefaultJson = fn.JsonBuildObject().
 	Prop("ID", organisation.ID).
 	Prop("Name", organisation.Name).
 	Prop("CreatedAt", organisation.CreatedAt).
-	Prop("UpdatedAt", organisation.UpdatedAt)
+	Prop("UpdatedAt", organisation.UpdatedAt).
+	Prop("Version", organisation.Version).
+	Prop("DeletedAt", organisation.DeletedAt)
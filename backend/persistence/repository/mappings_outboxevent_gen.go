@@ -0,0 +1,119 @@
+// Code generated by construct, DO NOT EDIT.
+package repository
+
+import (
+	"encoding/json"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	qrb "github.com/networkteam/qrb"
+	builder "github.com/networkteam/qrb/builder"
+	fn "github.com/networkteam/qrb/fn"
+
+	domain "myvendor.mytld/myproject/backend/domain/model"
+)
+
+var outboxEvent = struct {
+	builder.Identer
+	ID            builder.IdentExp
+	EventType     builder.IdentExp
+	Payload       builder.IdentExp
+	Status        builder.IdentExp
+	Attempts      builder.IdentExp
+	NextAttemptAt builder.IdentExp
+	LastError     builder.IdentExp
+	OccurredAt    builder.IdentExp
+	DeliveredAt   builder.IdentExp
+}{
+	Attempts:      qrb.N("outbox_events.attempts"),
+	DeliveredAt:   qrb.N("outbox_events.delivered_at"),
+	EventType:     qrb.N("outbox_events.event_type"),
+	ID:            qrb.N("outbox_events.event_id"),
+	Identer:       qrb.N("outbox_events"),
+	LastError:     qrb.N("outbox_events.last_error"),
+	NextAttemptAt: qrb.N("outbox_events.next_attempt_at"),
+	OccurredAt:    qrb.N("outbox_events.occurred_at"),
+	Payload:       qrb.N("outbox_events.payload"),
+	Status:        qrb.N("outbox_events.status"),
+}
+
+var outboxEventSortFields = map[string]builder.IdentExp{
+	"eventtype":     outboxEvent.EventType,
+	"nextattemptat": outboxEvent.NextAttemptAt,
+	"occurredat":    outboxEvent.OccurredAt,
+	"status":        outboxEvent.Status,
+}
+
+type OutboxEventChangeSet struct {
+	ID            *uuid.UUID
+	EventType     *string
+	Payload       *json.RawMessage
+	Status        *domain.OutboxEventStatus
+	Attempts      *int
+	NextAttemptAt *time.Time
+	LastError     **string
+	OccurredAt    *time.Time
+	DeliveredAt   **time.Time
+}
+
+func (c OutboxEventChangeSet) toMap() map[string]interface{} {
+	m := make(map[string]interface{})
+	if c.ID != nil {
+		m["event_id"] = *c.ID
+	}
+	if c.EventType != nil {
+		m["event_type"] = *c.EventType
+	}
+	if c.Payload != nil {
+		m["payload"] = *c.Payload
+	}
+	if c.Status != nil {
+		m["status"] = *c.Status
+	}
+	if c.Attempts != nil {
+		m["attempts"] = *c.Attempts
+	}
+	if c.NextAttemptAt != nil {
+		m["next_attempt_at"] = *c.NextAttemptAt
+	}
+	if c.LastError != nil {
+		m["last_error"] = *c.LastError
+	}
+	if c.OccurredAt != nil {
+		m["occurred_at"] = *c.OccurredAt
+	}
+	if c.DeliveredAt != nil {
+		m["delivered_at"] = *c.DeliveredAt
+	}
+	return m
+}
+
+func OutboxEventToChangeSet(r domain.OutboxEvent) (c OutboxEventChangeSet) {
+	if r.ID != uuid.Nil {
+		c.ID = &r.ID
+	}
+	c.EventType = &r.EventType
+	c.Payload = &r.Payload
+	c.Status = &r.Status
+	c.Attempts = &r.Attempts
+	if !r.NextAttemptAt.IsZero() {
+		c.NextAttemptAt = &r.NextAttemptAt
+	}
+	c.LastError = &r.LastError
+	if !r.OccurredAt.IsZero() {
+		c.OccurredAt = &r.OccurredAt
+	}
+	c.DeliveredAt = &r.DeliveredAt
+	return
+}
+
+var outboxEventDefaultJson = fn.JsonBuildObject().
+	Prop("ID", outboxEvent.ID).
+	Prop("EventType", outboxEvent.EventType).
+	Prop("Payload", outboxEvent.Payload).
+	Prop("Status", outboxEvent.Status).
+	Prop("Attempts", outboxEvent.Attempts).
+	Prop("NextAttemptAt", outboxEvent.NextAttemptAt).
+	Prop("LastError", outboxEvent.LastError).
+	Prop("OccurredAt", outboxEvent.OccurredAt).
+	Prop("DeliveredAt", outboxEvent.DeliveredAt)
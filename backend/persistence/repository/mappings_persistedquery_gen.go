@@ -0,0 +1,54 @@
+// Code generated by construct, DO NOT EDIT.
+package repository
+
+import (
+	qrb "github.com/networkteam/qrb"
+	builder "github.com/networkteam/qrb/builder"
+	fn "github.com/networkteam/qrb/fn"
+
+	domain "myvendor.mytld/myproject/backend/domain/model"
+)
+
+var persistedQuery = struct {
+	builder.Identer
+	Hash      builder.IdentExp
+	Document  builder.IdentExp
+	CreatedAt builder.IdentExp
+}{
+	CreatedAt: qrb.N("persisted_queries.created_at"),
+	Document:  qrb.N("persisted_queries.document"),
+	Hash:      qrb.N("persisted_queries.hash"),
+	Identer:   qrb.N("persisted_queries"),
+}
+
+var persistedQuerySortFields = map[string]builder.IdentExp{
+	"createdat": persistedQuery.CreatedAt,
+	"hash":      persistedQuery.Hash,
+}
+
+type PersistedQueryChangeSet struct {
+	Hash     *string
+	Document *string
+}
+
+func (c PersistedQueryChangeSet) toMap() map[string]interface{} {
+	m := make(map[string]interface{})
+	if c.Hash != nil {
+		m["hash"] = *c.Hash
+	}
+	if c.Document != nil {
+		m["document"] = *c.Document
+	}
+	return m
+}
+
+func PersistedQueryToChangeSet(r domain.PersistedQuery) (c PersistedQueryChangeSet) {
+	c.Hash = &r.Hash
+	c.Document = &r.Document
+	return
+}
+
+var persistedQueryDefaultJson = fn.JsonBuildObject().
+	Prop("Hash", persistedQuery.Hash).
+	Prop("Document", persistedQuery.Document).
+	Prop("CreatedAt", persistedQuery.CreatedAt)
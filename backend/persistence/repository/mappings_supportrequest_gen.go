@@ -0,0 +1,72 @@
+// Code generated by construct, DO NOT EDIT.
+package repository
+
+import (
+	uuid "github.com/gofrs/uuid"
+	qrb "github.com/networkteam/qrb"
+	builder "github.com/networkteam/qrb/builder"
+	fn "github.com/networkteam/qrb/fn"
+	"time"
+
+	domain "myvendor.mytld/myproject/backend/domain/model"
+)
+
+var supportRequest = struct {
+	builder.Identer
+	ID        builder.IdentExp
+	AccountID builder.IdentExp
+	Subject   builder.IdentExp
+	CreatedAt builder.IdentExp
+}{
+	AccountID: qrb.N("support_requests.account_id"),
+	CreatedAt: qrb.N("support_requests.created_at"),
+	ID:        qrb.N("support_requests.support_request_id"),
+	Identer:   qrb.N("support_requests"),
+	Subject:   qrb.N("support_requests.subject"),
+}
+
+var supportRequestSortFields = map[string]builder.IdentExp{"createdat": supportRequest.CreatedAt}
+
+type SupportRequestChangeSet struct {
+	ID        *uuid.UUID
+	AccountID *uuid.UUID
+	Subject   *string
+	CreatedAt *time.Time
+}
+
+func (c SupportRequestChangeSet) toMap() map[string]interface{} {
+	m := make(map[string]interface{})
+	if c.ID != nil {
+		m["support_request_id"] = *c.ID
+	}
+	if c.AccountID != nil {
+		m["account_id"] = *c.AccountID
+	}
+	if c.Subject != nil {
+		m["subject"] = *c.Subject
+	}
+	if c.CreatedAt != nil {
+		m["created_at"] = *c.CreatedAt
+	}
+	return m
+}
+
+func SupportRequestToChangeSet(r domain.SupportRequest) (c SupportRequestChangeSet) {
+	if r.ID != uuid.Nil {
+		c.ID = &r.ID
+	}
+	if r.AccountID != uuid.Nil {
+		c.AccountID = &r.AccountID
+	}
+	c.Subject = &r.Subject
+	if !r.CreatedAt.IsZero() {
+		c.CreatedAt = &r.CreatedAt
+	}
+	return
+}
+
+var supportRequestDefaultJson = fn.JsonBuildObject().
+	Prop("ID", supportRequest.ID).
+	Prop("AccountID", supportRequest.AccountID).
+	Prop("Subject", supportRequest.Subject).
+	Prop("CreatedAt", supportRequest.CreatedAt)
@@ -0,0 +1,156 @@
+// Code generated by construct, DO NOT EDIT.
+package repository
+
+import (
+	"encoding/json"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	qrb "github.com/networkteam/qrb"
+	builder "github.com/networkteam/qrb/builder"
+	fn "github.com/networkteam/qrb/fn"
+
+	domain "myvendor.mytld/myproject/backend/domain/model"
+)
+
+var webhookDelivery = struct {
+	builder.Identer
+	ID                builder.IdentExp
+	WebhookEndpointID builder.IdentExp
+	OrganisationID    builder.IdentExp
+	EventID           builder.IdentExp
+	EventType         builder.IdentExp
+	Payload           builder.IdentExp
+	Status            builder.IdentExp
+	Attempts          builder.IdentExp
+	ResponseStatus    builder.IdentExp
+	LastError         builder.IdentExp
+	CreatedAt         builder.IdentExp
+	LastAttemptAt     builder.IdentExp
+	DeliveredAt       builder.IdentExp
+}{
+	Attempts:          qrb.N("webhook_deliveries.attempts"),
+	CreatedAt:         qrb.N("webhook_deliveries.created_at"),
+	DeliveredAt:       qrb.N("webhook_deliveries.delivered_at"),
+	EventID:           qrb.N("webhook_deliveries.event_id"),
+	EventType:         qrb.N("webhook_deliveries.event_type"),
+	ID:                qrb.N("webhook_deliveries.webhook_delivery_id"),
+	Identer:           qrb.N("webhook_deliveries"),
+	LastAttemptAt:     qrb.N("webhook_deliveries.last_attempt_at"),
+	LastError:         qrb.N("webhook_deliveries.last_error"),
+	OrganisationID:    qrb.N("webhook_deliveries.organisation_id"),
+	Payload:           qrb.N("webhook_deliveries.payload"),
+	ResponseStatus:    qrb.N("webhook_deliveries.response_status"),
+	Status:            qrb.N("webhook_deliveries.status"),
+	WebhookEndpointID: qrb.N("webhook_deliveries.webhook_endpoint_id"),
+}
+
+var webhookDeliverySortFields = map[string]builder.IdentExp{
+	"createdat":     webhookDelivery.CreatedAt,
+	"eventtype":     webhookDelivery.EventType,
+	"id":            webhookDelivery.ID,
+	"lastattemptat": webhookDelivery.LastAttemptAt,
+	"status":        webhookDelivery.Status,
+}
+
+type WebhookDeliveryChangeSet struct {
+	ID                *uuid.UUID
+	WebhookEndpointID *uuid.UUID
+	OrganisationID    *uuid.UUID
+	EventID           *uuid.UUID
+	EventType         *string
+	Payload           *json.RawMessage
+	Status            *domain.WebhookDeliveryStatus
+	Attempts          *int
+	ResponseStatus    **int
+	LastError         **string
+	CreatedAt         *time.Time
+	LastAttemptAt     **time.Time
+	DeliveredAt       **time.Time
+}
+
+func (c WebhookDeliveryChangeSet) toMap() map[string]interface{} {
+	m := make(map[string]interface{})
+	if c.ID != nil {
+		m["webhook_delivery_id"] = *c.ID
+	}
+	if c.WebhookEndpointID != nil {
+		m["webhook_endpoint_id"] = *c.WebhookEndpointID
+	}
+	if c.OrganisationID != nil {
+		m["organisation_id"] = *c.OrganisationID
+	}
+	if c.EventID != nil {
+		m["event_id"] = *c.EventID
+	}
+	if c.EventType != nil {
+		m["event_type"] = *c.EventType
+	}
+	if c.Payload != nil {
+		m["payload"] = *c.Payload
+	}
+	if c.Status != nil {
+		m["status"] = *c.Status
+	}
+	if c.Attempts != nil {
+		m["attempts"] = *c.Attempts
+	}
+	if c.ResponseStatus != nil {
+		m["response_status"] = *c.ResponseStatus
+	}
+	if c.LastError != nil {
+		m["last_error"] = *c.LastError
+	}
+	if c.CreatedAt != nil {
+		m["created_at"] = *c.CreatedAt
+	}
+	if c.LastAttemptAt != nil {
+		m["last_attempt_at"] = *c.LastAttemptAt
+	}
+	if c.DeliveredAt != nil {
+		m["delivered_at"] = *c.DeliveredAt
+	}
+	return m
+}
+
+func WebhookDeliveryToChangeSet(r domain.WebhookDelivery) (c WebhookDeliveryChangeSet) {
+	if r.ID != uuid.Nil {
+		c.ID = &r.ID
+	}
+	if r.WebhookEndpointID != uuid.Nil {
+		c.WebhookEndpointID = &r.WebhookEndpointID
+	}
+	if r.OrganisationID != uuid.Nil {
+		c.OrganisationID = &r.OrganisationID
+	}
+	if r.EventID != uuid.Nil {
+		c.EventID = &r.EventID
+	}
+	c.EventType = &r.EventType
+	c.Payload = &r.Payload
+	c.Status = &r.Status
+	c.Attempts = &r.Attempts
+	c.ResponseStatus = &r.ResponseStatus
+	c.LastError = &r.LastError
+	if !r.CreatedAt.IsZero() {
+		c.CreatedAt = &r.CreatedAt
+	}
+	c.LastAttemptAt = &r.LastAttemptAt
+	c.DeliveredAt = &r.DeliveredAt
+	return
+}
+
+var webhookDeliveryDefaultJson = fn.JsonBuildObject().
+	Prop("ID", webhookDelivery.ID).
+	Prop("WebhookEndpointID", webhookDelivery.WebhookEndpointID).
+	Prop("OrganisationID", webhookDelivery.OrganisationID).
+	Prop("EventID", webhookDelivery.EventID).
+	Prop("EventType", webhookDelivery.EventType).
+	Prop("Payload", webhookDelivery.Payload).
+	Prop("Status", webhookDelivery.Status).
+	Prop("Attempts", webhookDelivery.Attempts).
+	Prop("ResponseStatus", webhookDelivery.ResponseStatus).
+	Prop("LastError", webhookDelivery.LastError).
+	Prop("CreatedAt", webhookDelivery.CreatedAt).
+	Prop("LastAttemptAt", webhookDelivery.LastAttemptAt).
+	Prop("DeliveredAt", webhookDelivery.DeliveredAt)
@@ -0,0 +1,88 @@
+// Code generated by construct, DO NOT EDIT.
+package repository
+
+import (
+	uuid "github.com/gofrs/uuid"
+	qrb "github.com/networkteam/qrb"
+	builder "github.com/networkteam/qrb/builder"
+	fn "github.com/networkteam/qrb/fn"
+
+	domain "myvendor.mytld/myproject/backend/domain/model"
+)
+
+var webhookEndpoint = struct {
+	builder.Identer
+	ID             builder.IdentExp
+	OrganisationID builder.IdentExp
+	URL            builder.IdentExp
+	Secret         builder.IdentExp
+	EventTypes     builder.IdentExp
+	CreatedAt      builder.IdentExp
+	UpdatedAt      builder.IdentExp
+}{
+	CreatedAt:      qrb.N("webhook_endpoints.created_at"),
+	EventTypes:     qrb.N("webhook_endpoints.event_types"),
+	ID:             qrb.N("webhook_endpoints.webhook_endpoint_id"),
+	Identer:        qrb.N("webhook_endpoints"),
+	OrganisationID: qrb.N("webhook_endpoints.organisation_id"),
+	Secret:         qrb.N("webhook_endpoints.secret"),
+	URL:            qrb.N("webhook_endpoints.url"),
+	UpdatedAt:      qrb.N("webhook_endpoints.updated_at"),
+}
+
+var webhookEndpointSortFields = map[string]builder.IdentExp{
+	"createdat": webhookEndpoint.CreatedAt,
+	"id":        webhookEndpoint.ID,
+	"updatedat": webhookEndpoint.UpdatedAt,
+	"url":       webhookEndpoint.URL,
+}
+
+type WebhookEndpointChangeSet struct {
+	ID             *uuid.UUID
+	OrganisationID *uuid.UUID
+	URL            *string
+	Secret         *string
+	EventTypes     []string
+}
+
+func (c WebhookEndpointChangeSet) toMap() map[string]interface{} {
+	m := make(map[string]interface{})
+	if c.ID != nil {
+		m["webhook_endpoint_id"] = *c.ID
+	}
+	if c.OrganisationID != nil {
+		m["organisation_id"] = *c.OrganisationID
+	}
+	if c.URL != nil {
+		m["url"] = *c.URL
+	}
+	if c.Secret != nil {
+		m["secret"] = *c.Secret
+	}
+	if c.EventTypes != nil {
+		m["event_types"] = c.EventTypes
+	}
+	return m
+}
+
+func WebhookEndpointToChangeSet(r domain.WebhookEndpoint) (c WebhookEndpointChangeSet) {
+	if r.ID != uuid.Nil {
+		c.ID = &r.ID
+	}
+	if r.OrganisationID != uuid.Nil {
+		c.OrganisationID = &r.OrganisationID
+	}
+	c.URL = &r.URL
+	c.Secret = &r.Secret
+	c.EventTypes = r.EventTypes
+	return
+}
+
+var webhookEndpointDefaultJson = fn.JsonBuildObject().
+	Prop("ID", webhookEndpoint.ID).
+	Prop("OrganisationID", webhookEndpoint.OrganisationID).
+	Prop("URL", webhookEndpoint.URL).
+	Prop("Secret", webhookEndpoint.Secret).
+	Prop("EventTypes", webhookEndpoint.EventTypes).
+	Prop("CreatedAt", webhookEndpoint.CreatedAt).
+	Prop("UpdatedAt", webhookEndpoint.UpdatedAt)
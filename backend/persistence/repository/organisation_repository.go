@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/networkteam/construct/v2/constructsql"
@@ -18,6 +19,10 @@ type OrganisationsFilter struct {
 	Opts       *domain_query.OrganisationQueryOpts
 	IDs        []uuid.UUID
 	SearchTerm string
+	// OrderByRank orders organisations by relevance for the search term, it should not be combined with sorting
+	OrderByRank bool
+	// WithDeleted includes soft deleted organisations
+	WithDeleted bool
 }
 
 func organisationBuildFindQuery(opts *domain_query.OrganisationQueryOpts) builder.SelectBuilder {
@@ -26,9 +31,22 @@ func organisationBuildFindQuery(opts *domain_query.OrganisationQueryOpts) builde
 		SelectBuilder
 }
 
+// FindOrganisationByID finds an organisation that is not deleted
 func FindOrganisationByID(ctx context.Context, executor qrbsql.Executor, id uuid.UUID, opts *domain_query.OrganisationQueryOpts) (model.Organisation, error) {
 	query := organisationBuildFindQuery(opts).
-		Where(organisation.ID.Eq(Arg(id)))
+		Where(organisation.ID.Eq(Arg(id))).
+		Where(organisation.DeletedAt.IsNull())
+
+	return constructsql.ScanRow[model.Organisation](
+		qrbsql.Build(query).WithExecutor(executor).QueryRow(ctx),
+	)
+}
+
+// FindDeletedOrganisationByID finds a soft deleted organisation (e.g. for restoring it)
+func FindDeletedOrganisationByID(ctx context.Context, executor qrbsql.Executor, id uuid.UUID, opts *domain_query.OrganisationQueryOpts) (model.Organisation, error) {
+	query := organisationBuildFindQuery(opts).
+		Where(organisation.ID.Eq(Arg(id))).
+		Where(organisation.DeletedAt.IsNotNull())
 
 	return constructsql.ScanRow[model.Organisation](
 		qrbsql.Build(query).WithExecutor(executor).QueryRow(ctx),
@@ -38,18 +56,25 @@ func FindOrganisationByID(ctx context.Context, executor qrbsql.Executor, id uuid
 func applyOrganisationFilter(filter OrganisationsFilter) func(q builder.SelectBuilder) builder.SelectBuilder {
 	return func(q builder.SelectBuilder) builder.SelectBuilder {
 		return q.
+			ApplyIf(!filter.WithDeleted, func(q builder.SelectBuilder) builder.SelectBuilder {
+				return q.Where(organisation.DeletedAt.IsNull())
+			}).
 			ApplyIf(len(filter.IDs) > 0, func(q builder.SelectBuilder) builder.SelectBuilder {
 				return q.Where(organisation.ID.Eq(Any(Arg(filter.IDs))))
 			}).
 			ApplyIf(filter.SearchTerm != "", func(q builder.SelectBuilder) builder.SelectBuilder {
-				return q.Where(organisation.Name.ILike(Arg("%" + filter.SearchTerm + "%")))
+				return q.Where(organisationSearchCondition(filter.SearchTerm))
 			})
 	}
 }
 
 func FindAllOrganisations(ctx context.Context, executor qrbsql.Executor, filter OrganisationsFilter, pagingOpts ...PagingOption) ([]model.Organisation, error) {
 	query := organisationBuildFindQuery(filter.Opts).
-		ApplyIf(true, applyOrganisationFilter(filter))
+		ApplyIf(true, applyOrganisationFilter(filter)).
+		ApplyIf(filter.OrderByRank && filter.SearchTerm != "", func(q builder.SelectBuilder) builder.SelectBuilder {
+			return q.OrderBy(organisationSearchRank(filter.SearchTerm)).Desc().
+				OrderBy(organisation.ID).SelectBuilder
+		})
 
 	query, err := applyPagingOptions(query, pagingOpts, organisationSortFields)
 	if err != nil {
@@ -79,16 +104,33 @@ func InsertOrganisation(ctx context.Context, executor qrbsql.Executor, changeSet
 	return err
 }
 
-func UpdateOrganisation(ctx context.Context, executor qrbsql.Executor, id uuid.UUID, changeSet OrganisationChangeSet) error {
+// UpdateOrganisation updates an organisation, only if it has the expected version if set (see ErrVersionConflict)
+func UpdateOrganisation(ctx context.Context, executor qrbsql.Executor, id uuid.UUID, changeSet OrganisationChangeSet, expectedVersion *int) error {
 	q := Update(organisation).
 		Where(organisation.ID.Eq(Arg(id))).
+		ApplyIf(expectedVersion != nil, func(q builder.UpdateBuilder) builder.UpdateBuilder {
+			return q.Where(organisation.Version.Eq(Arg(*expectedVersion)))
+		}).
 		SetMap(changeSet.toMap())
 
-	return constructsql.AssertRowsAffected("update", 1)(
+	return assertUpdatedAtVersion(expectedVersion)(
 		qrbsql.Build(q).WithExecutor(executor).Exec(ctx),
 	)
 }
 
+// DeleteOrganisationsDeletedBefore purges organisations that were soft deleted before the given time.
+// Accounts of the organisations are deleted by cascade.
+func DeleteOrganisationsDeletedBefore(ctx context.Context, executor qrbsql.Executor, before time.Time) (int64, error) {
+	query := DeleteFrom(organisation).
+		Where(organisation.DeletedAt.Lt(Arg(before)))
+
+	result, err := qrbsql.Build(query).WithExecutor(executor).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 func DeleteOrganisation(ctx context.Context, executor qrbsql.Executor, id uuid.UUID) error {
 	query := DeleteFrom(organisation).
 		Where(organisation.ID.Eq(Arg(id)))
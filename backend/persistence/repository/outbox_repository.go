@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/construct/v2/constructsql"
+	. "github.com/networkteam/qrb"
+	"github.com/networkteam/qrb/builder"
+	"github.com/networkteam/qrb/qrbsql"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+)
+
+type OutboxEventsFilter struct {
+	Status *model.OutboxEventStatus
+}
+
+func FindAllOutboxEvents(ctx context.Context, executor qrbsql.Executor, filter OutboxEventsFilter, pagingOpts ...PagingOption) ([]model.OutboxEvent, error) {
+	query := Select(outboxEventDefaultJson).
+		From(outboxEvent).
+		ApplyIf(filter.Status != nil, func(q builder.SelectBuilder) builder.SelectBuilder {
+			return q.Where(outboxEvent.Status.Eq(Arg(*filter.Status)))
+		})
+
+	query, err := applyPagingOptions(query, pagingOpts, outboxEventSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return constructsql.CollectRows[model.OutboxEvent](
+		qrbsql.Build(query).WithExecutor(executor).Query(ctx),
+	)
+}
+
+// FindDueOutboxEventsForUpdate fetches pending events that are due for delivery in the order they occurred.
+// The rows are locked until the end of the transaction, events locked by other dispatchers are skipped.
+func FindDueOutboxEventsForUpdate(ctx context.Context, executor qrbsql.Executor, now time.Time, limit int) ([]model.OutboxEvent, error) {
+	query := Select(outboxEventDefaultJson).
+		From(outboxEvent).
+		Where(And(
+			outboxEvent.Status.Eq(Arg(model.OutboxEventStatusPending)),
+			outboxEvent.NextAttemptAt.Lte(Arg(now)),
+		)).
+		OrderBy(outboxEvent.OccurredAt).
+		OrderBy(outboxEvent.ID).
+		Limit(Arg(limit)).
+		ForUpdate().SkipLocked()
+
+	return constructsql.CollectRows[model.OutboxEvent](
+		qrbsql.Build(query).WithExecutor(executor).Query(ctx),
+	)
+}
+
+func InsertOutboxEvent(ctx context.Context, executor qrbsql.Executor, changeSet OutboxEventChangeSet) error {
+	query := InsertInto(outboxEvent).
+		SetMap(changeSet.toMap())
+
+	_, err := qrbsql.Build(query).WithExecutor(executor).Exec(ctx)
+	return err
+}
+
+func UpdateOutboxEvent(ctx context.Context, executor qrbsql.Executor, id uuid.UUID, changeSet OutboxEventChangeSet) error {
+	query := Update(outboxEvent).
+		SetMap(changeSet.toMap()).
+		Where(outboxEvent.ID.Eq(Arg(id)))
+
+	return constructsql.AssertRowsAffected("update", 1)(
+		qrbsql.Build(query).WithExecutor(executor).Exec(ctx),
+	)
+}
+
+// RequeueDeadOutboxEvents sets dead events to pending again, so they are retried with a fresh number of attempts
+func RequeueDeadOutboxEvents(ctx context.Context, executor qrbsql.Executor, now time.Time) (int64, error) {
+	query := Update(outboxEvent).
+		Set("status", Arg(model.OutboxEventStatusPending)).
+		Set("attempts", Int(0)).
+		Set("next_attempt_at", Arg(now)).
+		Where(outboxEvent.Status.Eq(Arg(model.OutboxEventStatusDead)))
+
+	result, err := qrbsql.Build(query).WithExecutor(executor).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// DeleteOutboxEventsDeliveredBefore deletes delivered events, dead events are kept for inspection
+func DeleteOutboxEventsDeliveredBefore(ctx context.Context, executor qrbsql.Executor, before time.Time) (int64, error) {
+	query := DeleteFrom(outboxEvent).
+		Where(And(
+			outboxEvent.Status.Eq(Arg(model.OutboxEventStatusDelivered)),
+			outboxEvent.DeliveredAt.Lt(Arg(before)),
+		))
+
+	result, err := qrbsql.Build(query).WithExecutor(executor).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
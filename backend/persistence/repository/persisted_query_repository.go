@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/networkteam/construct/v2/constructsql"
+	. "github.com/networkteam/qrb"
+	"github.com/networkteam/qrb/qrbsql"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+)
+
+func FindAllPersistedQueries(ctx context.Context, executor qrbsql.Executor) ([]model.PersistedQuery, error) {
+	query := Select(persistedQueryDefaultJson).
+		From(persistedQuery).
+		OrderBy(persistedQuery.Hash)
+
+	return constructsql.CollectRows[model.PersistedQuery](
+		qrbsql.Build(query).WithExecutor(executor).Query(ctx),
+	)
+}
+
+// InsertPersistedQuery inserts a persisted query, an existing query with the same hash is left untouched
+func InsertPersistedQuery(ctx context.Context, executor qrbsql.Executor, changeSet PersistedQueryChangeSet) error {
+	q := InsertInto(persistedQuery).
+		SetMap(changeSet.toMap()).
+		OnConflict(N("hash")).DoNothing()
+
+	_, err := qrbsql.Build(q).WithExecutor(executor).Exec(ctx)
+	return err
+}
+
+func DeleteAllPersistedQueries(ctx context.Context, executor qrbsql.Executor) error {
+	q := DeleteFrom(persistedQuery)
+
+	_, err := qrbsql.Build(q).WithExecutor(executor).Exec(ctx)
+	return err
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/networkteam/construct/v2/constructsql"
+	. "github.com/networkteam/qrb"
+	"github.com/networkteam/qrb/fn"
+	"github.com/networkteam/qrb/qrbsql"
+)
+
+// FindReplicationLag gets the time a standby is behind its primary.
+// The lag is zero if the standby replayed all received changes or if the executor is not a standby.
+// It returns false if the lag is unknown (e.g. no transaction was replayed yet).
+func FindReplicationLag(ctx context.Context, executor qrbsql.Executor) (time.Duration, bool, error) {
+	query := Select(
+		Case().
+			When(Or(
+				Not(Func("pg_is_in_recovery")),
+				Func("pg_last_wal_receive_lsn").Eq(Func("pg_last_wal_replay_lsn")),
+			)).Then(Int(0)).
+			Else(Coalesce(
+				fn.Extract("EPOCH", Func("now").Minus(Func("pg_last_xact_replay_timestamp"))),
+				Int(-1),
+			)).
+			End(),
+	)
+
+	seconds, err := constructsql.ScanRow[float64](
+		qrbsql.Build(query).WithExecutor(executor).QueryRow(ctx),
+	)
+	if err != nil {
+		return 0, false, err
+	}
+	if seconds < 0 {
+		return 0, false, nil
+	}
+	return time.Duration(seconds * float64(time.Second)), true, nil
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/networkteam/construct/v2/constructsql"
+	. "github.com/networkteam/qrb"
+	"github.com/networkteam/qrb/builder"
+	"github.com/networkteam/qrb/fn"
+	"github.com/networkteam/qrb/qrbsql"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+)
+
+// searchConfig is the text search configuration of the generated search vector columns
+const searchConfig = "simple"
+
+//nolint:gochecknoglobals
+var (
+	accountSearchVector      = N("accounts.search_vector")
+	organisationSearchVector = N("organisations.search_vector")
+)
+
+// searchWords splits a search term into lower case words of letters and digits, like the parser of the search vectors
+func searchWords(term string) []string {
+	return strings.FieldsFunc(strings.ToLower(term), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// searchTSQuery matches all words of the search term as prefixes, so results are found while typing.
+// It returns nil if the search term has no words.
+func searchTSQuery(term string) builder.Exp {
+	words := searchWords(term)
+	if len(words) == 0 {
+		return nil
+	}
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return Func("to_tsquery", String(searchConfig), Arg(strings.Join(words, " & ")))
+}
+
+// accountSearchCondition matches accounts by words, a substring or similarity (e.g. typos) of the email address.
+// Words of the organisation name are matched if the organisation is joined.
+func accountSearchCondition(term string, includeOrganisation bool) builder.Exp {
+	conditions := []builder.Exp{
+		account.EmailAddress.ILike(Arg("%" + term + "%")),
+		account.EmailAddress.Op("%", Arg(term)),
+	}
+	if tsQuery := searchTSQuery(term); tsQuery != nil {
+		conditions = append(conditions, accountSearchVector.Op("@@", tsQuery))
+		if includeOrganisation {
+			conditions = append(conditions, organisationSearchVector.Op("@@", tsQuery))
+		}
+	}
+	return Or(conditions...)
+}
+
+func organisationSearchCondition(term string) builder.Exp {
+	tsQuery := searchTSQuery(term)
+	if tsQuery == nil {
+		return organisation.Name.ILike(Arg("%" + term + "%"))
+	}
+	return organisationSearchVector.Op("@@", tsQuery)
+}
+
+// searchRank combines the rank of the search vector with the similarity of the text,
+// both are between 0 and 1, so ranks of accounts and organisations can be compared
+func searchRank(searchVector, text builder.IdentExp, term string) builder.Exp {
+	similarity := Func("similarity", text, Arg(term))
+	tsQuery := searchTSQuery(term)
+	if tsQuery == nil {
+		return similarity
+	}
+	return Greatest(Func("ts_rank", searchVector, tsQuery), similarity)
+}
+
+func accountSearchRank(term string) builder.Exp {
+	return searchRank(accountSearchVector, account.EmailAddress, term)
+}
+
+func organisationSearchRank(term string) builder.Exp {
+	return searchRank(organisationSearchVector, organisation.Name, term)
+}
+
+// SearchAccounts finds accounts matching the search term of the filter ordered by relevance
+func SearchAccounts(ctx context.Context, executor qrbsql.Executor, filter AccountsFilter, limit int) ([]model.SearchResult, error) {
+	rank := accountSearchRank(filter.SearchTerm)
+
+	query := Select(fn.JsonBuildObject().
+		Prop("Account", buildAccountJSON(filter.Opts)).
+		Prop("Rank", rank).
+		Prop("Text", account.EmailAddress)).
+		From(account).
+		ApplyIf(filter.Opts != nil && filter.Opts.IncludeOrganisation, func(q builder.SelectBuilder) builder.SelectBuilder {
+			return q.LeftJoin(organisation).On(organisation.ID.Eq(account.OrganisationID))
+		}).
+		ApplyIf(true, applyAccountFilter(filter)).
+		OrderBy(rank).Desc().
+		OrderBy(account.ID).
+		Limit(Arg(limit))
+
+	results, err := constructsql.CollectRows[model.SearchResult](
+		qrbsql.Build(query).WithExecutor(executor).Query(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return highlightResults(results, filter.SearchTerm), nil
+}
+
+// SearchOrganisations finds organisations matching the search term of the filter ordered by relevance
+func SearchOrganisations(ctx context.Context, executor qrbsql.Executor, filter OrganisationsFilter, limit int) ([]model.SearchResult, error) {
+	rank := organisationSearchRank(filter.SearchTerm)
+
+	query := Select(fn.JsonBuildObject().
+		Prop("Organisation", buildOrganisationJSON(filter.Opts)).
+		Prop("Rank", rank).
+		Prop("Text", organisation.Name)).
+		From(organisation).
+		ApplyIf(true, applyOrganisationFilter(filter)).
+		OrderBy(rank).Desc().
+		OrderBy(organisation.ID).
+		Limit(Arg(limit))
+
+	results, err := constructsql.CollectRows[model.SearchResult](
+		qrbsql.Build(query).WithExecutor(executor).Query(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return highlightResults(results, filter.SearchTerm), nil
+}
+
+func highlightResults(results []model.SearchResult, term string) []model.SearchResult {
+	for i := range results {
+		results[i].Highlight = highlight(results[i].Text, term)
+	}
+	return results
+}
+
+// highlight escapes the text for HTML and wraps all occurrences of words of the search term in <b> tags (ignoring case)
+func highlight(text, term string) string {
+	words := searchWords(term)
+	if len(words) == 0 {
+		return html.EscapeString(text)
+	}
+	// Longer words first, so a word that is a prefix of another one does not take precedence
+	sort.Slice(words, func(i, j int) bool {
+		return len(words[i]) > len(words[j])
+	})
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+	pattern := regexp.MustCompile("(?i)" + strings.Join(words, "|"))
+
+	var sb strings.Builder
+	last := 0
+	for _, loc := range pattern.FindAllStringIndex(text, -1) {
+		sb.WriteString(html.EscapeString(text[last:loc[0]]))
+		sb.WriteString("<b>")
+		sb.WriteString(html.EscapeString(text[loc[0]:loc[1]]))
+		sb.WriteString("</b>")
+		last = loc[1]
+	}
+	sb.WriteString(html.EscapeString(text[last:]))
+	return sb.String()
+}
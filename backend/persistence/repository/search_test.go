@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlight(t *testing.T) {
+	tt := []struct {
+		name     string
+		text     string
+		term     string
+		expected string
+	}{
+		{
+			name:     "word",
+			text:     "Acme Inc.",
+			term:     "acme",
+			expected: "<b>Acme</b> Inc.",
+		},
+		{
+			name:     "multiple words",
+			text:     "Other Corp",
+			term:     "corp other",
+			expected: "<b>Other</b> <b>Corp</b>",
+		},
+		{
+			name:     "prefix of another word",
+			text:     "admin+acmeinc@example.com",
+			term:     "a acme",
+			expected: "<b>a</b>dmin+<b>acme</b>inc@ex<b>a</b>mple.com",
+		},
+		{
+			name:     "escaped text",
+			text:     "Smith & <Sons>",
+			term:     "sons",
+			expected: "Smith &amp; &lt;<b>Sons</b>&gt;",
+		},
+		{
+			name:     "without words",
+			text:     "Acme Inc.",
+			term:     "@",
+			expected: "Acme Inc.",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, highlight(tc.text, tc.term))
+		})
+	}
+}
+
+func TestSearchWords(t *testing.T) {
+	assert.Equal(t, []string{"admin", "acmeinc", "example", "com"}, searchWords("Admin+AcmeInc@example.com"))
+	assert.Empty(t, searchWords(" +@ "))
+}
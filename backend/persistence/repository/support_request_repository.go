@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/construct/v2/constructsql"
+	. "github.com/networkteam/qrb"
+	"github.com/networkteam/qrb/fn"
+	"github.com/networkteam/qrb/qrbsql"
+)
+
+// CountSupportRequestsSince counts the support requests of an account created at or after since
+func CountSupportRequestsSince(ctx context.Context, executor qrbsql.Executor, accountID uuid.UUID, since time.Time) (count int, err error) {
+	query := Select(fn.Count(N("*"))).
+		From(supportRequest).
+		Where(And(
+			supportRequest.AccountID.Eq(Arg(accountID)),
+			supportRequest.CreatedAt.Gte(Arg(since)),
+		))
+
+	return constructsql.ScanRow[int](
+		qrbsql.Build(query).WithExecutor(executor).QueryRow(ctx),
+	)
+}
+
+func InsertSupportRequest(ctx context.Context, executor qrbsql.Executor, changeSet SupportRequestChangeSet) error {
+	query := InsertInto(supportRequest).
+		SetMap(changeSet.toMap())
+
+	_, err := qrbsql.Build(query).WithExecutor(executor).Exec(ctx)
+	return err
+}
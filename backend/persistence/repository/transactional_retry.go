@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"math/rand"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+//nolint:revive // Better readability with underscores
+const (
+	pgErrCode_serialization_failure = "40001"
+	pgErrCode_deadlock_detected     = "40P01"
+)
+
+const (
+	DefaultRetryMaxAttempts = 3
+	DefaultRetryBaseDelay   = 10 * time.Millisecond
+	DefaultRetryMaxDelay    = 250 * time.Millisecond
+)
+
+const meterName = "myvendor.mytld/myproject/backend/persistence/repository"
+
+// RetryOpts configures the retries of TransactionalWithRetry, zero values are set to the defaults
+type RetryOpts struct {
+	// MaxAttempts is the number of attempts including the first one
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, it is doubled for every further retry up to MaxDelay
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// MeterProvider is used for the retry counter, the global provider is used if nil
+	MeterProvider metric.MeterProvider
+}
+
+func (o RetryOpts) withDefaults() RetryOpts {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultRetryMaxAttempts
+	}
+	if o.BaseDelay <= 0 {
+		o.BaseDelay = DefaultRetryBaseDelay
+	}
+	if o.MaxDelay <= 0 {
+		o.MaxDelay = DefaultRetryMaxDelay
+	}
+	if o.MeterProvider == nil {
+		o.MeterProvider = otel.GetMeterProvider()
+	}
+	return o
+}
+
+// TransactionalWithRetry runs f in a transaction like TransactionalWithOpts and runs it again in a new transaction
+// if it failed with a serialization failure or deadlock. Other errors are returned immediately.
+// Since f can be called multiple times, it must not have side effects outside the transaction.
+func TransactionalWithRetry(ctx context.Context, proxy TxBeginner, opts *sql.TxOptions, retryOpts RetryOpts, f func(tx *sql.Tx) error) error {
+	retryOpts = retryOpts.withDefaults()
+
+	for attempt := 1; ; attempt++ {
+		err := TransactionalWithOpts(ctx, proxy, opts, f)
+
+		code, retryable := retryableErrCode(err)
+		if !retryable || attempt >= retryOpts.MaxAttempts {
+			return err
+		}
+
+		retryCounter(retryOpts.MeterProvider).Add(ctx, 1, metric.WithAttributes(
+			attribute.String("db.sql_state", code),
+		))
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(retryDelay(retryOpts, attempt)):
+		}
+	}
+}
+
+// IsRetryableErr checks if the error is a serialization failure or deadlock, so the transaction can be retried
+func IsRetryableErr(err error) bool {
+	_, retryable := retryableErrCode(err)
+	return retryable
+}
+
+func retryableErrCode(err error) (string, bool) {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgErrCode_serialization_failure, pgErrCode_deadlock_detected:
+			return pgErr.Code, true
+		}
+	}
+	return "", false
+}
+
+// retryDelay is the exponential delay for the attempt with a random jitter of up to half the delay,
+// so conflicting transactions are not retried at the same time again
+func retryDelay(retryOpts RetryOpts, attempt int) time.Duration {
+	delay := retryOpts.BaseDelay
+	for i := 1; i < attempt && delay < retryOpts.MaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, retryOpts.MaxDelay)
+
+	half := int64(delay / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+func retryCounter(provider metric.MeterProvider) metric.Int64Counter {
+	// Instruments are cached by the provider, so getting the counter for every retry is cheap
+	counter, err := provider.Meter(meterName).Int64Counter(
+		"transactions.retries",
+		metric.WithDescription("Number of transactions retried after a serialization failure or deadlock."),
+		metric.WithUnit("{retry}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+	return counter
+}
@@ -0,0 +1,172 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	std_errors "errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+	test_telemetry "myvendor.mytld/myproject/backend/test/telemetry"
+)
+
+const (
+	meterScope          = "myvendor.mytld/myproject/backend/persistence/repository"
+	acmeOrganisationID  = "6330de58-2761-411e-a243-bec6d0c53876"
+	otherOrganisationID = "dba20d09-a3df-4975-9406-2fb6fd8f0940"
+)
+
+func TestTransactionalWithRetry_ConcurrentUpdate(t *testing.T) {
+	ctx := context.Background()
+	db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+
+	reader, meterProvider := test_telemetry.SetupTestMeter(t)
+
+	snapshotTaken := make(chan struct{})
+	otherCommitted := make(chan struct{})
+	errs := make(chan error, 1)
+	var attempts int
+
+	go func() {
+		errs <- repository.TransactionalWithRetry(ctx, db, &sql.TxOptions{Isolation: sql.LevelRepeatableRead}, repository.RetryOpts{
+			MeterProvider: meterProvider,
+		}, func(tx *sql.Tx) error {
+			attempts++
+
+			var name string
+			err := tx.QueryRowContext(ctx, "SELECT name FROM organisations WHERE organisation_id = $1", acmeOrganisationID).Scan(&name)
+			if err != nil {
+				return err
+			}
+			// Let another transaction update the row after the snapshot of the first attempt was taken
+			if attempts == 1 {
+				close(snapshotTaken)
+				<-otherCommitted
+			}
+
+			_, err = tx.ExecContext(ctx, "UPDATE organisations SET name = $1 WHERE organisation_id = $2", name+" (updated)", acmeOrganisationID)
+			return err
+		})
+	}()
+
+	<-snapshotTaken
+	_, err := db.ExecContext(ctx, "UPDATE organisations SET name = 'Acme Corp.' WHERE organisation_id = $1", acmeOrganisationID)
+	require.NoError(t, err)
+	close(otherCommitted)
+
+	require.NoError(t, <-errs)
+	assert.Equal(t, 2, attempts)
+
+	var name string
+	err = db.QueryRowContext(ctx, "SELECT name FROM organisations WHERE organisation_id = $1", acmeOrganisationID).Scan(&name)
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Corp. (updated)", name, "retry should read the committed update")
+
+	test_telemetry.AssertMeterCounter(t, reader, meterScope, "transactions.retries", 1)
+}
+
+func TestTransactionalWithRetry_Deadlock(t *testing.T) {
+	ctx := context.Background()
+	db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+
+	reader, meterProvider := test_telemetry.SetupTestMeter(t)
+
+	// Both transactions lock their first row before updating the row of the other one
+	var locked sync.WaitGroup
+	locked.Add(2)
+
+	updateBoth := func(firstID, secondID string) error {
+		var attempts int
+		return repository.TransactionalWithRetry(ctx, db, nil, repository.RetryOpts{
+			MeterProvider: meterProvider,
+		}, func(tx *sql.Tx) error {
+			attempts++
+
+			_, err := tx.ExecContext(ctx, "UPDATE organisations SET name = name || '.' WHERE organisation_id = $1", firstID)
+			if err != nil {
+				return err
+			}
+			if attempts == 1 {
+				locked.Done()
+				locked.Wait()
+			}
+			_, err = tx.ExecContext(ctx, "UPDATE organisations SET name = name || '.' WHERE organisation_id = $1", secondID)
+			return err
+		})
+	}
+
+	errs := make(chan error, 2)
+	go func() { errs <- updateBoth(acmeOrganisationID, otherOrganisationID) }()
+	go func() { errs <- updateBoth(otherOrganisationID, acmeOrganisationID) }()
+
+	require.NoError(t, <-errs)
+	require.NoError(t, <-errs)
+
+	var names []string
+	rows, err := db.QueryContext(ctx, "SELECT name FROM organisations ORDER BY name")
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		names = append(names, name)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"Acme Inc...", "Other Corp.."}, names)
+
+	test_telemetry.AssertMeterCounter(t, reader, meterScope, "transactions.retries", 1)
+}
+
+func TestTransactionalWithRetry_Errors(t *testing.T) {
+	tt := []struct {
+		name             string
+		err              error
+		expectedAttempts int
+	}{
+		{
+			name:             "serialization failure",
+			err:              &pgconn.PgError{Code: "40001"},
+			expectedAttempts: 3,
+		},
+		{
+			name:             "deadlock",
+			err:              &pgconn.PgError{Code: "40P01"},
+			expectedAttempts: 3,
+		},
+		{
+			name:             "unique violation",
+			err:              &pgconn.PgError{Code: "23505"},
+			expectedAttempts: 1,
+		},
+		{
+			name:             "other error",
+			err:              std_errors.New("something failed"),
+			expectedAttempts: 1,
+		},
+	}
+
+	db := test_db.CreateTestDatabase(t)
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts int
+			err := repository.TransactionalWithRetry(context.Background(), db, nil, repository.RetryOpts{
+				MaxAttempts: 3,
+				BaseDelay:   time.Millisecond,
+			}, func(tx *sql.Tx) error {
+				attempts++
+				return tc.err
+			})
+
+			require.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expectedAttempts, attempts)
+		})
+	}
+}
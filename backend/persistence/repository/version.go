@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/networkteam/construct/v2/constructsql"
+
+	"myvendor.mytld/myproject/backend/domain/types"
+)
+
+// ErrVersionConflict is returned by conditional updates if the row was changed since the expected version was read
+var ErrVersionConflict = types.FieldError{
+	Field: "expectedVersion",
+	Code:  types.ErrorCodeConflict,
+}
+
+// assertUpdatedAtVersion checks that an update affected exactly one row.
+// If an expected version was given, a missing row is reported as a version conflict, so callers must check existence before.
+func assertUpdatedAtVersion(expectedVersion *int) func(sql.Result, error) error {
+	assertUpdated := constructsql.AssertRowsAffected("update", 1)
+	if expectedVersion == nil {
+		return assertUpdated
+	}
+	return func(result sql.Result, err error) error {
+		if err != nil {
+			return err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrVersionConflict
+		}
+		return assertUpdated(result, nil)
+	}
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/construct/v2/constructsql"
+	. "github.com/networkteam/qrb"
+	"github.com/networkteam/qrb/builder"
+	"github.com/networkteam/qrb/fn"
+	"github.com/networkteam/qrb/qrbsql"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+)
+
+type WebhookEndpointsFilter struct {
+	OrganisationID *uuid.UUID
+	// EventType only returns endpoints that are subscribed to the event type
+	EventType *string
+}
+
+func FindWebhookEndpointByID(ctx context.Context, executor qrbsql.Executor, id uuid.UUID) (model.WebhookEndpoint, error) {
+	query := Select(webhookEndpointDefaultJson).
+		From(webhookEndpoint).
+		Where(webhookEndpoint.ID.Eq(Arg(id)))
+
+	return constructsql.ScanRow[model.WebhookEndpoint](
+		qrbsql.Build(query).WithExecutor(executor).QueryRow(ctx),
+	)
+}
+
+func FindAllWebhookEndpoints(ctx context.Context, executor qrbsql.Executor, filter WebhookEndpointsFilter, pagingOpts ...PagingOption) ([]model.WebhookEndpoint, error) {
+	query := Select(webhookEndpointDefaultJson).
+		From(webhookEndpoint).
+		ApplyIf(filter.OrganisationID != nil, func(q builder.SelectBuilder) builder.SelectBuilder {
+			return q.Where(webhookEndpoint.OrganisationID.Eq(Arg(*filter.OrganisationID)))
+		}).
+		ApplyIf(filter.EventType != nil, func(q builder.SelectBuilder) builder.SelectBuilder {
+			return q.Where(Arg(*filter.EventType).Eq(Any(webhookEndpoint.EventTypes)))
+		})
+
+	query, err := applyPagingOptions(query, pagingOpts, webhookEndpointSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return constructsql.CollectRows[model.WebhookEndpoint](
+		qrbsql.Build(query).WithExecutor(executor).Query(ctx),
+	)
+}
+
+func InsertWebhookEndpoint(ctx context.Context, executor qrbsql.Executor, changeSet WebhookEndpointChangeSet) error {
+	query := InsertInto(webhookEndpoint).
+		SetMap(changeSet.toMap())
+
+	_, err := qrbsql.Build(query).WithExecutor(executor).Exec(ctx)
+	return err
+}
+
+func UpdateWebhookEndpoint(ctx context.Context, executor qrbsql.Executor, id uuid.UUID, changeSet WebhookEndpointChangeSet) error {
+	query := Update(webhookEndpoint).
+		SetMap(changeSet.toMap()).
+		Where(webhookEndpoint.ID.Eq(Arg(id)))
+
+	return constructsql.AssertRowsAffected("update", 1)(
+		qrbsql.Build(query).WithExecutor(executor).Exec(ctx),
+	)
+}
+
+// DeleteWebhookEndpoint deletes an endpoint together with its deliveries
+func DeleteWebhookEndpoint(ctx context.Context, executor qrbsql.Executor, id uuid.UUID) error {
+	query := DeleteFrom(webhookEndpoint).
+		Where(webhookEndpoint.ID.Eq(Arg(id)))
+
+	return constructsql.AssertRowsAffected("delete", 1)(
+		qrbsql.Build(query).WithExecutor(executor).Exec(ctx),
+	)
+}
+
+type WebhookDeliveriesFilter struct {
+	OrganisationID    *uuid.UUID
+	WebhookEndpointID *uuid.UUID
+	Status            *model.WebhookDeliveryStatus
+}
+
+func FindWebhookDeliveryByID(ctx context.Context, executor qrbsql.Executor, id uuid.UUID) (model.WebhookDelivery, error) {
+	query := Select(webhookDeliveryDefaultJson).
+		From(webhookDelivery).
+		Where(webhookDelivery.ID.Eq(Arg(id)))
+
+	return constructsql.ScanRow[model.WebhookDelivery](
+		qrbsql.Build(query).WithExecutor(executor).QueryRow(ctx),
+	)
+}
+
+func applyWebhookDeliveryFilter(filter WebhookDeliveriesFilter) func(q builder.SelectBuilder) builder.SelectBuilder {
+	return func(q builder.SelectBuilder) builder.SelectBuilder {
+		return q.
+			ApplyIf(filter.OrganisationID != nil, func(q builder.SelectBuilder) builder.SelectBuilder {
+				return q.Where(webhookDelivery.OrganisationID.Eq(Arg(*filter.OrganisationID)))
+			}).
+			ApplyIf(filter.WebhookEndpointID != nil, func(q builder.SelectBuilder) builder.SelectBuilder {
+				return q.Where(webhookDelivery.WebhookEndpointID.Eq(Arg(*filter.WebhookEndpointID)))
+			}).
+			ApplyIf(filter.Status != nil, func(q builder.SelectBuilder) builder.SelectBuilder {
+				return q.Where(webhookDelivery.Status.Eq(Arg(*filter.Status)))
+			})
+	}
+}
+
+func FindAllWebhookDeliveries(ctx context.Context, executor qrbsql.Executor, filter WebhookDeliveriesFilter, pagingOpts ...PagingOption) ([]model.WebhookDelivery, error) {
+	query := Select(webhookDeliveryDefaultJson).
+		From(webhookDelivery).
+		ApplyIf(true, applyWebhookDeliveryFilter(filter))
+
+	query, err := applyPagingOptions(query, pagingOpts, webhookDeliverySortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	return constructsql.CollectRows[model.WebhookDelivery](
+		qrbsql.Build(query).WithExecutor(executor).Query(ctx),
+	)
+}
+
+func CountWebhookDeliveries(ctx context.Context, executor qrbsql.Executor, filter WebhookDeliveriesFilter) (count int, err error) {
+	query := Select(fn.Count(N("*"))).
+		From(webhookDelivery).
+		ApplyIf(true, applyWebhookDeliveryFilter(filter))
+
+	return constructsql.ScanRow[int](
+		qrbsql.Build(query).WithExecutor(executor).QueryRow(ctx),
+	)
+}
+
+// InsertWebhookDelivery inserts a delivery if the event was not already delivered to the endpoint.
+// It returns false if the delivery exists, e.g. because the outbox event is delivered again after a failure.
+func InsertWebhookDelivery(ctx context.Context, executor qrbsql.Executor, changeSet WebhookDeliveryChangeSet) (inserted bool, err error) {
+	query := InsertInto(webhookDelivery).
+		SetMap(changeSet.toMap()).
+		OnConflict().DoNothing()
+
+	result, err := qrbsql.Build(query).WithExecutor(executor).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func UpdateWebhookDelivery(ctx context.Context, executor qrbsql.Executor, id uuid.UUID, changeSet WebhookDeliveryChangeSet) error {
+	query := Update(webhookDelivery).
+		SetMap(changeSet.toMap()).
+		Where(webhookDelivery.ID.Eq(Arg(id)))
+
+	return constructsql.AssertRowsAffected("update", 1)(
+		qrbsql.Build(query).WithExecutor(executor).Exec(ctx),
+	)
+}
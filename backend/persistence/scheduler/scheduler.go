@@ -0,0 +1,468 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	std_errors "errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	logger "github.com/apex/log"
+	"github.com/friendsofgo/errors"
+	"github.com/getsentry/sentry-go"
+	"github.com/robfig/cron"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+const (
+	defaultPollInterval     = 5 * time.Second
+	defaultMisfireThreshold = time.Minute
+)
+
+var (
+	ErrUnknownJob = std_errors.New("unknown cron job")
+	// ErrJobRunning is returned if a job is triggered while it is running on any instance
+	ErrJobRunning = std_errors.New("cron job is running")
+)
+
+// Func is the function of a cron job
+type Func func(ctx context.Context) error
+
+// CatchUpPolicy defines what happens with runs that were missed, e.g. because no instance was running
+type CatchUpPolicy string
+
+const (
+	// CatchUpSkip skips missed runs and waits for the next scheduled run
+	CatchUpSkip CatchUpPolicy = "skip"
+	// CatchUpOnce runs a job once, no matter how many runs were missed
+	CatchUpOnce CatchUpPolicy = "once"
+)
+
+type job struct {
+	name     string
+	spec     string
+	schedule cron.Schedule
+	fn       Func
+	catchUp  CatchUpPolicy
+}
+
+type JobOption func(j *job)
+
+// WithCatchUp sets the policy for missed runs, the default is CatchUpSkip
+func WithCatchUp(policy CatchUpPolicy) JobOption {
+	return func(j *job) {
+		j.catchUp = policy
+	}
+}
+
+// Scheduler runs cron jobs once per schedule across all instances.
+// The state of the jobs is stored in the database and every run is guarded by an advisory lock per job.
+type Scheduler struct {
+	db         *sql.DB
+	timeSource types.TimeSource
+
+	pollInterval     time.Duration
+	misfireThreshold time.Duration
+
+	mu   sync.Mutex
+	jobs []*job
+	// running tracks jobs that run in this instance, so they are not started twice
+	running map[string]struct{}
+}
+
+type Option func(s *Scheduler)
+
+// WithPollInterval sets the interval for checking for due jobs
+func WithPollInterval(interval time.Duration) Option {
+	return func(s *Scheduler) {
+		s.pollInterval = interval
+	}
+}
+
+// WithMisfireThreshold sets how late a run can start before it counts as missed and the catch-up policy applies
+func WithMisfireThreshold(threshold time.Duration) Option {
+	return func(s *Scheduler) {
+		s.misfireThreshold = threshold
+	}
+}
+
+func New(db *sql.DB, timeSource types.TimeSource, opts ...Option) *Scheduler {
+	s := &Scheduler{
+		db:               db,
+		timeSource:       timeSource,
+		pollInterval:     defaultPollInterval,
+		misfireThreshold: defaultMisfireThreshold,
+		running:          make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Register adds a job with a standard cron spec (e.g. "0 3 * * *" or "@hourly").
+// The name identifies the job across instances and deployments.
+func (s *Scheduler) Register(name, spec string, fn Func, opts ...JobOption) error {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return errors.Wrapf(err, "parsing schedule of %s", name)
+	}
+
+	j := &job{
+		name:     name,
+		spec:     spec,
+		schedule: schedule,
+		fn:       fn,
+		catchUp:  CatchUpSkip,
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.jobs {
+		if existing.name == name {
+			return errors.Errorf("cron job %s already registered", name)
+		}
+	}
+	s.jobs = append(s.jobs, j)
+	return nil
+}
+
+// Sync stores the registered jobs in the database.
+// If the schedule of a job changed, the next run is calculated from the new schedule.
+func (s *Scheduler) Sync(ctx context.Context) error {
+	now := s.timeSource.Now()
+
+	return repository.Transactional(ctx, s.db, func(tx *sql.Tx) error {
+		for _, j := range s.registeredJobs() {
+			nextRunAt := j.schedule.Next(now)
+			err := repository.InsertCronJob(ctx, tx, repository.CronJobChangeSet{
+				Name:      &j.name,
+				Schedule:  &j.spec,
+				NextRunAt: &nextRunAt,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "inserting cron job %s", j.name)
+			}
+
+			state, err := repository.FindCronJobByName(ctx, tx, j.name)
+			if err != nil {
+				return errors.Wrapf(err, "finding cron job %s", j.name)
+			}
+			if state.Schedule != j.spec {
+				err = repository.UpdateCronJob(ctx, tx, j.name, repository.CronJobChangeSet{
+					Schedule:  &j.spec,
+					NextRunAt: &nextRunAt,
+				})
+				if err != nil {
+					return errors.Wrapf(err, "updating cron job %s", j.name)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// List returns the state of all registered jobs.
+// Jobs that were not synced yet are returned with the next run calculated from now.
+func (s *Scheduler) List(ctx context.Context) ([]model.CronJob, error) {
+	states, err := repository.FindAllCronJobs(ctx, s.db)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding cron jobs")
+	}
+	statesByName := make(map[string]model.CronJob, len(states))
+	for _, state := range states {
+		statesByName[state.Name] = state
+	}
+
+	jobs := s.registeredJobs()
+	result := make([]model.CronJob, len(jobs))
+	for i, j := range jobs {
+		state, ok := statesByName[j.name]
+		if !ok {
+			state = model.CronJob{
+				Name:      j.name,
+				Schedule:  j.spec,
+				NextRunAt: j.schedule.Next(s.timeSource.Now()),
+			}
+		}
+		result[i] = state
+	}
+	return result, nil
+}
+
+// Run syncs the jobs and runs due jobs until the context is cancelled.
+// It returns after running jobs are finished.
+func (s *Scheduler) Run(ctx context.Context) error {
+	log := logger.FromContext(ctx).
+		WithField("component", "scheduler")
+
+	if err := s.Sync(ctx); err != nil {
+		return errors.Wrap(err, "syncing cron jobs")
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		states, err := repository.FindAllCronJobs(ctx, s.db)
+		if err != nil && ctx.Err() == nil {
+			log.WithError(err).Error("Could not find cron jobs")
+		}
+
+		now := s.timeSource.Now()
+		for _, state := range states {
+			if state.NextRunAt.After(now) {
+				continue
+			}
+			j, ok := s.startRunning(state.Name)
+			if !ok {
+				continue
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer s.stopRunning(j.name)
+
+				if _, err := s.runDue(ctx, j); err != nil && ctx.Err() == nil {
+					log.WithError(err).WithField("cronJob", j.name).Error("Could not run cron job")
+				}
+			}()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue runs a job if it is due and not running on another instance.
+// It returns true if the job was run.
+func (s *Scheduler) RunDue(ctx context.Context, name string) (bool, error) {
+	j, ok := s.startRunning(name)
+	if !ok {
+		if _, registered := s.findJob(name); !registered {
+			return false, ErrUnknownJob
+		}
+		return false, nil
+	}
+	defer s.stopRunning(name)
+
+	return s.runDue(ctx, j)
+}
+
+// Trigger runs a job immediately, regardless of its schedule, and returns the error of the job.
+// The next scheduled run is not changed.
+func (s *Scheduler) Trigger(ctx context.Context, name string) error {
+	j, ok := s.findJob(name)
+	if !ok {
+		return ErrUnknownJob
+	}
+
+	// Make sure the state exists, so the outcome can be recorded
+	if err := s.Sync(ctx); err != nil {
+		return errors.Wrap(err, "syncing cron jobs")
+	}
+
+	var runErr error
+	ran, err := s.withLock(ctx, j, func(conn *sql.Conn) (err error) {
+		runErr, err = s.run(ctx, conn, j, false)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if !ran {
+		return ErrJobRunning
+	}
+	return runErr
+}
+
+func (s *Scheduler) runDue(ctx context.Context, j *job) (bool, error) {
+	var ran bool
+	_, err := s.withLock(ctx, j, func(conn *sql.Conn) error {
+		// The state must be read after acquiring the lock, another instance could have run the job in the meantime
+		state, err := repository.FindCronJobByName(ctx, conn, j.name)
+		if err != nil {
+			return errors.Wrap(err, "finding cron job")
+		}
+
+		now := s.timeSource.Now()
+		if state.NextRunAt.After(now) {
+			return nil
+		}
+
+		if now.Sub(state.NextRunAt) > s.misfireThreshold && j.catchUp == CatchUpSkip {
+			nextRunAt := j.schedule.Next(now)
+			logger.FromContext(ctx).
+				WithField("component", "scheduler").
+				WithField("cronJob", j.name).
+				WithField("missedRunAt", state.NextRunAt).
+				WithField("nextRunAt", nextRunAt).
+				Info("Skipping missed cron job run")
+
+			return repository.UpdateCronJob(ctx, conn, j.name, repository.CronJobChangeSet{
+				NextRunAt: &nextRunAt,
+			})
+		}
+
+		ran = true
+		// Failed runs are recorded and logged, the next scheduled run is a retry
+		_, err = s.run(ctx, conn, j, true)
+		return err
+	})
+	return ran, err
+}
+
+// withLock calls f with a connection that holds the advisory lock of the job.
+// It returns false if the lock is held by another session.
+func (s *Scheduler) withLock(ctx context.Context, j *job, f func(conn *sql.Conn) error) (locked bool, err error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "getting connection")
+	}
+	defer conn.Close()
+
+	key := lockKey(j.name)
+	locked, err = repository.TryAdvisoryLock(ctx, conn, key)
+	if err != nil {
+		return false, errors.Wrap(err, "acquiring advisory lock")
+	}
+	if !locked {
+		return false, nil
+	}
+	defer func() {
+		unlockErr := repository.AdvisoryUnlock(context.WithoutCancel(ctx), conn, key)
+		if unlockErr != nil {
+			err = std_errors.Join(err, errors.Wrap(unlockErr, "releasing advisory lock"))
+		}
+	}()
+
+	return true, f(conn)
+}
+
+// run calls the job function and records the outcome.
+// Scheduled runs also advance the next run, based on the start of the run.
+// It returns the error of the job function separately from errors recording the outcome.
+func (s *Scheduler) run(ctx context.Context, conn *sql.Conn, j *job, scheduled bool) (runErr error, err error) {
+	log := logger.FromContext(ctx).
+		WithField("component", "scheduler").
+		WithField("cronJob", j.name)
+
+	startedAt := s.timeSource.Now()
+	log.Debug("Running cron job")
+
+	runErr = callJob(ctx, j)
+
+	finishedAt := s.timeSource.Now()
+	status := model.CronRunStatusSucceeded
+	var lastError *string
+	if runErr != nil {
+		status = model.CronRunStatusFailed
+		msg := runErr.Error()
+		lastError = &msg
+		log.WithError(runErr).Error("Cron job failed")
+	} else {
+		log.
+			WithField("duration", finishedAt.Sub(startedAt)).
+			Info("Cron job finished")
+	}
+
+	startedAtPtr := &startedAt
+	finishedAtPtr := &finishedAt
+	statusPtr := &status
+	changeSet := repository.CronJobChangeSet{
+		LastRunAt:      &startedAtPtr,
+		LastFinishedAt: &finishedAtPtr,
+		LastStatus:     &statusPtr,
+		LastError:      &lastError,
+	}
+	if scheduled {
+		nextRunAt := j.schedule.Next(startedAt)
+		changeSet.NextRunAt = &nextRunAt
+	}
+
+	// The outcome must be recorded even if the scheduler is stopped
+	err = repository.UpdateCronJob(context.WithoutCancel(ctx), conn, j.name, changeSet)
+	if err != nil {
+		return runErr, errors.Wrap(err, "updating cron job")
+	}
+	return runErr, nil
+}
+
+func callJob(ctx context.Context, j *job) (err error) {
+	// Build a goroutine local Sentry hub for the run
+	hub := sentry.CurrentHub().Clone()
+	hub.Scope().SetTag("section", "cron")
+	hub.Scope().SetTag("cronJob", j.name)
+	ctx = sentry.SetHubOnContext(ctx, hub)
+
+	defer func() {
+		if r := recover(); r != nil {
+			hub.Recover(r)
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	err = j.fn(ctx)
+	if err != nil {
+		hub.CaptureException(err)
+	}
+	return err
+}
+
+func (s *Scheduler) registeredJobs() []*job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*job(nil), s.jobs...)
+}
+
+func (s *Scheduler) findJob(name string) (*job, bool) {
+	for _, j := range s.registeredJobs() {
+		if j.name == name {
+			return j, true
+		}
+	}
+	return nil, false
+}
+
+func (s *Scheduler) startRunning(name string) (*job, bool) {
+	j, ok := s.findJob(name)
+	if !ok {
+		return nil, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, running := s.running[name]; running {
+		return nil, false
+	}
+	s.running[name] = struct{}{}
+	return j, true
+}
+
+func (s *Scheduler) stopRunning(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, name)
+}
+
+// lockKey derives the advisory lock key from the job name
+func lockKey(name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("cron:" + name))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,184 @@
+package scheduler_test
+
+import (
+	"context"
+	std_errors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/persistence/scheduler"
+	"myvendor.mytld/myproject/backend/test"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+)
+
+func TestScheduler_RunDue(t *testing.T) {
+	ctx := context.Background()
+	db := test_db.CreateTestDatabase(t)
+	timeSource := test.NewSettableTimeSource(time.Date(2024, 3, 1, 10, 2, 0, 0, time.UTC))
+
+	var runs int
+	newScheduler := func() *scheduler.Scheduler {
+		s := scheduler.New(db, timeSource)
+		err := s.Register("report", "*/10 * * * *", func(context.Context) error {
+			runs++
+			return nil
+		})
+		require.NoError(t, err)
+		require.NoError(t, s.Sync(ctx))
+		return s
+	}
+	// Two schedulers simulate two instances
+	instance1 := newScheduler()
+	instance2 := newScheduler()
+
+	state, err := repository.FindCronJobByName(ctx, db, "report")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 10, 0, 0, time.UTC), state.NextRunAt.UTC())
+
+	ran, err := instance1.RunDue(ctx, "report")
+	require.NoError(t, err)
+	assert.False(t, ran, "job should not run before it is due")
+
+	timeSource.Set(time.Date(2024, 3, 1, 10, 10, 5, 0, time.UTC))
+
+	ran, err = instance1.RunDue(ctx, "report")
+	require.NoError(t, err)
+	assert.True(t, ran)
+
+	ran, err = instance2.RunDue(ctx, "report")
+	require.NoError(t, err)
+	assert.False(t, ran, "job should only run once per schedule")
+
+	assert.Equal(t, 1, runs)
+
+	state, err = repository.FindCronJobByName(ctx, db, "report")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 20, 0, 0, time.UTC), state.NextRunAt.UTC())
+	require.NotNil(t, state.LastStatus)
+	assert.Equal(t, model.CronRunStatusSucceeded, *state.LastStatus)
+	require.NotNil(t, state.LastRunAt)
+	assert.Equal(t, timeSource.Now(), state.LastRunAt.UTC())
+
+	_, err = instance1.RunDue(ctx, "unknown")
+	require.ErrorIs(t, err, scheduler.ErrUnknownJob)
+}
+
+func TestScheduler_RunDue_Locked(t *testing.T) {
+	ctx := context.Background()
+	db := test_db.CreateTestDatabase(t)
+	timeSource := test.NewSettableTimeSource(time.Date(2024, 3, 1, 10, 2, 0, 0, time.UTC))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	s := scheduler.New(db, timeSource)
+	err := s.Register("report", "*/10 * * * *", func(context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Sync(ctx))
+
+	timeSource.Set(time.Date(2024, 3, 1, 10, 10, 0, 0, time.UTC))
+
+	done := make(chan error)
+	go func() {
+		_, err := s.RunDue(ctx, "report")
+		done <- err
+	}()
+	<-started
+
+	// Another instance cannot trigger the job while it is running
+	other := scheduler.New(db, timeSource)
+	err = other.Register("report", "*/10 * * * *", func(context.Context) error {
+		t.Error("job should not run concurrently")
+		return nil
+	})
+	require.NoError(t, err)
+	err = other.Trigger(ctx, "report")
+	require.ErrorIs(t, err, scheduler.ErrJobRunning)
+
+	close(release)
+	require.NoError(t, <-done)
+}
+
+func TestScheduler_CatchUp(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       scheduler.CatchUpPolicy
+		expectedRuns int
+	}{
+		{
+			name:         "skip",
+			policy:       scheduler.CatchUpSkip,
+			expectedRuns: 0,
+		},
+		{
+			name:         "once",
+			policy:       scheduler.CatchUpOnce,
+			expectedRuns: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := test_db.CreateTestDatabase(t)
+			timeSource := test.NewSettableTimeSource(time.Date(2024, 3, 1, 10, 2, 0, 0, time.UTC))
+
+			var runs int
+			s := scheduler.New(db, timeSource)
+			err := s.Register("report", "@hourly", func(context.Context) error {
+				runs++
+				return nil
+			}, scheduler.WithCatchUp(tt.policy))
+			require.NoError(t, err)
+			require.NoError(t, s.Sync(ctx))
+
+			// No instance was running for several hours
+			timeSource.Set(time.Date(2024, 3, 1, 15, 30, 0, 0, time.UTC))
+
+			_, err = s.RunDue(ctx, "report")
+			require.NoError(t, err)
+			_, err = s.RunDue(ctx, "report")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedRuns, runs)
+
+			state, err := repository.FindCronJobByName(ctx, db, "report")
+			require.NoError(t, err)
+			assert.Equal(t, time.Date(2024, 3, 1, 16, 0, 0, 0, time.UTC), state.NextRunAt.UTC())
+		})
+	}
+}
+
+func TestScheduler_Trigger(t *testing.T) {
+	ctx := context.Background()
+	db := test_db.CreateTestDatabase(t)
+	timeSource := test.NewSettableTimeSource(time.Date(2024, 3, 1, 10, 2, 0, 0, time.UTC))
+
+	s := scheduler.New(db, timeSource)
+	err := s.Register("report", "@daily", func(context.Context) error {
+		return std_errors.New("report service unavailable")
+	})
+	require.NoError(t, err)
+
+	err = s.Trigger(ctx, "report")
+	require.EqualError(t, err, "report service unavailable")
+
+	jobs, err := s.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), jobs[0].NextRunAt.UTC(), "next run should not change")
+	require.NotNil(t, jobs[0].LastStatus)
+	assert.Equal(t, model.CronRunStatusFailed, *jobs[0].LastStatus)
+	require.NotNil(t, jobs[0].LastError)
+	assert.Equal(t, "report service unavailable", *jobs[0].LastError)
+
+	err = s.Trigger(ctx, "unknown")
+	require.ErrorIs(t, err, scheduler.ErrUnknownJob)
+}
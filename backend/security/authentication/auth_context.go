@@ -41,6 +41,9 @@ type AuthContext struct {
 	Secret                    []byte
 	IssuedAt                  time.Time
 	Expiry                    time.Time
+
+	// ImpersonatorAccountID is set if another account acts as AccountID, it is recorded in the audit log
+	ImpersonatorAccountID uuid.NullUUID
 }
 
 func (authCtx AuthContext) Fields() log.Fields {
@@ -118,6 +118,16 @@ func setOrganisationID(query OrganisationIDSetter) authorizationCheck {
 	}
 }
 
+// requireWithoutDeleted denies queries for soft deleted records
+func requireWithoutDeleted(withDeleted bool) authorizationCheck {
+	return func(_ authentication.AuthContext) error {
+		if withDeleted {
+			return authorizationError{cause: "deleted records not allowed"}
+		}
+		return nil
+	}
+}
+
 func uuidOrNil(id uuid.NullUUID) *uuid.UUID {
 	if id.Valid {
 		return &id.UUID
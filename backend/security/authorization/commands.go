@@ -1,11 +1,55 @@
 package authorization
 
 import (
+	"fmt"
+
 	"myvendor.mytld/myproject/backend/domain/command"
 	"myvendor.mytld/myproject/backend/domain/types"
 	"myvendor.mytld/myproject/backend/security/authentication"
 )
 
+// AllowsCmd checks a command that is handled by the command bus with the check of its type.
+// Commands without a check are not allowed, so a new command cannot be handled before it is authorized.
+func (a *Authorizer) AllowsCmd(cmd any) error {
+	switch cmd := cmd.(type) {
+	case command.LoginCmd:
+		// Login is allowed without authentication, the credentials are checked by the handler
+		return nil
+	case command.AccountCreateCmd:
+		return a.AllowsAccountCreateCmd(cmd)
+	case command.AccountUpdateCmd:
+		return a.AllowsAccountUpdateCmd(cmd)
+	case command.AccountDeleteCmd:
+		return a.AllowsAccountDeleteCmd(cmd)
+	case command.AccountRestoreCmd:
+		return a.AllowsAccountRestoreCmd(cmd)
+	case command.OrganisationCreateCmd:
+		return a.AllowsOrganisationCreateCmd(cmd)
+	case command.OrganisationUpdateCmd:
+		return a.AllowsOrganisationUpdateCmd(cmd)
+	case command.OrganisationDeleteCmd:
+		return a.AllowsOrganisationDeleteCmd(cmd)
+	case command.OrganisationRestoreCmd:
+		return a.AllowsOrganisationRestoreCmd(cmd)
+	case command.AssetCreateCmd:
+		return a.AllowsAssetCreateCmd(cmd)
+	case command.AssetDeleteCmd:
+		return a.AllowsAssetDeleteCmd(cmd)
+	case command.SupportRequestSubmitCmd:
+		return a.AllowsSupportRequestSubmitCmd(cmd)
+	case command.WebhookEndpointCreateCmd:
+		return a.AllowsWebhookEndpointCreateCmd(cmd)
+	case command.WebhookEndpointUpdateCmd:
+		return a.AllowsWebhookEndpointUpdateCmd(cmd)
+	case command.WebhookEndpointDeleteCmd:
+		return a.AllowsWebhookEndpointDeleteCmd(cmd)
+	case command.WebhookRedeliverCmd:
+		return a.AllowsWebhookRedeliverCmd(cmd)
+	default:
+		return authorizationError{cause: fmt.Sprintf("no authorization check for %T", cmd)}
+	}
+}
+
 func (a *Authorizer) AllowsAccountCreateCmd(cmd command.AccountCreateCmd) error {
 	return a.check(
 		satisfyAny(
@@ -55,6 +99,18 @@ func (a *Authorizer) AllowsAccountDeleteCmd(cmd command.AccountDeleteCmd) error
 	)
 }
 
+func (a *Authorizer) AllowsAccountRestoreCmd(cmd command.AccountRestoreCmd) error {
+	return a.check(
+		requireAll(
+			requireNotSameAccount(&cmd.AccountID),
+			satisfyAny(
+				requireRole(types.RoleSystemAdministrator),
+				requireSameOrganisationAdministrator(uuidOrNil(cmd.OrganisationID)),
+			),
+		),
+	)
+}
+
 func (a *Authorizer) AllowsOrganisationCreateCmd(command.OrganisationCreateCmd) error {
 	return a.check(
 		requireRole(types.RoleSystemAdministrator),
@@ -72,3 +128,69 @@ func (a *Authorizer) AllowsOrganisationDeleteCmd(command.OrganisationDeleteCmd)
 		requireRole(types.RoleSystemAdministrator),
 	)
 }
+
+func (a *Authorizer) AllowsOrganisationRestoreCmd(command.OrganisationRestoreCmd) error {
+	return a.check(
+		requireRole(types.RoleSystemAdministrator),
+	)
+}
+
+func (a *Authorizer) AllowsAssetCreateCmd(cmd command.AssetCreateCmd) error {
+	return a.check(
+		satisfyAny(
+			requireRole(types.RoleSystemAdministrator),
+			requireSameOrganisation(uuidOrNil(cmd.OrganisationID)),
+		),
+	)
+}
+
+func (a *Authorizer) AllowsAssetDeleteCmd(cmd command.AssetDeleteCmd) error {
+	return a.check(
+		satisfyAny(
+			requireRole(types.RoleSystemAdministrator),
+			requireSameOrganisation(uuidOrNil(cmd.OrganisationID)),
+		),
+	)
+}
+
+func (a *Authorizer) AllowsSupportRequestSubmitCmd(cmd command.SupportRequestSubmitCmd) error {
+	return a.check(
+		requireSameAccount(&cmd.AccountID),
+	)
+}
+
+func (a *Authorizer) AllowsWebhookEndpointCreateCmd(cmd command.WebhookEndpointCreateCmd) error {
+	return a.check(
+		satisfyAny(
+			requireRole(types.RoleSystemAdministrator),
+			requireSameOrganisationAdministrator(&cmd.OrganisationID),
+		),
+	)
+}
+
+func (a *Authorizer) AllowsWebhookEndpointUpdateCmd(cmd command.WebhookEndpointUpdateCmd) error {
+	return a.check(
+		satisfyAny(
+			requireRole(types.RoleSystemAdministrator),
+			requireSameOrganisationAdministrator(&cmd.OrganisationID),
+		),
+	)
+}
+
+func (a *Authorizer) AllowsWebhookEndpointDeleteCmd(cmd command.WebhookEndpointDeleteCmd) error {
+	return a.check(
+		satisfyAny(
+			requireRole(types.RoleSystemAdministrator),
+			requireSameOrganisationAdministrator(&cmd.OrganisationID),
+		),
+	)
+}
+
+func (a *Authorizer) AllowsWebhookRedeliverCmd(cmd command.WebhookRedeliverCmd) error {
+	return a.check(
+		satisfyAny(
+			requireRole(types.RoleSystemAdministrator),
+			requireSameOrganisationAdministrator(&cmd.OrganisationID),
+		),
+	)
+}
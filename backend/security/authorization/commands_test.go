@@ -0,0 +1,70 @@
+package authorization_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/security/authentication"
+	"myvendor.mytld/myproject/backend/security/authorization"
+)
+
+func TestAuthorizer_AllowsCmd(t *testing.T) {
+	fixtureOrganisationID := uuid.Must(uuid.FromString("2bf9eab6-c592-4c9c-99d6-20339c845ea8"))
+	otherOrganisationID := uuid.Must(uuid.FromString("f49c01b7-15a6-48ad-8989-f2fd4e5fa5c1"))
+
+	orgAdminAuthCtx := authentication.AuthContext{
+		Authenticated:  true,
+		AccountID:      uuid.Must(uuid.FromString("04086bfe-4f22-4aa3-9ed7-f85b15a83efd")),
+		OrganisationID: &fixtureOrganisationID,
+		Role:           types.RoleOrganisationAdministrator,
+	}
+
+	tests := []struct {
+		name    string
+		authCtx authentication.AuthContext
+		cmd     any
+		wantErr bool
+	}{
+		{
+			name:    "login without authentication",
+			authCtx: authentication.AuthContext{},
+			cmd:     command.NewLoginCmd("jane@example.com", "secret"),
+			wantErr: false,
+		},
+		{
+			name:    "OrganisationAdministrator - delete asset of own organisation",
+			authCtx: orgAdminAuthCtx,
+			cmd:     command.NewAssetDeleteCmd(uuid.Must(uuid.NewV4()), uuid.NullUUID{UUID: fixtureOrganisationID, Valid: true}),
+			wantErr: false,
+		},
+		{
+			name:    "OrganisationAdministrator - delete asset of other organisation",
+			authCtx: orgAdminAuthCtx,
+			cmd:     command.NewAssetDeleteCmd(uuid.Must(uuid.NewV4()), uuid.NullUUID{UUID: otherOrganisationID, Valid: true}),
+			wantErr: true,
+		},
+		{
+			name: "SystemAdministrator - command without check",
+			authCtx: authentication.AuthContext{
+				Authenticated: true,
+				Role:          types.RoleSystemAdministrator,
+			},
+			cmd:     struct{}{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authorization.NewAuthorizer(tt.authCtx).AllowsCmd(tt.cmd)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
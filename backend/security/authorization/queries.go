@@ -15,12 +15,22 @@ func (a *Authorizer) AllowsOrganisationQuery(query query.OrganisationQuery) erro
 	)
 }
 
+func (a *Authorizer) AllowsOrganisationView(record model.Organisation) error {
+	return a.check(
+		satisfyAny(
+			requireRole(types.RoleSystemAdministrator),
+			requireSameOrganisation(&record.ID),
+		),
+	)
+}
+
 func (a *Authorizer) AllowsAndFilterAllOrganisationsQuery(query *query.OrganisationsQuery) error {
 	return a.check(
 		satisfyAny(
 			requireRole(types.RoleSystemAdministrator),
 			requireAll(
 				requireRole(types.RoleOrganisationAdministrator),
+				requireWithoutDeleted(query.WithDeleted),
 				setOrganisationID(query),
 			),
 		),
@@ -42,6 +52,7 @@ func (a *Authorizer) AllowsAndFilterAllAccountsQuery(query *query.AccountsQuery)
 			requireRole(types.RoleSystemAdministrator),
 			requireAll(
 				requireRole(types.RoleOrganisationAdministrator),
+				requireWithoutDeleted(query.WithDeleted),
 				setOrganisationID(query),
 			),
 		),
@@ -53,3 +64,87 @@ func (a *Authorizer) AllowsAllAccountsQuery() error {
 		requireRole(types.RoleSystemAdministrator),
 	)
 }
+
+func (a *Authorizer) AllowsAndFilterAccountChangedSubscription(query *query.AccountChangedSubscription) error {
+	return a.check(
+		satisfyAny(
+			requireRole(types.RoleSystemAdministrator),
+			requireAll(
+				requireRole(types.RoleOrganisationAdministrator),
+				setOrganisationID(query),
+			),
+		),
+	)
+}
+
+func (a *Authorizer) AllowsAndFilterOrganisationChangedSubscription(query *query.OrganisationChangedSubscription) error {
+	return a.check(
+		satisfyAny(
+			requireRole(types.RoleSystemAdministrator),
+			requireAll(
+				requireRole(types.OrganisationRoles...),
+				setOrganisationID(query),
+			),
+		),
+	)
+}
+
+func (a *Authorizer) AllowsAssetView(record model.Asset) error {
+	return a.check(
+		satisfyAny(
+			requireRole(types.RoleSystemAdministrator),
+			requireSameOrganisation(uuidOrNil(record.OrganisationID)),
+		),
+	)
+}
+
+func (a *Authorizer) AllowsWebhookEndpointView(record model.WebhookEndpoint) error {
+	return a.check(
+		satisfyAny(
+			requireRole(types.RoleSystemAdministrator),
+			requireSameOrganisationAdministrator(&record.OrganisationID),
+		),
+	)
+}
+
+func (a *Authorizer) AllowsAndFilterAllWebhookEndpointsQuery(query *query.WebhookEndpointsQuery) error {
+	return a.check(
+		satisfyAny(
+			requireRole(types.RoleSystemAdministrator),
+			requireAll(
+				requireRole(types.RoleOrganisationAdministrator),
+				setOrganisationID(query),
+			),
+		),
+	)
+}
+
+func (a *Authorizer) AllowsWebhookDeliveriesQuery(query query.WebhookDeliveriesQuery) error {
+	return a.check(
+		satisfyAny(
+			requireRole(types.RoleSystemAdministrator),
+			requireSameOrganisationAdministrator(&query.OrganisationID),
+		),
+	)
+}
+
+func (a *Authorizer) AllowsWebhookDeliveryView(record model.WebhookDelivery) error {
+	return a.check(
+		satisfyAny(
+			requireRole(types.RoleSystemAdministrator),
+			requireSameOrganisationAdministrator(&record.OrganisationID),
+		),
+	)
+}
+
+func (a *Authorizer) AllowsAndFilterAllAuditEntriesQuery(query *query.AuditEntriesQuery) error {
+	return a.check(
+		satisfyAny(
+			requireRole(types.RoleSystemAdministrator),
+			requireAll(
+				requireRole(types.RoleOrganisationAdministrator),
+				setOrganisationID(query),
+			),
+		),
+	)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/query"
 	"myvendor.mytld/myproject/backend/domain/types"
 	"myvendor.mytld/myproject/backend/security/authentication"
 	"myvendor.mytld/myproject/backend/security/authorization"
@@ -91,3 +92,151 @@ func TestAuthorizer_AllowsAccountView(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorizer_AllowsOrganisationView(t *testing.T) {
+	fixtureAccountID := uuid.Must(uuid.FromString("04086bfe-4f22-4aa3-9ed7-f85b15a83efd"))
+	fixtureOrganisationID := uuid.Must(uuid.FromString("2bf9eab6-c592-4c9c-99d6-20339c845ea8"))
+
+	tests := []struct {
+		name    string
+		authCtx authentication.AuthContext
+		record  model.Organisation
+		wantErr bool
+	}{
+		{
+			name:    "unauthenticated",
+			authCtx: authentication.AuthContext{},
+			record: model.Organisation{
+				ID: fixtureOrganisationID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "OrganisationAdministrator - same organisation",
+			authCtx: authentication.AuthContext{
+				Authenticated:  true,
+				AccountID:      fixtureAccountID,
+				OrganisationID: &fixtureOrganisationID,
+				Role:           types.RoleOrganisationAdministrator,
+			},
+			record: model.Organisation{
+				ID: fixtureOrganisationID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "OrganisationAdministrator - other organisation",
+			authCtx: authentication.AuthContext{
+				Authenticated:  true,
+				AccountID:      fixtureAccountID,
+				OrganisationID: &fixtureOrganisationID,
+				Role:           types.RoleOrganisationAdministrator,
+			},
+			record: model.Organisation{
+				ID: uuid.Must(uuid.FromString("f9e84475-45f9-47d1-a58c-e416f1c7f39d")),
+			},
+			wantErr: true,
+		},
+		{
+			name: "SystemAdministrator",
+			authCtx: authentication.AuthContext{
+				Authenticated: true,
+				AccountID:     fixtureAccountID,
+				Role:          types.RoleSystemAdministrator,
+			},
+			record: model.Organisation{
+				ID: uuid.Must(uuid.FromString("f9e84475-45f9-47d1-a58c-e416f1c7f39d")),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := authorization.NewAuthorizer(tt.authCtx)
+
+			err := a.AllowsOrganisationView(tt.record)
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthorizer_AllowsAndFilterAccountChangedSubscription(t *testing.T) {
+	fixtureAccountID := uuid.Must(uuid.FromString("04086bfe-4f22-4aa3-9ed7-f85b15a83efd"))
+	fixtureOrganisationID := uuid.Must(uuid.FromString("2bf9eab6-c592-4c9c-99d6-20339c845ea8"))
+	otherOrganisationID := uuid.Must(uuid.FromString("f9e84475-45f9-47d1-a58c-e416f1c7f39d"))
+
+	tests := []struct {
+		name                   string
+		authCtx                authentication.AuthContext
+		organisationID         *uuid.UUID
+		wantErr                bool
+		expectedOrganisationID *uuid.UUID
+	}{
+		{
+			name:           "unauthenticated",
+			authCtx:        authentication.AuthContext{},
+			organisationID: &fixtureOrganisationID,
+			wantErr:        true,
+		},
+		{
+			name: "OrganisationAdministrator - without organisation",
+			authCtx: authentication.AuthContext{
+				Authenticated:  true,
+				AccountID:      fixtureAccountID,
+				OrganisationID: &fixtureOrganisationID,
+				Role:           types.RoleOrganisationAdministrator,
+			},
+			expectedOrganisationID: &fixtureOrganisationID,
+		},
+		{
+			name: "OrganisationAdministrator - other organisation",
+			authCtx: authentication.AuthContext{
+				Authenticated:  true,
+				AccountID:      fixtureAccountID,
+				OrganisationID: &fixtureOrganisationID,
+				Role:           types.RoleOrganisationAdministrator,
+			},
+			organisationID:         &otherOrganisationID,
+			expectedOrganisationID: &fixtureOrganisationID,
+		},
+		{
+			name: "SystemAdministrator - without organisation",
+			authCtx: authentication.AuthContext{
+				Authenticated: true,
+				AccountID:     fixtureAccountID,
+				Role:          types.RoleSystemAdministrator,
+			},
+			expectedOrganisationID: nil,
+		},
+		{
+			name: "SystemAdministrator - other organisation",
+			authCtx: authentication.AuthContext{
+				Authenticated: true,
+				AccountID:     fixtureAccountID,
+				Role:          types.RoleSystemAdministrator,
+			},
+			organisationID:         &otherOrganisationID,
+			expectedOrganisationID: &otherOrganisationID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := authorization.NewAuthorizer(tt.authCtx)
+
+			q := query.AccountChangedSubscription{OrganisationID: tt.organisationID}
+			err := a.AllowsAndFilterAccountChangedSubscription(&q)
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedOrganisationID, q.OrganisationID)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Package netguard restricts outgoing connections to requested URLs (e.g. webhooks) to public addresses,
+// so they cannot be used to reach internal services or cloud metadata endpoints.
+package netguard
+
+import (
+	std_errors "errors"
+	"net"
+	"net/netip"
+	"strings"
+	"syscall"
+
+	"github.com/friendsofgo/errors"
+)
+
+var ErrForbiddenAddress = std_errors.New("forbidden address")
+
+// reservedPrefixes are special purpose ranges that are not covered by the checks of netip.Addr
+//
+//nolint:gochecknoglobals
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001::/23"),
+	netip.MustParsePrefix("2001:db8::/32"),
+	netip.MustParsePrefix("2002::/16"),
+}
+
+// IsPublicAddr checks if an address is routable on the internet.
+// Loopback, private (RFC 1918, unique local), link-local (including 169.254.169.254), multicast and reserved addresses are not public.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() ||
+		addr.IsUnspecified() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsForbiddenHost checks if the host of a URL is known to not be public without resolving it (localhost or a non-public IP address).
+// Host names are checked again when connecting, see DialControl.
+func IsForbiddenHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return !IsPublicAddr(addr)
+	}
+	return false
+}
+
+// DialControl is a net.Dialer control function that rejects connections to addresses that are not public.
+// It is called with the resolved address, so it also catches host names resolving to internal addresses (e.g. DNS rebinding).
+func DialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrap(err, "splitting address")
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return errors.Wrap(err, "parsing address")
+	}
+	if !IsPublicAddr(addr) {
+		return errors.Wrapf(ErrForbiddenAddress, "dialing %s %s", network, address)
+	}
+	return nil
+}
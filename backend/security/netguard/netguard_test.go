@@ -0,0 +1,53 @@
+package netguard_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/security/netguard"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr     string
+		expected bool
+	}{
+		{addr: "93.184.215.14", expected: true},
+		{addr: "2606:2800:21f:cb07:6820:80da:af6b:8b2c", expected: true},
+		{addr: "127.0.0.1", expected: false},
+		{addr: "::1", expected: false},
+		{addr: "10.1.2.3", expected: false},
+		{addr: "172.16.0.1", expected: false},
+		{addr: "192.168.178.1", expected: false},
+		{addr: "169.254.169.254", expected: false},
+		{addr: "100.64.0.1", expected: false},
+		{addr: "0.0.0.0", expected: false},
+		{addr: "fd00::1", expected: false},
+		{addr: "fe80::1", expected: false},
+		{addr: "::ffff:127.0.0.1", expected: false},
+		{addr: "64:ff9b::a9fe:a9fe", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.expected, netguard.IsPublicAddr(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func TestIsForbiddenHost(t *testing.T) {
+	assert.False(t, netguard.IsForbiddenHost("example.com"))
+	assert.False(t, netguard.IsForbiddenHost("93.184.215.14"))
+	assert.True(t, netguard.IsForbiddenHost("localhost"))
+	assert.True(t, netguard.IsForbiddenHost("api.LOCALHOST."))
+	assert.True(t, netguard.IsForbiddenHost("169.254.169.254"))
+	assert.True(t, netguard.IsForbiddenHost("::1"))
+}
+
+func TestDialControl(t *testing.T) {
+	require.NoError(t, netguard.DialControl("tcp4", "93.184.215.14:443", nil))
+	require.ErrorIs(t, netguard.DialControl("tcp4", "127.0.0.1:8080", nil), netguard.ErrForbiddenAddress)
+	require.ErrorIs(t, netguard.DialControl("tcp6", "[fd00::1]:443", nil), netguard.ErrForbiddenAddress)
+}
@@ -0,0 +1,87 @@
+package signedurl_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/security/signedurl"
+)
+
+func TestSigner(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	signer := signedurl.NewSigner([]byte("secret"))
+
+	signed := signer.Sign("/assets/0190e6a4-2a8e-7c6f-8a0a-2d9c1b2a3f4e", now.Add(15*time.Minute))
+
+	tests := []struct {
+		name    string
+		url     string
+		now     time.Time
+		wantErr error
+	}{
+		{
+			name: "valid",
+			url:  signed,
+			now:  now,
+		},
+		{
+			name: "at expiry",
+			url:  signed,
+			now:  now.Add(15 * time.Minute),
+		},
+		{
+			name:    "expired",
+			url:     signed,
+			now:     now.Add(15*time.Minute + time.Second),
+			wantErr: signedurl.ErrExpired,
+		},
+		{
+			name:    "other path",
+			url:     "/assets/other" + signed[len("/assets/0190e6a4-2a8e-7c6f-8a0a-2d9c1b2a3f4e"):],
+			now:     now,
+			wantErr: signedurl.ErrInvalidSignature,
+		},
+		{
+			name:    "extended expiry",
+			url:     "/assets/0190e6a4-2a8e-7c6f-8a0a-2d9c1b2a3f4e?" + url.Values{"expires": {"9999999999"}, "signature": {mustQuery(t, signed).Get("signature")}}.Encode(),
+			now:     now,
+			wantErr: signedurl.ErrInvalidSignature,
+		},
+		{
+			name:    "unsigned",
+			url:     "/assets/0190e6a4-2a8e-7c6f-8a0a-2d9c1b2a3f4e",
+			now:     now,
+			wantErr: signedurl.ErrInvalidSignature,
+		},
+		{
+			name:    "other secret",
+			url:     signedurl.NewSigner([]byte("other")).Sign("/assets/0190e6a4-2a8e-7c6f-8a0a-2d9c1b2a3f4e", now.Add(15*time.Minute)),
+			now:     now,
+			wantErr: signedurl.ErrInvalidSignature,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			require.NoError(t, err)
+
+			err = signer.Verify(u, tt.now)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func mustQuery(t *testing.T, rawURL string) url.Values {
+	t.Helper()
+
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+	return u.Query()
+}
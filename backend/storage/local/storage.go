@@ -0,0 +1,104 @@
+package local
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/friendsofgo/errors"
+
+	"myvendor.mytld/myproject/backend/storage"
+)
+
+type localStorage struct {
+	dir string
+}
+
+var _ storage.Storage = new(localStorage)
+
+// NewStorage creates a storage that keeps objects as files below dir, the directory is created if it does not exist
+func NewStorage(dir string) (storage.Storage, error) {
+	err := os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating storage directory")
+	}
+	return &localStorage{
+		dir: dir,
+	}, nil
+}
+
+func (s *localStorage) Put(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o750)
+	if err != nil {
+		return errors.Wrap(err, "creating directory")
+	}
+
+	// Write to a temporary file first, so readers never see partially written objects
+	f, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return errors.Wrap(err, "creating temporary file")
+	}
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+
+	_, err = io.Copy(f, r)
+	if err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "writing file")
+	}
+	err = f.Close()
+	if err != nil {
+		return errors.Wrap(err, "closing file")
+	}
+
+	err = os.Rename(f.Name(), path)
+	if err != nil {
+		return errors.Wrap(err, "renaming file")
+	}
+
+	return nil
+}
+
+func (s *localStorage) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, storage.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "opening file")
+	}
+	return f, nil
+}
+
+func (s *localStorage) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "removing file")
+	}
+	return nil
+}
+
+func (s *localStorage) path(key string) (string, error) {
+	err := storage.ValidateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
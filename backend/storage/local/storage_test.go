@@ -0,0 +1,55 @@
+package local_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/storage"
+	"myvendor.mytld/myproject/backend/storage/local"
+)
+
+func TestStorage(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := local.NewStorage(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = s.Get(ctx, "assets/missing")
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	err = s.Put(ctx, "assets/org/file", strings.NewReader("Hello"), 5, "text/plain")
+	require.NoError(t, err)
+
+	r, err := s.Get(ctx, "assets/org/file")
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "Hello", string(content))
+
+	err = s.Delete(ctx, "assets/org/file")
+	require.NoError(t, err)
+
+	_, err = s.Get(ctx, "assets/org/file")
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	// Deleting a missing object is not an error
+	err = s.Delete(ctx, "assets/org/file")
+	require.NoError(t, err)
+}
+
+func TestStorage_InvalidKey(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := local.NewStorage(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "/etc/passwd", "../outside", "assets/../../outside", "assets//file"} {
+		err = s.Put(ctx, key, strings.NewReader("x"), 1, "text/plain")
+		require.ErrorIs(t, err, storage.ErrInvalidKey, "key %q", key)
+	}
+}
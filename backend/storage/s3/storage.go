@@ -0,0 +1,170 @@
+package s3
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+
+	"myvendor.mytld/myproject/backend/storage"
+)
+
+// Config for an S3-compatible object storage (e.g. AWS S3, MinIO)
+type Config struct {
+	// Endpoint URL of the service, e.g. https://s3.eu-central-1.amazonaws.com or http://localhost:9000
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// UsePathStyle addresses the bucket in the path instead of the host name (needed for most self-hosted services)
+	UsePathStyle bool
+	// Prefix is prepended to all object keys
+	Prefix string
+	// HTTPClient is used for requests, http.DefaultClient if nil
+	HTTPClient *http.Client
+}
+
+type s3Storage struct {
+	config   Config
+	endpoint *url.URL
+	client   *http.Client
+}
+
+var _ storage.Storage = new(s3Storage)
+
+func NewStorage(config Config) (storage.Storage, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing endpoint")
+	}
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, errors.Errorf("endpoint must be an absolute URL: %s", config.Endpoint)
+	}
+	if config.Bucket == "" {
+		return nil, errors.New("bucket must be set")
+	}
+
+	client := config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &s3Storage{
+		config:   config,
+		endpoint: endpoint,
+		client:   client,
+	}, nil
+}
+
+func (s *s3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	return nil
+}
+
+func (s *s3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		_ = resp.Body.Close()
+		return nil, storage.ErrNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, responseError(resp)
+	}
+}
+
+func (s *s3Storage) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return responseError(resp)
+	}
+}
+
+func (s *s3Storage) newRequest(ctx context.Context, method string, key string, body io.Reader) (*http.Request, error) {
+	err := storage.ValidateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(s.config.Prefix+key).String(), body)
+	if err != nil {
+		return nil, errors.Wrap(err, "building request")
+	}
+	return req, nil
+}
+
+func (s *s3Storage) do(req *http.Request) (*http.Response, error) {
+	signRequest(req, s.config.AccessKeyID, s.config.SecretAccessKey, s.config.Region, time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "executing %s request", req.Method)
+	}
+	return resp, nil
+}
+
+func (s *s3Storage) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	basePath := strings.TrimSuffix(u.Path, "/")
+	if s.config.UsePathStyle {
+		u.Path = basePath + "/" + s.config.Bucket + "/" + key
+		u.RawPath = basePath + "/" + uriEncode(s.config.Bucket, true) + "/" + uriEncode(key, false)
+	} else {
+		u.Host = s.config.Bucket + "." + u.Host
+		u.Path = basePath + "/" + key
+		u.RawPath = basePath + "/" + uriEncode(key, false)
+	}
+	return &u
+}
+
+func responseError(resp *http.Response) error {
+	// The error body is XML with a code and message, it is included for troubleshooting
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return errors.Errorf("unexpected response status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,55 @@
+package s3_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/storage"
+	"myvendor.mytld/myproject/backend/storage/s3"
+	test_storage "myvendor.mytld/myproject/backend/test/storage"
+)
+
+func TestStorage(t *testing.T) {
+	ctx := context.Background()
+	server := test_storage.NewS3Server(t)
+
+	s, err := s3.NewStorage(server.Config())
+	require.NoError(t, err)
+
+	_, err = s.Get(ctx, "assets/missing")
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	err = s.Put(ctx, "assets/org/file name.txt", strings.NewReader("Hello"), 5, "text/plain")
+	require.NoError(t, err)
+	require.Equal(t, []string{"assets/org/file name.txt"}, server.ObjectKeys())
+
+	r, err := s.Get(ctx, "assets/org/file name.txt")
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "Hello", string(content))
+
+	err = s.Delete(ctx, "assets/org/file name.txt")
+	require.NoError(t, err)
+	require.Empty(t, server.ObjectKeys())
+
+	_, err = s.Get(ctx, "assets/org/file name.txt")
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestStorage_WrongCredentials(t *testing.T) {
+	server := test_storage.NewS3Server(t)
+
+	config := server.Config()
+	config.AccessKeyID = "other-key"
+	s, err := s3.NewStorage(config)
+	require.NoError(t, err)
+
+	err = s.Put(context.Background(), "assets/file", strings.NewReader("Hello"), 5, "text/plain")
+	require.ErrorContains(t, err, "403")
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	std_errors "errors"
+	"io"
+	"strings"
+)
+
+var (
+	ErrNotFound   = std_errors.New("object not found")
+	ErrInvalidKey = std_errors.New("invalid object key")
+)
+
+// Storage stores binary objects (e.g. uploaded files) by key
+type Storage interface {
+	// Put stores the content of r with the given size under key, an existing object is replaced
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the object with the given key, ErrNotFound is returned if it does not exist
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object with the given key, deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// ValidateKey checks that a key is a relative slash separated path without empty or dot segments
+func ValidateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gofrs/uuid"
 
+	"myvendor.mytld/myproject/backend/domain/model"
 	"myvendor.mytld/myproject/backend/domain/types"
 	"myvendor.mytld/myproject/backend/security/authentication"
 )
@@ -114,3 +115,26 @@ func GetContextWithAnonymous() context.Context {
 		Authenticated: false,
 	})
 }
+
+// AuthTokenDataForAccount returns the auth token data of an account (e.g. built with fixtures.Account)
+func AuthTokenDataForAccount(account model.Account) FixedAuthTokenData {
+	return FixedAuthTokenData{
+		TokenSecret:    account.Secret,
+		AccountID:      account.ID,
+		OrganisationID: account.OrganisationID,
+		RoleIdentifier: string(account.Role),
+	}
+}
+
+// ApplyAuthValuesForAccount returns a func that authenticates a request as the given account
+func ApplyAuthValuesForAccount(account model.Account) ApplyAuthValuesFunc {
+	return func(t *testing.T, timeSource types.TimeSource, req *http.Request) FixedAuthTokenData {
+		t.Helper()
+
+		authTokenData := AuthTokenDataForAccount(account)
+
+		addTokenToRequest(t, timeSource, req, authTokenData)
+
+		return authTokenData
+	}
+}
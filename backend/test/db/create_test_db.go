@@ -14,7 +14,6 @@ import (
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/jackc/pgx/v5/tracelog"
 	apexlogutils_pgx "github.com/networkteam/apexlogutils/pgx/v5"
-	"github.com/pressly/goose/v3"
 
 	// Import migrations with side-effect
 	_ "myvendor.mytld/myproject/backend/persistence/migrations"
@@ -26,37 +25,91 @@ const (
 	dbName = "myproject-test"
 )
 
+// extensions are installed before migrations are executed, since concurrent CREATE EXTENSION can fail
+//
+//nolint:gochecknoglobals
+var extensions = []string{"btree_gist", "pg_trgm"}
+
 // PrepareTestDatabase prepares the test database (e.g. it creates extensions)
 //
 // This might be needed to be done outside of migrations because of concurrency issues when running package tests
-// in parallel.
+// in parallel. Template databases of previous runs are dropped, they are created again by the next test.
 func PrepareTestDatabase() error {
-	postgresDSN := fmt.Sprintf("host=localhost port=%d dbname=%s sslmode=disable", dbPort, dbName)
+	db, connStr, err := openDatabase(dbName)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+		stdlib.UnregisterConnConfig(connStr)
+	}()
 
-	connConfig, err := pgx.ParseConfig(postgresDSN)
+	err = createExtensions(db)
 	if err != nil {
-		return errors.Wrap(err, "parsing PostgreSQL connection string")
+		return err
 	}
-	connConfig.Tracer = &tracelog.TraceLog{
-		Logger: apexlogutils_pgx.NewLogger(log.Log),
-		// Increase to LogLevelTrace to see all queries
-		LogLevel: tracelog.LogLevelDebug,
+
+	return dropTemplateDatabases(db)
+}
+
+// CreateTestDatabase creates a migrated database for a test that is dropped after the test.
+// The database is a copy of a template database, so tests can run in parallel without executing migrations every time.
+func CreateTestDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	return CreateTestDatabaseWithFixtures(t)
+}
+
+// CreateTestDatabaseWithFixtures creates a migrated database with the given fixtures (see ExecFixtures) for a test.
+// Fixtures are executed once in a template database for all tests with the same fixtures.
+func CreateTestDatabaseWithFixtures(t *testing.T, fixtureFilenames ...string) *sql.DB {
+	t.Helper()
+
+	templateName, err := templateDatabase(fixtureFilenames)
+	if err != nil {
+		t.Fatalf("Failed to create template database: %v", err)
 	}
-	connStr := stdlib.RegisterConnConfig(connConfig)
-	db, err := sql.Open("pgx", connStr)
+
+	maintenanceDB, err := maintenanceDatabase()
 	if err != nil {
-		return errors.Wrap(err, "open database")
+		t.Fatalf("Failed to open database connection: %v", err)
 	}
 
-	_, err = db.Exec("CREATE EXTENSION IF NOT EXISTS btree_gist")
+	randomSuffix, err := helper.GenerateRandomString(12)
 	if err != nil {
-		return errors.Wrap(err, "creating extensions")
+		t.Fatalf("Failed to generate random string: %v", err)
 	}
+	name := dbName + "-" + strings.ToLower(randomSuffix)
 
-	return nil
+	_, err = maintenanceDB.Exec(fmt.Sprintf("CREATE DATABASE %s TEMPLATE %s", pgx.Identifier{name}.Sanitize(), pgx.Identifier{templateName}.Sanitize()))
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+
+	db, connStr, err := openDatabase(name)
+	if err != nil {
+		t.Fatalf("Failed to open database connection: %v", err)
+	}
+
+	t.Cleanup(func() {
+		err := db.Close()
+		if err != nil {
+			t.Logf("Error closing test DB: %v", err)
+		}
+		stdlib.UnregisterConnConfig(connStr)
+
+		// Connections of goroutines started by the test might still be open
+		_, err = maintenanceDB.Exec(fmt.Sprintf("DROP DATABASE %s WITH (FORCE)", pgx.Identifier{name}.Sanitize()))
+		if err != nil {
+			t.Fatalf("Failed to drop database: %v", err)
+		}
+	})
+
+	return db
 }
 
-func CreateTestDatabase(t *testing.T) *sql.DB {
+// createTestSchema creates an empty schema in the test database that is dropped after the test and returns a connection using it
+func createTestSchema(t *testing.T) *sql.DB {
 	t.Helper()
 
 	randomSuffix, err := helper.GenerateRandomString(12)
@@ -65,21 +118,10 @@ func CreateTestDatabase(t *testing.T) *sql.DB {
 	}
 	schemaName := "test-" + strings.ToLower(randomSuffix)
 
-	postgresDSN := fmt.Sprintf("host=localhost port=%d dbname=%s sslmode=disable search_path=%s", dbPort, dbName, schemaName)
+	// Extensions are installed in the public schema by PrepareTestDatabase
+	postgresDSN := fmt.Sprintf("host=localhost port=%d dbname=%s sslmode=disable search_path=%s,public", dbPort, dbName, schemaName)
 
-	connConfig, err := pgx.ParseConfig(postgresDSN)
-	if err != nil {
-		t.Fatalf("Failed to parse PostgreSQL connection string: %v", err)
-	}
-	connConfig.Tracer = &tracelog.TraceLog{
-		Logger: apexlogutils_pgx.NewLogger(log.Log, apexlogutils_pgx.WithIgnoreErrors(func(err error) bool {
-			return err.Error() == "ERROR: relation \"goose_db_version\" does not exist (SQLSTATE 42P01)"
-		})),
-		// Increase to LogLevelTrace to see all queries
-		LogLevel: tracelog.LogLevelDebug,
-	}
-	connStr := stdlib.RegisterConnConfig(connConfig)
-	db, err := sql.Open("pgx", connStr)
+	db, connStr, err := openDSN(postgresDSN)
 	if err != nil {
 		t.Fatalf("Failed to open database connection: %v", err)
 	}
@@ -101,16 +143,47 @@ func CreateTestDatabase(t *testing.T) *sql.DB {
 		stdlib.UnregisterConnConfig(connStr)
 	})
 
-	_, filename, _, _ := runtime.Caller(0)
-	migrationSource := filepath.Dir(filename + "/../../../persistence/migrations/")
+	return db
+}
 
-	goose.SetLogger(testGooseLogger{t})
-	err = goose.Up(db, migrationSource)
+func openDatabase(name string) (db *sql.DB, connStr string, err error) {
+	return openDSN(fmt.Sprintf("host=localhost port=%d dbname=%s sslmode=disable", dbPort, name))
+}
+
+func openDSN(postgresDSN string) (db *sql.DB, connStr string, err error) {
+	connConfig, err := pgx.ParseConfig(postgresDSN)
 	if err != nil {
-		t.Fatalf("Failed to execute migrations: %v", err)
+		return nil, "", errors.Wrap(err, "parsing PostgreSQL connection string")
 	}
+	connConfig.Tracer = &tracelog.TraceLog{
+		Logger: apexlogutils_pgx.NewLogger(log.Log, apexlogutils_pgx.WithIgnoreErrors(func(err error) bool {
+			return err.Error() == "ERROR: relation \"goose_db_version\" does not exist (SQLSTATE 42P01)"
+		})),
+		// Increase to LogLevelTrace to see all queries
+		LogLevel: tracelog.LogLevelDebug,
+	}
+	connStr = stdlib.RegisterConnConfig(connConfig)
+	db, err = sql.Open("pgx", connStr)
+	if err != nil {
+		stdlib.UnregisterConnConfig(connStr)
+		return nil, "", errors.Wrap(err, "open database")
+	}
+	return db, connStr, nil
+}
 
-	return db
+func createExtensions(db *sql.DB) error {
+	for _, extension := range extensions {
+		_, err := db.Exec("CREATE EXTENSION IF NOT EXISTS " + extension)
+		if err != nil {
+			return errors.Wrapf(err, "creating extension %s", extension)
+		}
+	}
+	return nil
+}
+
+func migrationSourcePath() string {
+	_, filename, _, _ := runtime.Caller(0)
+	return filepath.Dir(filename + "/../../../persistence/migrations/")
 }
 
 type testGooseLogger struct {
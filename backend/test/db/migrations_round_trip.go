@@ -0,0 +1,185 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+)
+
+// RequireMigrationsRoundTrip applies all migrations step by step in an empty schema. Every migration is migrated down
+// and up again, the schema must then be the same as before and after the migration. This catches down migrations that
+// leave residue (e.g. a forgotten index or function) and up migrations that cannot be applied again after a down migration.
+// Finally, all migrations are migrated down to an empty schema and up again.
+func RequireMigrationsRoundTrip(t *testing.T) {
+	t.Helper()
+
+	db := createTestSchema(t)
+	migrationSource := migrationSourcePath()
+	goose.SetLogger(testGooseLogger{t})
+
+	migrations, err := goose.CollectMigrations(migrationSource, 0, goose.MaxVersion)
+	if err != nil {
+		t.Fatalf("Failed to collect migrations: %v", err)
+	}
+
+	emptySchema := dumpSchema(t, db)
+	before := emptySchema
+	for _, migration := range migrations {
+		source := path.Base(migration.Source)
+
+		err = goose.UpByOne(db, migrationSource)
+		if err != nil {
+			t.Fatalf("Failed to migrate up %s: %v", source, err)
+		}
+		after := dumpSchema(t, db)
+
+		err = goose.Down(db, migrationSource)
+		if err != nil {
+			t.Fatalf("Failed to migrate down %s: %v", source, err)
+		}
+		requireSameSchema(t, before, dumpSchema(t, db), "Down migration of %s leaves residue", source)
+
+		err = goose.UpByOne(db, migrationSource)
+		if err != nil {
+			t.Fatalf("Failed to migrate up %s again: %v", source, err)
+		}
+		requireSameSchema(t, after, dumpSchema(t, db), "Up migration of %s is not reproducible", source)
+
+		before = after
+	}
+
+	err = goose.DownTo(db, migrationSource, 0)
+	if err != nil {
+		t.Fatalf("Failed to migrate down all migrations: %v", err)
+	}
+	requireSameSchema(t, emptySchema, dumpSchema(t, db), "Down migrations leave residue")
+
+	err = goose.Up(db, migrationSource)
+	if err != nil {
+		t.Fatalf("Failed to migrate up all migrations again: %v", err)
+	}
+	requireSameSchema(t, before, dumpSchema(t, db), "Up migrations are not reproducible")
+}
+
+// schemaDumpQueries select a line per object of the current schema. Columns are not ordered by their position,
+// since a column that is dropped and added again is appended to the table.
+//
+//nolint:gochecknoglobals
+var schemaDumpQueries = []string{
+	// Tables, views and sequences
+	`SELECT format('relation %s %s', c.relname, c.relkind)
+		FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = current_schema() AND c.relkind IN ('r', 'p', 'v', 'm', 'S', 'f')`,
+	`SELECT format('column %s.%s %s%s%s', c.relname, a.attname, format_type(a.atttypid, a.atttypmod),
+			CASE WHEN a.attnotnull THEN ' NOT NULL' ELSE '' END,
+			CASE WHEN a.attgenerated = 's' THEN ' GENERATED ' ELSE ' DEFAULT ' END || pg_get_expr(d.adbin, d.adrelid))
+		FROM pg_attribute a
+		JOIN pg_class c ON c.oid = a.attrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE n.nspname = current_schema() AND c.relkind IN ('r', 'p', 'v', 'm', 'f') AND a.attnum > 0 AND NOT a.attisdropped`,
+	`SELECT format('constraint %s.%s %s', coalesce(c.relname, t.typname), con.conname, pg_get_constraintdef(con.oid))
+		FROM pg_constraint con
+		JOIN pg_namespace n ON n.oid = con.connamespace
+		LEFT JOIN pg_class c ON c.oid = con.conrelid
+		LEFT JOIN pg_type t ON t.oid = con.contypid
+		WHERE n.nspname = current_schema()`,
+	`SELECT format('index %s', indexdef) FROM pg_indexes WHERE schemaname = current_schema()`,
+	`SELECT format('view %s %s', viewname, definition) FROM pg_views WHERE schemaname = current_schema()`,
+	`SELECT format('trigger %s', pg_get_triggerdef(t.oid))
+		FROM pg_trigger t
+		JOIN pg_class c ON c.oid = t.tgrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = current_schema() AND NOT t.tgisinternal`,
+	`SELECT format('function %s(%s) %s %s', p.proname, pg_get_function_identity_arguments(p.oid), pg_get_function_result(p.oid), md5(p.prosrc))
+		FROM pg_proc p JOIN pg_namespace n ON n.oid = p.pronamespace
+		WHERE n.nspname = current_schema()`,
+	`SELECT format('type %s %s %s', t.typname, t.typtype,
+			coalesce(format_type(nullif(t.typbasetype, 0), t.typtypmod), (SELECT string_agg(e.enumlabel, ',' ORDER BY e.enumsortorder) FROM pg_enum e WHERE e.enumtypid = t.oid), ''))
+		FROM pg_type t JOIN pg_namespace n ON n.oid = t.typnamespace
+		WHERE n.nspname = current_schema() AND t.typtype IN ('e', 'd')`,
+}
+
+// dumpSchema returns a sorted line per object of the current schema (without the schema name, since it is random)
+func dumpSchema(t *testing.T, db *sql.DB) []string {
+	t.Helper()
+
+	var schemaName string
+	err := db.QueryRow("SELECT current_schema()").Scan(&schemaName)
+	if err != nil {
+		t.Fatalf("Failed to get current schema: %v", err)
+	}
+
+	var lines []string
+	for _, query := range schemaDumpQueries {
+		rows, err := db.Query(query)
+		if err != nil {
+			t.Fatalf("Failed to dump schema: %v", err)
+		}
+		for rows.Next() {
+			var line string
+			if err := rows.Scan(&line); err != nil {
+				t.Fatalf("Failed to scan schema dump: %v", err)
+			}
+			// The version table of goose is created by the first migration and not dropped
+			if strings.Contains(line, goose.TableName()) {
+				continue
+			}
+			line = strings.ReplaceAll(line, `"`+schemaName+`".`, "")
+			lines = append(lines, line)
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatalf("Failed to dump schema: %v", err)
+		}
+		_ = rows.Close()
+	}
+
+	sort.Strings(lines)
+	return lines
+}
+
+func requireSameSchema(t *testing.T, expected, actual []string, msg string, args ...any) {
+	t.Helper()
+
+	missing, unexpected := diffLines(expected, actual)
+	if len(missing) == 0 && len(unexpected) == 0 {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(msg, args...) + ":")
+	for _, line := range missing {
+		sb.WriteString("\n- " + line)
+	}
+	for _, line := range unexpected {
+		sb.WriteString("\n+ " + line)
+	}
+	t.Fatal(sb.String())
+}
+
+// diffLines returns the lines of expected that are missing in actual and the lines of actual that are not expected
+func diffLines(expected, actual []string) (missing, unexpected []string) {
+	counts := make(map[string]int, len(expected))
+	for _, line := range expected {
+		counts[line]++
+	}
+	for _, line := range actual {
+		if counts[line] > 0 {
+			counts[line]--
+			continue
+		}
+		unexpected = append(unexpected, line)
+	}
+	for _, line := range expected {
+		if counts[line] > 0 {
+			counts[line]--
+			missing = append(missing, line)
+		}
+	}
+	return missing, unexpected
+}
@@ -0,0 +1,235 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/friendsofgo/errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+)
+
+// templateDBPrefix is the prefix of template databases, the suffix is a hash of the migrations and fixtures
+const templateDBPrefix = dbName + "-tpl-"
+
+//nolint:gochecknoglobals
+var (
+	templatesMu sync.Mutex
+	// templates by fixture filenames, so a template is only checked once per test binary
+	templates = make(map[string]*templateDB)
+
+	maintenanceDBOnce sync.Once
+	maintenanceDB     *sql.DB
+	maintenanceDBErr  error
+)
+
+type templateDB struct {
+	once sync.Once
+	name string
+	err  error
+}
+
+// maintenanceDatabase returns a connection to the test database, that is used to create and drop databases
+func maintenanceDatabase() (*sql.DB, error) {
+	maintenanceDBOnce.Do(func() {
+		maintenanceDB, _, maintenanceDBErr = openDatabase(dbName)
+	})
+	return maintenanceDB, maintenanceDBErr
+}
+
+// templateDatabase returns the name of a migrated template database with the given fixtures, which is created if it does not exist yet.
+// The name contains a hash of migrations and fixtures, so a template is created again after they were changed.
+func templateDatabase(fixtureFilenames []string) (string, error) {
+	templatesMu.Lock()
+	key := strings.Join(fixtureFilenames, ",")
+	tpl, ok := templates[key]
+	if !ok {
+		tpl = &templateDB{}
+		templates[key] = tpl
+	}
+	templatesMu.Unlock()
+
+	tpl.once.Do(func() {
+		tpl.name, tpl.err = createTemplateDatabase(fixtureFilenames)
+	})
+	return tpl.name, tpl.err
+}
+
+func createTemplateDatabase(fixtureFilenames []string) (string, error) {
+	// Templates with fixtures are based on the migrated template
+	var baseTemplateName string
+	if len(fixtureFilenames) > 0 {
+		var err error
+		baseTemplateName, err = templateDatabase(nil)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	hash, err := templateHash(fixtureFilenames)
+	if err != nil {
+		return "", err
+	}
+	name := templateDBPrefix + hash
+
+	maintenanceDB, err := maintenanceDatabase()
+	if err != nil {
+		return "", err
+	}
+
+	ctx := context.Background()
+	conn, err := maintenanceDB.Conn(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "getting connection")
+	}
+	defer conn.Close()
+
+	// Test binaries of packages run in parallel and create the same template
+	_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock(hashtext($1))", templateDBPrefix)
+	if err != nil {
+		return "", errors.Wrap(err, "acquiring lock")
+	}
+	defer func() {
+		_, _ = conn.ExecContext(ctx, "SELECT pg_advisory_unlock(hashtext($1))", templateDBPrefix)
+	}()
+
+	// A database that is not marked as template is left over from a failed attempt
+	var isTemplate bool
+	err = conn.QueryRowContext(ctx, "SELECT datistemplate FROM pg_database WHERE datname = $1", name).Scan(&isTemplate)
+	switch {
+	case err == nil && isTemplate:
+		return name, nil
+	case err == nil:
+		_, err = conn.ExecContext(ctx, fmt.Sprintf("DROP DATABASE %s WITH (FORCE)", pgx.Identifier{name}.Sanitize()))
+		if err != nil {
+			return "", errors.Wrap(err, "dropping incomplete template database")
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return "", errors.Wrap(err, "checking template database")
+	}
+
+	createSQL := fmt.Sprintf("CREATE DATABASE %s", pgx.Identifier{name}.Sanitize())
+	if baseTemplateName != "" {
+		createSQL += fmt.Sprintf(" TEMPLATE %s", pgx.Identifier{baseTemplateName}.Sanitize())
+	}
+	_, err = conn.ExecContext(ctx, createSQL)
+	if err != nil {
+		return "", errors.Wrap(err, "creating template database")
+	}
+
+	err = initTemplateDatabase(name, fixtureFilenames)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = conn.ExecContext(ctx, fmt.Sprintf("ALTER DATABASE %s WITH IS_TEMPLATE true", pgx.Identifier{name}.Sanitize()))
+	if err != nil {
+		return "", errors.Wrap(err, "marking template database")
+	}
+
+	return name, nil
+}
+
+// initTemplateDatabase executes the migrations or fixtures (if a base template is used) in the template database.
+// All connections must be closed afterwards, since a database cannot be copied while there are connections to it.
+func initTemplateDatabase(name string, fixtureFilenames []string) error {
+	db, connStr, err := openDatabase(name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+		stdlib.UnregisterConnConfig(connStr)
+	}()
+
+	if len(fixtureFilenames) > 0 {
+		for _, file := range fixtureFilenames {
+			data, err := os.ReadFile(FixtureSourcePath() + "/" + file + ".sql")
+			if err != nil {
+				return errors.Wrapf(err, "reading fixture %s", file)
+			}
+			_, err = db.Exec(string(data))
+			if err != nil {
+				return errors.Wrapf(err, "executing fixture %s", file)
+			}
+		}
+		return nil
+	}
+
+	err = createExtensions(db)
+	if err != nil {
+		return err
+	}
+
+	goose.SetLogger(goose.NopLogger())
+	err = goose.Up(db, migrationSourcePath())
+	if err != nil {
+		return errors.Wrap(err, "executing migrations")
+	}
+	return nil
+}
+
+// templateHash hashes the migration files and the given fixtures
+func templateHash(fixtureFilenames []string) (string, error) {
+	files, err := filepath.Glob(migrationSourcePath() + "/*")
+	if err != nil {
+		return "", errors.Wrap(err, "listing migrations")
+	}
+	sort.Strings(files)
+	for _, file := range fixtureFilenames {
+		files = append(files, FixtureSourcePath()+"/"+file+".sql")
+	}
+
+	h := sha256.New()
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", errors.Wrapf(err, "reading %s", file)
+		}
+		_, _ = fmt.Fprintf(h, "%s\n%d\n", filepath.Base(file), len(data))
+		_, _ = h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16], nil
+}
+
+// dropTemplateDatabases drops all template databases of tests
+func dropTemplateDatabases(db *sql.DB) error {
+	rows, err := db.Query("SELECT datname FROM pg_database WHERE starts_with(datname, $1)", templateDBPrefix)
+	if err != nil {
+		return errors.Wrap(err, "listing template databases")
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return errors.Wrap(err, "scanning template database")
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, "listing template databases")
+	}
+	_ = rows.Close()
+
+	for _, name := range names {
+		identifier := pgx.Identifier{name}.Sanitize()
+		_, err = db.Exec(fmt.Sprintf("ALTER DATABASE %s WITH IS_TEMPLATE false", identifier))
+		if err != nil {
+			return errors.Wrapf(err, "unmarking template database %s", name)
+		}
+		_, err = db.Exec(fmt.Sprintf("DROP DATABASE %s WITH (FORCE)", identifier))
+		if err != nil {
+			return errors.Wrapf(err, "dropping template database %s", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package builder
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/qrb/qrbsql"
+	"golang.org/x/crypto/bcrypt"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/security/helper"
+	test_auth "myvendor.mytld/myproject/backend/test/auth"
+	"myvendor.mytld/myproject/backend/test/fixtures"
+)
+
+// AccountBuilder builds an account for a test, see Account
+type AccountBuilder struct {
+	t        *testing.T
+	executor qrbsql.Executor
+	account  model.Account
+	password string
+	roleSet  bool
+}
+
+// Account starts building an account with a random ID, a unique email address and fixtures.DefaultPassword.
+// The role is SystemAdministrator, or OrganisationAdministrator if an organisation is set.
+// The account is inserted by calling Create or Authenticate.
+func Account(t *testing.T, executor qrbsql.Executor) *AccountBuilder {
+	t.Helper()
+
+	id := newID(t)
+	secret, err := model.NewAccountSecret()
+	if err != nil {
+		t.Fatalf("Failed to generate account secret: %v", err)
+	}
+	return &AccountBuilder{
+		t:        t,
+		executor: executor,
+		account: model.Account{
+			ID:           id,
+			EmailAddress: "account-" + id.String()[:8] + "@example.com",
+			Secret:       secret,
+			Role:         types.RoleSystemAdministrator,
+		},
+		password: fixtures.DefaultPassword,
+	}
+}
+
+func (b *AccountBuilder) WithID(id uuid.UUID) *AccountBuilder {
+	b.account.ID = id
+	return b
+}
+
+func (b *AccountBuilder) WithEmailAddress(emailAddress string) *AccountBuilder {
+	b.account.EmailAddress = emailAddress
+	return b
+}
+
+func (b *AccountBuilder) WithRole(role types.Role) *AccountBuilder {
+	b.account.Role = role
+	b.roleSet = true
+	return b
+}
+
+// WithPassword sets the password, it is hashed with the minimum cost to keep tests fast
+func (b *AccountBuilder) WithPassword(password string) *AccountBuilder {
+	b.password = password
+	return b
+}
+
+// WithOrganisation assigns the account to an organisation (e.g. built with Organisation)
+func (b *AccountBuilder) WithOrganisation(organisation model.Organisation) *AccountBuilder {
+	return b.WithOrganisationID(organisation.ID)
+}
+
+func (b *AccountBuilder) WithOrganisationID(organisationID uuid.UUID) *AccountBuilder {
+	b.account.OrganisationID = uuid.NullUUID{UUID: organisationID, Valid: true}
+	if !b.roleSet {
+		b.account.Role = types.RoleOrganisationAdministrator
+	}
+	return b
+}
+
+func (b *AccountBuilder) WithLastLogin(lastLogin time.Time) *AccountBuilder {
+	b.account.LastLogin = &lastLogin
+	return b
+}
+
+// WithDeletedAt builds a soft deleted account
+func (b *AccountBuilder) WithDeletedAt(deletedAt time.Time) *AccountBuilder {
+	b.account.DeletedAt = &deletedAt
+	return b
+}
+
+// Create inserts the account and returns it as stored (e.g. with timestamps and version)
+func (b *AccountBuilder) Create() model.Account {
+	b.t.Helper()
+
+	ctx := context.Background()
+
+	passwordHash, err := helper.GenerateHashFromPassword([]byte(b.password), bcrypt.MinCost)
+	if err != nil {
+		b.t.Fatalf("Failed to hash password: %v", err)
+	}
+	b.account.PasswordHash = passwordHash
+
+	err = repository.InsertAccount(ctx, b.executor, repository.AccountToChangeSet(b.account))
+	if err != nil {
+		b.t.Fatalf("Failed to insert account fixture: %v", err)
+	}
+
+	findByID := repository.FindAccountByID
+	if b.account.DeletedAt != nil {
+		findByID = repository.FindDeletedAccountByID
+	}
+	account, err := findByID(ctx, b.executor, b.account.ID, nil)
+	if err != nil {
+		b.t.Fatalf("Failed to find account fixture: %v", err)
+	}
+	return account
+}
+
+// Authenticate inserts the account and authenticates the request as the account (see test_auth.ApplyAuthValuesForAccount)
+func (b *AccountBuilder) Authenticate(timeSource types.TimeSource, req *http.Request) model.Account {
+	b.t.Helper()
+
+	account := b.Create()
+	test_auth.ApplyAuthValuesForAccount(account)(b.t, timeSource, req)
+	return account
+}
@@ -0,0 +1,84 @@
+package builder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/qrb/qrbsql"
+
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+)
+
+// OrganisationBuilder builds an organisation for a test, see Organisation
+type OrganisationBuilder struct {
+	t            *testing.T
+	executor     qrbsql.Executor
+	organisation model.Organisation
+}
+
+// Organisation starts building an organisation with a random ID and a unique name.
+// The organisation is inserted by calling Create.
+func Organisation(t *testing.T, executor qrbsql.Executor) *OrganisationBuilder {
+	t.Helper()
+
+	id := newID(t)
+	return &OrganisationBuilder{
+		t:        t,
+		executor: executor,
+		organisation: model.Organisation{
+			ID:   id,
+			Name: "Organisation " + id.String()[:8],
+		},
+	}
+}
+
+func (b *OrganisationBuilder) WithID(id uuid.UUID) *OrganisationBuilder {
+	b.organisation.ID = id
+	return b
+}
+
+func (b *OrganisationBuilder) WithName(name string) *OrganisationBuilder {
+	b.organisation.Name = name
+	return b
+}
+
+// WithDeletedAt builds a soft deleted organisation
+func (b *OrganisationBuilder) WithDeletedAt(deletedAt time.Time) *OrganisationBuilder {
+	b.organisation.DeletedAt = &deletedAt
+	return b
+}
+
+// Create inserts the organisation and returns it as stored (e.g. with timestamps and version)
+func (b *OrganisationBuilder) Create() model.Organisation {
+	b.t.Helper()
+
+	ctx := context.Background()
+
+	err := repository.InsertOrganisation(ctx, b.executor, repository.OrganisationToChangeSet(b.organisation))
+	if err != nil {
+		b.t.Fatalf("Failed to insert organisation fixture: %v", err)
+	}
+
+	findByID := repository.FindOrganisationByID
+	if b.organisation.DeletedAt != nil {
+		findByID = repository.FindDeletedOrganisationByID
+	}
+	organisation, err := findByID(ctx, b.executor, b.organisation.ID, nil)
+	if err != nil {
+		b.t.Fatalf("Failed to find organisation fixture: %v", err)
+	}
+	return organisation
+}
+
+func newID(t *testing.T) uuid.UUID {
+	t.Helper()
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		t.Fatalf("Failed to generate ID: %v", err)
+	}
+	return id
+}
@@ -0,0 +1,35 @@
+--
+-- Soft deleted records (requires base fixtures)
+--
+
+-- Account (f045e5d1-cdad-4964-a7e2-139c8a87346c) of Acme Inc. was deleted
+
+UPDATE accounts
+SET deleted_at = '2023-05-01 10:00:00+00'
+WHERE account_id = 'f045e5d1-cdad-4964-a7e2-139c8a87346c';
+
+-- Account (8b0c5a8e-7f3d-4b1a-9c62-0e4f5d6a7b81) of Other Corp was deleted before the organisation
+--   username: former+othercorp@example.com
+--   password: myRandomPassword
+--   role: OrganisationAdministrator
+
+INSERT INTO
+    accounts (account_id, role_identifier, secret, email_address, password_hash, organisation_id, deleted_at)
+VALUES ('8b0c5a8e-7f3d-4b1a-9c62-0e4f5d6a7b81',
+        'OrganisationAdministrator',
+        '\xf71ab8929ad747915e135b8e9a5e01403329cc6b202c8e540e74920a78394e36',
+        'former+othercorp@example.com',
+        '\x24326124303424664b4263675349637966474f6f4571534b5a566c6c4f6d4f347461395161623162545a65556c556e6b4962455269764a645930624f',
+           -- Other Corp
+        'dba20d09-a3df-4975-9406-2fb6fd8f0940',
+        '2023-05-01 10:00:00+00');
+
+-- Organisation Other Corp (dba20d09-a3df-4975-9406-2fb6fd8f0940) was deleted together with its accounts
+
+UPDATE organisations
+SET deleted_at = '2023-06-01 10:00:00+00'
+WHERE organisation_id = 'dba20d09-a3df-4975-9406-2fb6fd8f0940';
+
+UPDATE accounts
+SET deleted_at = '2023-06-01 10:00:00+00'
+WHERE account_id = '2035f4da-f385-42c4-a609-02d9aa7290e5';
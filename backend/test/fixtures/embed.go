@@ -4,3 +4,6 @@ import "embed"
 
 //go:embed *.sql
 var FS embed.FS
+
+// DefaultPassword is the password of accounts in the SQL fixtures, generated accounts and accounts built for tests
+const DefaultPassword = "myRandomPassword" //nolint:gosec
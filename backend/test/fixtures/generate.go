@@ -0,0 +1,189 @@
+package fixtures
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+
+	"myvendor.mytld/myproject/backend/domain"
+	"myvendor.mytld/myproject/backend/domain/command"
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/types"
+)
+
+// generatedTimeSpan is the time span before now in which generated records are created
+const generatedTimeSpan = 2 * 365 * 24 * time.Hour
+
+//nolint:gochecknoglobals
+var (
+	organisationAdjectives = []string{
+		"Blue", "Bright", "Silver", "Northern", "Golden", "Green", "Rapid", "Clear", "Summit", "Urban",
+		"Coastal", "Alpine", "Solid", "Modern", "Red", "Smart", "Open", "Quiet", "Bold", "United",
+	}
+	organisationNouns = []string{
+		"Harbor", "River", "Peak", "Forest", "Bridge", "Stone", "Field", "Valley", "Lake", "Tower",
+		"Falcon", "Oak", "Maple", "Beacon", "Anchor", "Compass", "Lantern", "Meadow", "Pine", "Canyon",
+	}
+	organisationIndustries = []string{
+		"Logistics", "Software", "Consulting", "Media", "Engineering", "Foods", "Energy", "Health",
+		"Design", "Finance", "Analytics", "Robotics", "Textiles", "Travel", "Systems", "Labs",
+	}
+	organisationLegalForms = []string{"Inc.", "Ltd.", "GmbH", "LLC", "AG", "Corp.", "& Co.", ""}
+
+	firstNames = []string{
+		"Anna", "Ben", "Clara", "David", "Emma", "Felix", "Greta", "Hannah", "Isaac", "Julia",
+		"Karl", "Lena", "Max", "Nora", "Oskar", "Paula", "Quentin", "Rosa", "Samuel", "Tina",
+		"Uwe", "Vera", "William", "Xenia", "Yusuf", "Zoe", "Liam", "Mia", "Noah", "Olivia",
+	}
+	lastNames = []string{
+		"Schmidt", "Miller", "Garcia", "Nguyen", "Kowalski", "Rossi", "Jansen", "Okafor", "Tanaka", "Novak",
+		"Fischer", "Smith", "Dubois", "Larsen", "Silva", "Weber", "Brown", "Kim", "Hoffmann", "Murphy",
+		"Becker", "Lopez", "Ivanova", "Cohen", "Wagner", "Khan", "Martin", "Svensson", "Meyer", "Walsh",
+	}
+)
+
+// Generator generates realistic fake organisations and accounts, e.g. for testing pagination, search or performance
+// in local development. The same seed generates the same data, except for account secrets which are always random.
+// Records are built from validated commands, but not inserted.
+type Generator struct {
+	rnd    *rand.Rand
+	now    time.Time
+	config domain.Config
+
+	organisationNames map[string]int
+	emailAddresses    map[string]int
+	// passwordHash is hashed once with the configured cost, all generated accounts have DefaultPassword
+	passwordHash []byte
+}
+
+func NewGenerator(seed int64, now time.Time, config domain.Config) *Generator {
+	return &Generator{
+		rnd:               rand.New(rand.NewSource(seed)), //nolint:gosec
+		now:               now.Truncate(time.Second),
+		config:            config,
+		organisationNames: make(map[string]int),
+		emailAddresses:    make(map[string]int),
+	}
+}
+
+// Organisation generates an organisation with a name like "Blue Harbor Logistics GmbH"
+func (g *Generator) Organisation() (model.Organisation, error) {
+	cmd, err := command.NewOrganisationCreateCmd()
+	if err != nil {
+		return model.Organisation{}, err
+	}
+	cmd.OrganisationID = g.uuid()
+	cmd.Name = strings.TrimSpace(fmt.Sprintf("%s %s %s %s",
+		pick(g.rnd, organisationAdjectives),
+		pick(g.rnd, organisationNouns),
+		pick(g.rnd, organisationIndustries),
+		pick(g.rnd, organisationLegalForms),
+	))
+	// A counter is added to a duplicate name (e.g. "Blue Harbor Logistics GmbH 2"), so email domains are unique
+	g.organisationNames[cmd.Name]++
+	if n := g.organisationNames[cmd.Name]; n > 1 {
+		cmd.Name = fmt.Sprintf("%s %d", cmd.Name, n)
+	}
+
+	err = cmd.Validate(g.config)
+	if err != nil {
+		return model.Organisation{}, errors.Wrap(err, "validating organisation")
+	}
+
+	createdAt := g.timeBetween(g.now.Add(-generatedTimeSpan), g.now)
+	return model.Organisation{
+		ID:        cmd.OrganisationID,
+		Name:      cmd.Name,
+		CreatedAt: createdAt,
+		UpdatedAt: g.timeBetween(createdAt, g.now),
+	}, nil
+}
+
+// Account generates an organisation administrator with an email address like "anna.schmidt@blue-harbor-logistics-gmbh.example"
+func (g *Generator) Account(organisation model.Organisation) (model.Account, error) {
+	firstName := pick(g.rnd, firstNames)
+	lastName := pick(g.rnd, lastNames)
+	domain := domainName(organisation.Name)
+	// A counter is added to the local part of a duplicate address (e.g. "anna.schmidt2@...")
+	localPart := strings.ToLower(firstName + "." + lastName)
+	g.emailAddresses[localPart+"@"+domain]++
+	if n := g.emailAddresses[localPart+"@"+domain]; n > 1 {
+		localPart = fmt.Sprintf("%s%d", localPart, n)
+	}
+	emailAddress := localPart + "@" + domain
+
+	cmd, err := command.NewAccountCreateCmd(emailAddress, types.RoleOrganisationAdministrator, DefaultPassword)
+	if err != nil {
+		return model.Account{}, err
+	}
+	cmd.AccountID = g.uuid()
+	cmd.OrganisationID = uuid.NullUUID{UUID: organisation.ID, Valid: true}
+
+	err = cmd.Validate(g.config)
+	if err != nil {
+		return model.Account{}, errors.Wrap(err, "validating account")
+	}
+
+	var account model.Account
+	if g.passwordHash == nil {
+		account, err = cmd.NewAccount(g.config)
+		if err != nil {
+			return model.Account{}, err
+		}
+		g.passwordHash = account.PasswordHash
+	} else {
+		secret, err := model.NewAccountSecret()
+		if err != nil {
+			return model.Account{}, errors.Wrap(err, "generating account secret")
+		}
+		account = model.Account{
+			ID:             cmd.AccountID,
+			EmailAddress:   cmd.EmailAddress,
+			Secret:         secret,
+			PasswordHash:   g.passwordHash,
+			Role:           cmd.Role,
+			OrganisationID: cmd.OrganisationID,
+		}
+	}
+
+	account.CreatedAt = g.timeBetween(organisation.CreatedAt, g.now)
+	account.UpdatedAt = g.timeBetween(account.CreatedAt, g.now)
+	// Some accounts never logged in
+	if g.rnd.Intn(10) < 7 {
+		lastLogin := g.timeBetween(account.CreatedAt, g.now)
+		account.LastLogin = &lastLogin
+	}
+	return account, nil
+}
+
+func (g *Generator) uuid() uuid.UUID {
+	var id uuid.UUID
+	_, _ = g.rnd.Read(id[:])
+	id.SetVersion(uuid.V4)
+	id.SetVariant(uuid.VariantRFC4122)
+	return id
+}
+
+func (g *Generator) timeBetween(from, to time.Time) time.Time {
+	d := to.Sub(from)
+	if d <= 0 {
+		return from
+	}
+	return from.Add(time.Duration(g.rnd.Int63n(int64(d)))).Truncate(time.Second)
+}
+
+func pick(rnd *rand.Rand, values []string) string {
+	return values[rnd.Intn(len(values))]
+}
+
+// domainName converts an organisation name to a domain, e.g. "Blue Harbor Logistics GmbH" to "blue-harbor-logistics-gmbh.example"
+func domainName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+	return strings.Join(words, "-") + ".example"
+}
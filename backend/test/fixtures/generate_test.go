@@ -0,0 +1,86 @@
+package fixtures_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"myvendor.mytld/myproject/backend/domain"
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/security/helper"
+	"myvendor.mytld/myproject/backend/test/fixtures"
+)
+
+func TestGenerator(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	config := domain.DefaultConfig()
+	config.HashCost = bcrypt.MinCost
+
+	generate := func(seed int64) ([]model.Organisation, []model.Account) {
+		g := fixtures.NewGenerator(seed, now, config)
+
+		var (
+			organisations []model.Organisation
+			accounts      []model.Account
+		)
+		for i := 0; i < 50; i++ {
+			organisation, err := g.Organisation()
+			require.NoError(t, err)
+			organisations = append(organisations, organisation)
+
+			for j := 0; j < 20; j++ {
+				account, err := g.Account(organisation)
+				require.NoError(t, err)
+				accounts = append(accounts, account)
+			}
+		}
+		return organisations, accounts
+	}
+
+	organisations, accounts := generate(42)
+
+	t.Run("same seed generates same data", func(t *testing.T) {
+		otherOrganisations, otherAccounts := generate(42)
+
+		assert.Equal(t, organisations, otherOrganisations)
+		require.Len(t, otherAccounts, len(accounts))
+		for i := range accounts {
+			assert.Equal(t, accounts[i].ID, otherAccounts[i].ID)
+			assert.Equal(t, accounts[i].EmailAddress, otherAccounts[i].EmailAddress)
+			assert.Equal(t, accounts[i].CreatedAt, otherAccounts[i].CreatedAt)
+			assert.Equal(t, accounts[i].LastLogin, otherAccounts[i].LastLogin)
+		}
+	})
+
+	t.Run("other seed generates other data", func(t *testing.T) {
+		otherOrganisations, _ := generate(43)
+
+		assert.NotEqual(t, organisations[0].ID, otherOrganisations[0].ID)
+	})
+
+	t.Run("unique names and email addresses", func(t *testing.T) {
+		names := make(map[string]bool)
+		for _, organisation := range organisations {
+			assert.False(t, names[organisation.Name], "duplicate name %s", organisation.Name)
+			names[organisation.Name] = true
+		}
+
+		emailAddresses := make(map[string]bool)
+		for _, account := range accounts {
+			assert.False(t, emailAddresses[account.EmailAddress], "duplicate email address %s", account.EmailAddress)
+			emailAddresses[account.EmailAddress] = true
+		}
+	})
+
+	t.Run("accounts can log in with default password", func(t *testing.T) {
+		for _, account := range []model.Account{accounts[0], accounts[len(accounts)-1]} {
+			assert.NoError(t, helper.CompareHashAndPassword(account.PasswordHash, []byte(fixtures.DefaultPassword)))
+			assert.Len(t, account.Secret, 32)
+			assert.True(t, account.OrganisationID.Valid)
+			assert.False(t, account.CreatedAt.After(now))
+		}
+	})
+}
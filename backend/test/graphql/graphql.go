@@ -21,6 +21,8 @@ import (
 	"myvendor.mytld/myproject/backend/domain"
 	"myvendor.mytld/myproject/backend/mail"
 	"myvendor.mytld/myproject/backend/mail/fixture"
+	"myvendor.mytld/myproject/backend/security/signedurl"
+	"myvendor.mytld/myproject/backend/storage/local"
 	"myvendor.mytld/myproject/backend/test"
 )
 
@@ -63,6 +65,18 @@ func SetTestDependencies(t *testing.T, deps *api.ResolverDependencies) {
 	if deps.MeterProvider == nil {
 		deps.MeterProvider = noop.NewMeterProvider()
 	}
+
+	if deps.Storage == nil {
+		fileStorage, err := local.NewStorage(t.TempDir())
+		if err != nil {
+			t.Fatalf("could not create storage: %v", err)
+		}
+		deps.Storage = fileStorage
+	}
+
+	if deps.AssetURLSigner == nil {
+		deps.AssetURLSigner = signedurl.NewSigner([]byte("test-secret"))
+	}
 }
 
 func Handle(t *testing.T, deps api.ResolverDependencies, req *http.Request, dst interface{}) *httptest.ResponseRecorder {
@@ -2,11 +2,13 @@ package graphql
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/apex/log"
 	graphql_ws "github.com/korylprince/go-graphql-ws"
@@ -15,6 +17,7 @@ import (
 	"myvendor.mytld/myproject/backend/api"
 	api_handler "myvendor.mytld/myproject/backend/api/handler"
 	http_api "myvendor.mytld/myproject/backend/api/http"
+	"myvendor.mytld/myproject/backend/persistence/notification"
 	test_auth "myvendor.mytld/myproject/backend/test/auth"
 )
 
@@ -22,6 +25,11 @@ func ServerAndSubscribe[T any](t *testing.T, deps api.ResolverDependencies, subs
 	t.Helper()
 	ctx := context.Background()
 
+	SetTestDependencies(t, &deps)
+	if deps.Notifications == nil {
+		deps.Notifications = startNotificationListener(t, deps.DB)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/query", nil)
 	require.NoError(t, err)
 	test_auth.ApplyFixedAuthValuesOrganisationAdministrator(t, deps.TimeSource, req)
@@ -81,6 +89,25 @@ func ServerAndSubscribe[T any](t *testing.T, deps api.ResolverDependencies, subs
 	return notifications
 }
 
+// startNotificationListener starts a listener for change notifications that is stopped after the test
+func startNotificationListener(t *testing.T, db *sql.DB) *notification.Listener {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	listener := notification.NewListener(db)
+	go listener.Run(ctx)
+
+	select {
+	case <-listener.Listening():
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification listener is not listening")
+	}
+
+	return listener
+}
+
 func httpToWs(t *testing.T, url string) string {
 	t.Helper()
 
@@ -0,0 +1,38 @@
+package test
+
+import (
+	"sync"
+	"time"
+)
+
+// SettableTimeSource is a time source for Now() that can be set or moved forward (e.g. to make jobs due)
+type SettableTimeSource struct {
+	mx  sync.Mutex
+	now time.Time
+}
+
+// NewSettableTimeSource returns a time source starting at the given time
+func NewSettableTimeSource(now time.Time) *SettableTimeSource {
+	return &SettableTimeSource{now: now}
+}
+
+// Now returns the current time of the time source
+func (sts *SettableTimeSource) Now() time.Time {
+	sts.mx.Lock()
+	defer sts.mx.Unlock()
+	return sts.now
+}
+
+// Set the current time
+func (sts *SettableTimeSource) Set(now time.Time) {
+	sts.mx.Lock()
+	defer sts.mx.Unlock()
+	sts.now = now
+}
+
+// Advance moves the current time forward by the given duration
+func (sts *SettableTimeSource) Advance(d time.Duration) {
+	sts.mx.Lock()
+	defer sts.mx.Unlock()
+	sts.now = sts.now.Add(d)
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"myvendor.mytld/myproject/backend/storage/s3"
+)
+
+const (
+	s3TestBucket          = "test-bucket"
+	s3TestAccessKeyID     = "test-access-key"
+	s3TestSecretAccessKey = "test-secret-key"
+)
+
+// S3Server is a minimal in-memory stand-in for an S3-compatible service that supports putting, getting and deleting objects
+type S3Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	objects map[string]s3Object
+}
+
+type s3Object struct {
+	content     []byte
+	contentType string
+}
+
+// NewS3Server starts a new S3 stand-in that is closed after the test
+func NewS3Server(t *testing.T) *S3Server {
+	t.Helper()
+
+	s := &S3Server{
+		objects: make(map[string]s3Object),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Config returns a storage config for accessing the server with path style
+func (s *S3Server) Config() s3.Config {
+	return s3.Config{
+		Endpoint:        s.URL,
+		Region:          "us-east-1",
+		Bucket:          s3TestBucket,
+		AccessKeyID:     s3TestAccessKeyID,
+		SecretAccessKey: s3TestSecretAccessKey,
+		UsePathStyle:    true,
+	}
+}
+
+// ObjectKeys returns the keys of all stored objects
+func (s *S3Server) ObjectKeys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (s *S3Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	// Signatures are not verified, but requests must be signed with the configured credentials
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential="+s3TestAccessKeyID+"/") || r.Header.Get("X-Amz-Date") == "" {
+		http.Error(w, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+		return
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != s3TestBucket {
+		http.Error(w, "<Error><Code>NoSuchBucket</Code></Error>", http.StatusNotFound)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		content, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		s.objects[key] = s3Object{
+			content:     content,
+			contentType: r.Header.Get("Content-Type"),
+		}
+	case http.MethodGet:
+		object, ok := s.objects[key]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", object.contentType)
+		_, _ = w.Write(object.content)
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
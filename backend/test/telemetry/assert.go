@@ -28,6 +28,41 @@ func SetupTestMeter(t *testing.T) (*sdkmetric.ManualReader, metric.MeterProvider
 func AssertMeterCounter(t *testing.T, reader sdkmetric.Reader, scope, name string, want int64) {
 	t.Helper()
 
+	metrics := collectMetrics(t, reader, scope, name)
+
+	agg, found := metrics.Data.(metricdata.Sum[int64])
+	if !found {
+		t.Fatalf("metrics for name %q is not a counter", name)
+	}
+	var sum int64
+	for _, dp := range agg.DataPoints {
+		sum += dp.Value
+	}
+
+	assert.Equal(t, want, sum, "sum of metric %q in scope %q", name, scope)
+}
+
+// AssertMeterHistogramCount asserts the number of recorded values of a histogram over all attributes
+func AssertMeterHistogramCount(t *testing.T, reader sdkmetric.Reader, scope, name string, want uint64) {
+	t.Helper()
+
+	metrics := collectMetrics(t, reader, scope, name)
+
+	agg, found := metrics.Data.(metricdata.Histogram[float64])
+	if !found {
+		t.Fatalf("metrics for name %q is not a float64 histogram", name)
+	}
+	var count uint64
+	for _, dp := range agg.DataPoints {
+		count += dp.Count
+	}
+
+	assert.Equal(t, want, count, "count of metric %q in scope %q", name, scope)
+}
+
+func collectMetrics(t *testing.T, reader sdkmetric.Reader, scope, name string) metricdata.Metrics {
+	t.Helper()
+
 	metricsData := metricdata.ResourceMetrics{}
 	err := reader.Collect(context.Background(), &metricsData)
 	require.NoError(t, err)
@@ -44,17 +79,7 @@ func AssertMeterCounter(t *testing.T, reader sdkmetric.Reader, scope, name strin
 	if !found {
 		t.Fatalf("metrics for name %q not found", name)
 	}
-
-	agg, found := metrics.Data.(metricdata.Sum[int64])
-	if !found {
-		t.Fatalf("metrics for name %q is not a counter", name)
-	}
-	var sum int64
-	for _, dp := range agg.DataPoints {
-		sum += dp.Value
-	}
-
-	assert.Equal(t, want, sum, "sum of metric %q in scope %q", name, scope)
+	return metrics
 }
 
 func find[T any](slice []T, predicate func(T) bool) (T, bool) {
@@ -0,0 +1,29 @@
+package webhook
+
+import (
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// newHTTPClient returns the default client for sending webhooks. Endpoint URLs are set by users, so connections are only
+// made to addresses allowed by the dial control (see netguard.DialControl) and redirects are not followed.
+// A proxy from the environment is not used, since it would connect to the endpoint without the check.
+func newHTTPClient(dialControl func(network, address string, c syscall.RawConn) error) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   defaultTimeout,
+		KeepAlive: 30 * time.Second,
+		Control:   dialControl,
+	}).DialContext
+
+	return &http.Client{
+		Timeout:   defaultTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
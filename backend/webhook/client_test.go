@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/security/netguard"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	t.Run("rejects non-public addresses", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, nil)
+		require.NoError(t, err)
+
+		_, err = newHTTPClient(netguard.DialControl).Do(req)
+		require.ErrorIs(t, err, netguard.ErrForbiddenAddress)
+		assert.Equal(t, 0, requests)
+	})
+
+	t.Run("does not follow redirects", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/redirect", nil)
+		require.NoError(t, err)
+
+		resp, err := newHTTPClient(nil).Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusFound, resp.StatusCode)
+		assert.Equal(t, 1, requests)
+	})
+}
@@ -0,0 +1,298 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	logger "github.com/apex/log"
+	"github.com/friendsofgo/errors"
+	"github.com/gofrs/uuid"
+	"github.com/networkteam/qrb/qrbsql"
+
+	"myvendor.mytld/myproject/backend/domain/event"
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/jobqueue"
+	"myvendor.mytld/myproject/backend/persistence/outbox"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/security/netguard"
+)
+
+// MaxAttempts is the number of attempts for a delivery before it is marked as failed
+const MaxAttempts = 8
+
+const (
+	defaultTimeout = 10 * time.Second
+	userAgent      = "myproject-webhook/1"
+	// Only the beginning of a response body is read to reuse the connection
+	maxDrainBodySize = 1024
+)
+
+// DeliverJob sends a webhook delivery, it is retried with backoff by the job queue
+type DeliverJob struct {
+	WebhookDeliveryID uuid.UUID `json:"webhookDeliveryId"`
+}
+
+func (DeliverJob) JobType() string {
+	return "webhookDeliver"
+}
+
+// Enqueue adds a job for sending a delivery, it is skipped if a job for the delivery is already pending
+func Enqueue(ctx context.Context, executor qrbsql.Executor, now time.Time, deliveryID uuid.UUID) error {
+	_, err := jobqueue.Enqueue(ctx, executor, now, DeliverJob{WebhookDeliveryID: deliveryID},
+		jobqueue.UniqueKey(deliveryID.String()),
+		jobqueue.MaxAttempts(MaxAttempts),
+	)
+	return err
+}
+
+// Service records deliveries for domain events and sends them to the webhook endpoints of organisations
+type Service struct {
+	db         *sql.DB
+	timeSource types.TimeSource
+	client     *http.Client
+}
+
+type Option func(s *Service)
+
+// WithHTTPClient sets the client for sending requests, it should have a timeout.
+// It replaces the default client that only connects to public addresses.
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *Service) {
+		s.client = client
+	}
+}
+
+func NewService(db *sql.DB, timeSource types.TimeSource, opts ...Option) *Service {
+	s := &Service{
+		db:         db,
+		timeSource: timeSource,
+		client:     newHTTPClient(netguard.DialControl),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Subscribe creates deliveries for events from the outbox
+func (s *Service) Subscribe(dispatcher *outbox.Dispatcher) {
+	dispatcher.Subscribe("webhooks", s.handleEvent)
+}
+
+// Register registers the handler for sending deliveries at a job worker
+func (s *Service) Register(worker *jobqueue.Worker) {
+	jobqueue.Register(worker, s.Deliver)
+}
+
+// payload is the request body of a webhook request
+type payload struct {
+	ID         uuid.UUID   `json:"id"`
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	Data       event.Event `json:"data"`
+}
+
+func (s *Service) handleEvent(ctx context.Context, e event.Event) error {
+	organisationID, ok := organisationIDOf(e)
+	if !ok {
+		return nil
+	}
+	metadata, ok := outbox.MetadataFromContext(ctx)
+	if !ok {
+		return errors.New("missing outbox metadata")
+	}
+
+	eventType := e.EventType()
+	endpoints, err := repository.FindAllWebhookEndpoints(ctx, s.db, repository.WebhookEndpointsFilter{
+		OrganisationID: &organisationID,
+		EventType:      &eventType,
+	})
+	if err != nil {
+		return errors.Wrap(err, "finding webhook endpoints")
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(payload{
+		ID:         metadata.EventID,
+		Type:       eventType,
+		OccurredAt: metadata.OccurredAt,
+		Data:       e,
+	})
+	if err != nil {
+		return errors.Wrap(err, "encoding payload")
+	}
+
+	return repository.Transactional(ctx, s.db, func(tx *sql.Tx) error {
+		now := s.timeSource.Now()
+		for _, endpoint := range endpoints {
+			deliveryID, err := uuid.NewV7()
+			if err != nil {
+				return errors.Wrap(err, "generating delivery id")
+			}
+
+			inserted, err := repository.InsertWebhookDelivery(ctx, tx, repository.WebhookDeliveryToChangeSet(model.WebhookDelivery{
+				ID:                deliveryID,
+				WebhookEndpointID: endpoint.ID,
+				OrganisationID:    organisationID,
+				EventID:           metadata.EventID,
+				EventType:         eventType,
+				Payload:           body,
+				Status:            model.WebhookDeliveryStatusPending,
+				CreatedAt:         now,
+			}))
+			if err != nil {
+				return errors.Wrap(err, "inserting webhook delivery")
+			}
+			// The event was already handled for this endpoint
+			if !inserted {
+				continue
+			}
+
+			err = Enqueue(ctx, tx, now, deliveryID)
+			if err != nil {
+				return errors.Wrap(err, "enqueueing webhook delivery")
+			}
+		}
+		return nil
+	})
+}
+
+// organisationIDOf returns the organisation that receives webhooks for an event.
+// Events of accounts without an organisation (system administrators) are not sent.
+func organisationIDOf(e event.Event) (uuid.UUID, bool) {
+	switch e := e.(type) {
+	case event.AccountCreated:
+		return e.OrganisationID.UUID, e.OrganisationID.Valid
+	case event.AccountUpdated:
+		return e.OrganisationID.UUID, e.OrganisationID.Valid
+	case event.AccountDeleted:
+		return e.OrganisationID.UUID, e.OrganisationID.Valid
+	case event.AccountRestored:
+		return e.OrganisationID.UUID, e.OrganisationID.Valid
+	case event.OrganisationCreated:
+		return e.OrganisationID, true
+	case event.OrganisationUpdated:
+		return e.OrganisationID, true
+	case event.OrganisationDeleted:
+		return e.OrganisationID, true
+	case event.OrganisationRestored:
+		return e.OrganisationID, true
+	default:
+		return uuid.Nil, false
+	}
+}
+
+// Deliver sends a delivery to its endpoint and records the result of the attempt.
+// An error is returned for a failed attempt, so the job is retried.
+func (s *Service) Deliver(ctx context.Context, job DeliverJob) error {
+	delivery, err := repository.FindWebhookDeliveryByID(ctx, s.db, job.WebhookDeliveryID)
+	// The delivery is gone if the endpoint was deleted in the meantime
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "finding webhook delivery")
+	}
+	if delivery.Status != model.WebhookDeliveryStatusPending {
+		return nil
+	}
+
+	endpoint, err := repository.FindWebhookEndpointByID(ctx, s.db, delivery.WebhookEndpointID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "finding webhook endpoint")
+	}
+
+	attemptedAt := s.timeSource.Now()
+	responseStatus, sendErr := s.send(ctx, endpoint, delivery, attemptedAt)
+
+	attempts := delivery.Attempts + 1
+	lastAttemptAt := &attemptedAt
+	var responseStatusPtr *int
+	if responseStatus != 0 {
+		responseStatusPtr = &responseStatus
+	}
+	changeSet := repository.WebhookDeliveryChangeSet{
+		Attempts:       &attempts,
+		ResponseStatus: &responseStatusPtr,
+		LastAttemptAt:  &lastAttemptAt,
+	}
+	if sendErr == nil {
+		status := model.WebhookDeliveryStatusSucceeded
+		deliveredAt := &attemptedAt
+		var lastError *string
+		changeSet.Status = &status
+		changeSet.DeliveredAt = &deliveredAt
+		changeSet.LastError = &lastError
+	} else {
+		lastError := sendErr.Error()
+		lastErrorPtr := &lastError
+		changeSet.LastError = &lastErrorPtr
+		if attempts >= MaxAttempts {
+			status := model.WebhookDeliveryStatusFailed
+			changeSet.Status = &status
+		}
+	}
+
+	// The result must be stored even if the worker is shutting down
+	err = repository.UpdateWebhookDelivery(context.WithoutCancel(ctx), s.db, delivery.ID, changeSet)
+	if err != nil {
+		return errors.Wrap(err, "updating webhook delivery")
+	}
+
+	if sendErr != nil {
+		logger.FromContext(ctx).
+			WithField("component", "webhook").
+			WithField("webhookDeliveryID", delivery.ID).
+			WithField("webhookEndpointID", endpoint.ID).
+			WithField("attempts", attempts).
+			WithError(sendErr).
+			Warn("Could not deliver webhook")
+		// A failed delivery is not retried anymore, it can be redelivered manually
+		if attempts >= MaxAttempts {
+			return nil
+		}
+		return sendErr
+	}
+
+	return nil
+}
+
+// send posts the payload of a delivery and returns the response status code (0 if no response was received)
+func (s *Service) send(ctx context.Context, endpoint model.WebhookEndpoint, delivery model.WebhookDelivery, now time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, errors.Wrap(err, "building request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderID, delivery.EventID.String())
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(endpoint.Secret, now, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "sending request")
+	}
+	defer resp.Body.Close()
+
+	// Drain the body to reuse the connection, it is not stored since it could expose responses of internal services
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBodySize))
+
+	// Redirects are not followed and count as failed attempts
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, errors.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
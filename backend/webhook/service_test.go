@@ -0,0 +1,275 @@
+package webhook_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"myvendor.mytld/myproject/backend/domain/event"
+	"myvendor.mytld/myproject/backend/domain/model"
+	"myvendor.mytld/myproject/backend/domain/types"
+	"myvendor.mytld/myproject/backend/persistence/jobqueue"
+	"myvendor.mytld/myproject/backend/persistence/outbox"
+	"myvendor.mytld/myproject/backend/persistence/repository"
+	"myvendor.mytld/myproject/backend/test"
+	test_db "myvendor.mytld/myproject/backend/test/db"
+	"myvendor.mytld/myproject/backend/webhook"
+)
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// receiver records requests to a test server and responds with a configurable status code
+type receiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []receivedRequest
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, receivedRequest{header: req.Header.Clone(), body: body})
+	w.WriteHeader(r.status)
+	_, _ = w.Write([]byte("receiver response"))
+}
+
+var acmeOrganisationID = uuid.Must(uuid.FromString("6330de58-2761-411e-a243-bec6d0c53876"))
+
+func setupWebhookTest(t *testing.T, receiverURL string, eventTypes []string) (*sql.DB, *test.SettableTimeSource, *outbox.Dispatcher, *jobqueue.Worker, model.WebhookEndpoint) {
+	t.Helper()
+
+	ctx := context.Background()
+	db := test_db.CreateTestDatabaseWithFixtures(t, "base")
+	timeSource := test.NewSettableTimeSource(test.FixedTime().Now())
+
+	endpoint := model.WebhookEndpoint{
+		ID:             uuid.Must(uuid.NewV7()),
+		OrganisationID: acmeOrganisationID,
+		URL:            receiverURL,
+		Secret:         "whsec_test",
+		EventTypes:     eventTypes,
+	}
+	err := repository.InsertWebhookEndpoint(ctx, db, repository.WebhookEndpointToChangeSet(endpoint))
+	require.NoError(t, err)
+
+	// The default client does not connect to the test server on a loopback address
+	service := webhook.NewService(db, timeSource, webhook.WithHTTPClient(&http.Client{Timeout: time.Second}))
+
+	dispatcher := outbox.NewDispatcher(db, timeSource)
+	service.Subscribe(dispatcher)
+
+	worker := jobqueue.NewWorker(db, timeSource, jobqueue.WithBackoff(time.Minute, time.Minute))
+	service.Register(worker)
+
+	return db, timeSource, dispatcher, worker, endpoint
+}
+
+func appendEvents(t *testing.T, db *sql.DB, now time.Time, events ...event.Event) {
+	t.Helper()
+
+	ctx := context.Background()
+	err := repository.Transactional(ctx, db, func(tx *sql.Tx) error {
+		return outbox.Append(ctx, tx, now, events...)
+	})
+	require.NoError(t, err)
+}
+
+func findDeliveries(t *testing.T, db *sql.DB, endpointID uuid.UUID) []model.WebhookDelivery {
+	t.Helper()
+
+	deliveries, err := repository.FindAllWebhookDeliveries(context.Background(), db, repository.WebhookDeliveriesFilter{
+		WebhookEndpointID: &endpointID,
+	})
+	require.NoError(t, err)
+	return deliveries
+}
+
+func TestService_Deliver(t *testing.T) {
+	ctx := context.Background()
+	recv := &receiver{status: http.StatusNoContent}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	db, timeSource, dispatcher, worker, endpoint := setupWebhookTest(t, server.URL+"/hooks", []string{event.TypeAccountCreated})
+
+	accountID := uuid.Must(uuid.FromString("0191b7e4-7d3a-7b5e-9c1a-2f0c6c1e4a11"))
+	appendEvents(t, db, timeSource.Now(),
+		event.AccountCreated{
+			AccountID:      accountID,
+			OrganisationID: uuid.NullUUID{UUID: acmeOrganisationID, Valid: true},
+			EmailAddress:   "new@example.com",
+			Role:           types.RoleOrganisationAdministrator,
+		},
+		// Not subscribed by the endpoint
+		event.OrganisationUpdated{OrganisationID: acmeOrganisationID, Name: "Acme Corp."},
+		// Events of other organisations are not sent
+		event.AccountCreated{
+			AccountID:      uuid.Must(uuid.NewV7()),
+			OrganisationID: uuid.NullUUID{UUID: uuid.Must(uuid.NewV7()), Valid: true},
+			EmailAddress:   "other@example.com",
+			Role:           types.RoleOrganisationAdministrator,
+		},
+	)
+
+	_, err := dispatcher.DispatchPending(ctx)
+	require.NoError(t, err)
+
+	deliveries := findDeliveries(t, db, endpoint.ID)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, event.TypeAccountCreated, deliveries[0].EventType)
+	assert.Equal(t, model.WebhookDeliveryStatusPending, deliveries[0].Status)
+
+	worked, err := worker.Work(ctx)
+	require.NoError(t, err)
+	require.True(t, worked)
+
+	require.Len(t, recv.requests, 1)
+	req := recv.requests[0]
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	assert.Equal(t, deliveries[0].EventID.String(), req.header.Get(webhook.HeaderID))
+	assert.Equal(t, event.TypeAccountCreated, req.header.Get(webhook.HeaderEvent))
+	err = webhook.Verify(endpoint.Secret, req.header.Get(webhook.HeaderTimestamp), req.header.Get(webhook.HeaderSignature), req.body, timeSource.Now(), time.Minute)
+	assert.NoError(t, err, "signature should be valid")
+
+	var body struct {
+		ID   uuid.UUID
+		Type string
+		Data event.AccountCreated
+	}
+	require.NoError(t, json.Unmarshal(req.body, &body))
+	assert.Equal(t, deliveries[0].EventID, body.ID)
+	assert.Equal(t, event.TypeAccountCreated, body.Type)
+	assert.Equal(t, accountID, body.Data.AccountID)
+
+	deliveries = findDeliveries(t, db, endpoint.ID)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, model.WebhookDeliveryStatusSucceeded, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, ptr(http.StatusNoContent), deliveries[0].ResponseStatus)
+	assert.NotNil(t, deliveries[0].DeliveredAt)
+	assert.Nil(t, deliveries[0].LastError)
+
+	// No job is left for the delivery
+	worked, err = worker.Work(ctx)
+	require.NoError(t, err)
+	assert.False(t, worked)
+}
+
+func TestService_Deliver_Retry(t *testing.T) {
+	ctx := context.Background()
+	recv := &receiver{status: http.StatusServiceUnavailable}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	db, timeSource, dispatcher, worker, endpoint := setupWebhookTest(t, server.URL, []string{event.TypeOrganisationUpdated})
+
+	appendEvents(t, db, timeSource.Now(), event.OrganisationUpdated{OrganisationID: acmeOrganisationID, Name: "Acme Corp."})
+	_, err := dispatcher.DispatchPending(ctx)
+	require.NoError(t, err)
+
+	for attempt := 1; attempt <= webhook.MaxAttempts; attempt++ {
+		worked, err := worker.Work(ctx)
+		require.NoError(t, err)
+		require.True(t, worked, "attempt %d should run", attempt)
+
+		deliveries := findDeliveries(t, db, endpoint.ID)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, attempt, deliveries[0].Attempts)
+		assert.Equal(t, ptr(http.StatusServiceUnavailable), deliveries[0].ResponseStatus)
+		require.NotNil(t, deliveries[0].LastError)
+		// The response body is not stored
+		assert.Equal(t, "unexpected response status 503", *deliveries[0].LastError)
+		if attempt < webhook.MaxAttempts {
+			assert.Equal(t, model.WebhookDeliveryStatusPending, deliveries[0].Status)
+		} else {
+			assert.Equal(t, model.WebhookDeliveryStatusFailed, deliveries[0].Status)
+		}
+
+		timeSource.Advance(time.Minute)
+	}
+
+	// A failed delivery is not retried anymore
+	worked, err := worker.Work(ctx)
+	require.NoError(t, err)
+	assert.False(t, worked)
+
+	// All attempts sent the same event and payload
+	require.Len(t, recv.requests, webhook.MaxAttempts)
+	for _, req := range recv.requests {
+		assert.Equal(t, recv.requests[0].header.Get(webhook.HeaderID), req.header.Get(webhook.HeaderID))
+		assert.Equal(t, recv.requests[0].body, req.body)
+	}
+
+	// Redelivery sends the delivery again after the receiver is fixed
+	recv.status = http.StatusOK
+	deliveryID := findDeliveries(t, db, endpoint.ID)[0].ID
+	err = repository.Transactional(ctx, db, func(tx *sql.Tx) error {
+		status := model.WebhookDeliveryStatusPending
+		attempts := 0
+		err := repository.UpdateWebhookDelivery(ctx, tx, deliveryID, repository.WebhookDeliveryChangeSet{
+			Status:   &status,
+			Attempts: &attempts,
+		})
+		if err != nil {
+			return err
+		}
+		return webhook.Enqueue(ctx, tx, timeSource.Now(), deliveryID)
+	})
+	require.NoError(t, err)
+
+	worked, err = worker.Work(ctx)
+	require.NoError(t, err)
+	require.True(t, worked)
+
+	deliveries := findDeliveries(t, db, endpoint.ID)
+	assert.Equal(t, model.WebhookDeliveryStatusSucceeded, deliveries[0].Status)
+	assert.Equal(t, ptr(http.StatusOK), deliveries[0].ResponseStatus)
+}
+
+func TestService_HandleEvent_Idempotent(t *testing.T) {
+	ctx := context.Background()
+	recv := &receiver{status: http.StatusOK}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	db, timeSource, dispatcher, _, endpoint := setupWebhookTest(t, server.URL, []string{event.TypeOrganisationUpdated})
+
+	appendEvents(t, db, timeSource.Now(), event.OrganisationUpdated{OrganisationID: acmeOrganisationID, Name: "Acme Corp."})
+
+	// Another subscriber fails, so the event is delivered to all subscribers again
+	failures := 1
+	dispatcher.Subscribe("flaky", func(context.Context, event.Event) error {
+		if failures > 0 {
+			failures--
+			return assert.AnError
+		}
+		return nil
+	})
+
+	_, err := dispatcher.DispatchPending(ctx)
+	require.NoError(t, err)
+	timeSource.Advance(time.Hour)
+	_, err = dispatcher.DispatchPending(ctx)
+	require.NoError(t, err)
+
+	assert.Len(t, findDeliveries(t, db, endpoint.ID), 1)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
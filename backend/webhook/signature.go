@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	std_errors "errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers of a webhook request
+const (
+	// HeaderID is the ID of the event, it is the same for all attempts and can be used by receivers to skip duplicates
+	HeaderID = "Webhook-Id"
+	// HeaderEvent is the type of the event
+	HeaderEvent = "Webhook-Event"
+	// HeaderTimestamp is the time of the attempt as Unix seconds, it is part of the signature to prevent replays
+	HeaderTimestamp = "Webhook-Timestamp"
+	// HeaderSignature is the HMAC-SHA256 signature of timestamp and body in the format "v1=<hex>"
+	HeaderSignature = "Webhook-Signature"
+)
+
+const signatureVersion = "v1"
+
+var (
+	ErrInvalidSignature = std_errors.New("invalid webhook signature")
+	ErrInvalidTimestamp = std_errors.New("invalid webhook timestamp")
+)
+
+// Sign builds the signature header value for a request body sent at timestamp.
+// The signed content is "<unix seconds>.<body>", so a signature cannot be reused with another timestamp.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	return signatureVersion + "=" + hex.EncodeToString(mac(secret, timestamp.Unix(), body))
+}
+
+// Verify checks the signature and timestamp header values of a received request.
+// Requests with a timestamp that differs more than tolerance from now are rejected.
+// Receivers written in Go can use it, it also documents the verification for other implementations.
+func Verify(secret string, timestampHeader, signatureHeader string, body []byte, now time.Time, tolerance time.Duration) error {
+	unix, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	if diff := now.Sub(time.Unix(unix, 0)); diff > tolerance || diff < -tolerance {
+		return ErrInvalidTimestamp
+	}
+
+	expected := mac(secret, unix, body)
+	// Multiple signatures can be sent separated by spaces (e.g. while rotating secrets)
+	for _, signature := range strings.Fields(signatureHeader) {
+		version, value, ok := strings.Cut(signature, "=")
+		if !ok || version != signatureVersion {
+			continue
+		}
+		actual, err := hex.DecodeString(value)
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(actual, expected) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+func mac(secret string, unix int64, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(strconv.FormatInt(unix, 10)))
+	h.Write([]byte("."))
+	h.Write(body)
+	return h.Sum(nil)
+}
@@ -0,0 +1,112 @@
+package webhook_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"myvendor.mytld/myproject/backend/webhook"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	secret := "s3cr3t"
+	body := []byte(`{"id":"0191b7e4-7d3a-7b5e-9c1a-2f0c6c1e4a11","type":"AccountCreated"}`)
+	sentAt := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+
+	signature := webhook.Sign(secret, sentAt, body)
+	assert.Regexp(t, `^v1=[0-9a-f]{64}$`, signature)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		signature string
+		body      []byte
+		now       time.Time
+		expectErr error
+	}{
+		{
+			name:      "valid",
+			secret:    secret,
+			timestamp: timestamp,
+			signature: signature,
+			body:      body,
+			now:       sentAt.Add(time.Minute),
+		},
+		{
+			name:      "valid with multiple signatures",
+			secret:    secret,
+			timestamp: timestamp,
+			signature: "v1=00ff " + signature,
+			body:      body,
+			now:       sentAt,
+		},
+		{
+			name:      "wrong secret",
+			secret:    "other",
+			timestamp: timestamp,
+			signature: signature,
+			body:      body,
+			now:       sentAt,
+			expectErr: webhook.ErrInvalidSignature,
+		},
+		{
+			name:      "modified body",
+			secret:    secret,
+			timestamp: timestamp,
+			signature: signature,
+			body:      []byte(`{"id":"0191b7e4-7d3a-7b5e-9c1a-2f0c6c1e4a11","type":"AccountDeleted"}`),
+			now:       sentAt,
+			expectErr: webhook.ErrInvalidSignature,
+		},
+		{
+			name:      "modified timestamp",
+			secret:    secret,
+			timestamp: strconv.FormatInt(sentAt.Unix()+1, 10),
+			signature: signature,
+			body:      body,
+			now:       sentAt,
+			expectErr: webhook.ErrInvalidSignature,
+		},
+		{
+			name:      "unknown version",
+			secret:    secret,
+			timestamp: timestamp,
+			signature: "v0=" + signature[3:],
+			body:      body,
+			now:       sentAt,
+			expectErr: webhook.ErrInvalidSignature,
+		},
+		{
+			name:      "timestamp outside tolerance",
+			secret:    secret,
+			timestamp: timestamp,
+			signature: signature,
+			body:      body,
+			now:       sentAt.Add(10 * time.Minute),
+			expectErr: webhook.ErrInvalidTimestamp,
+		},
+		{
+			name:      "invalid timestamp",
+			secret:    secret,
+			timestamp: "yesterday",
+			signature: signature,
+			body:      body,
+			now:       sentAt,
+			expectErr: webhook.ErrInvalidTimestamp,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := webhook.Verify(tt.secret, tt.timestamp, tt.signature, tt.body, tt.now, 5*time.Minute)
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}